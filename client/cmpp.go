@@ -0,0 +1,247 @@
+package client
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/consts"
+)
+
+// ErrAuthISMG indicates that the AuthenticatorISMG in CMPP_CONNECT_RESP does not match the password.
+var ErrAuthISMG = errors.New("invalid AuthenticatorISMG")
+
+// CMPPResultError is returned when the ISMG answers a request with a non-zero Status or Result.
+type CMPPResultError struct {
+	Command cmpp.CommandID
+	Result  uint32
+}
+
+func (e *CMPPResultError) Error() string {
+	return fmt.Sprintf("%s result: %d", e.Command.String(), e.Result)
+}
+
+// CMPPConfig is the configuration of a CMPP SP client.
+type CMPPConfig struct {
+	// Addr is the ISMG address, e.g. "127.0.0.1:7890".
+	Addr string
+
+	// Version is consts.CMPPVersion2_0 or consts.CMPPVersion3_0. Defaults to consts.CMPPVersion2_0.
+	Version consts.CMPPVersion
+
+	// SourceAddr is the SP account (6 bytes).
+	SourceAddr string
+	Password   string
+
+	// SkipAuthISMG disables the validation of AuthenticatorISMG in CMPP_CONNECT_RESP.
+	// Some gateways leave it empty.
+	SkipAuthISMG bool
+
+	// OnDeliver is called for every CMPP_DELIVER, both MO and status report (RegisteredDeliver=1).
+	// pdu is a *cmpp20.PduDeliver or a *cmpp30.Deliver according to Version.
+	// The returned value is sent back as the Result of CMPP_DELIVER_RESP. If nil, 0 is returned.
+	OnDeliver func(ctx context.Context, pdu protocol.PDU) uint32
+}
+
+// CMPPClient is a CMPP 2.0/3.0 SP client.
+// Requests are matched with responses by sequence ID, CMPP_ACTIVE_TEST is sent automatically
+// when the session is idle and answered when received, and CMPP_DELIVER is passed to CMPPConfig.OnDeliver.
+type CMPPClient struct {
+	cfg    CMPPConfig
+	opts   *options
+	keeper *keeper
+}
+
+// DialCMPP connects to the ISMG and sends CMPP_CONNECT.
+func DialCMPP(ctx context.Context, cfg CMPPConfig, opts ...Option) (*CMPPClient, error) {
+	if cfg.Version == 0 {
+		cfg.Version = consts.CMPPVersion2_0
+	}
+	if cfg.Version != consts.CMPPVersion2_0 && cfg.Version != consts.CMPPVersion3_0 {
+		return nil, fmt.Errorf("unsupported cmpp version: %s", cfg.Version.String())
+	}
+
+	c := &CMPPClient{cfg: cfg, opts: newOptions(opts...)}
+	c.keeper = newKeeper(c.opts, c.connect)
+	if err := c.keeper.start(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *CMPPClient) is30() bool {
+	return c.cfg.Version == consts.CMPPVersion3_0
+}
+
+func (c *CMPPClient) connect(ctx context.Context) (*session, error) {
+	decode := cmpp20.DecodeCMPP20
+	if c.is30() {
+		decode = cmpp30.DecodeCMPP30
+	}
+	return dial(ctx, c.cfg.Addr, codec.NewCMPPCodec(c.opts.codecOptions()...), decode, c.opts, c.setup, c.login)
+}
+
+func (c *CMPPClient) setup(s *session) {
+	s.handle = c.handle
+	s.heartbeat = func() protocol.PDU {
+		if c.is30() {
+			return &cmpp30.ActiveTest{Header: cmpp.Header{CommandID: cmpp.CommandActiveTest}}
+		}
+		return &cmpp20.PduActiveTest{Header: cmpp.Header{CommandID: cmpp.CommandActiveTest}}
+	}
+	s.unbind = func() protocol.PDU {
+		if c.is30() {
+			return &cmpp30.Terminate{Header: cmpp.Header{CommandID: cmpp.CommandTerminate}}
+		}
+		return &cmpp20.PduTerminate{Header: cmpp.Header{CommandID: cmpp.CommandTerminate}}
+	}
+}
+
+// login sends CMPP_CONNECT and validates CMPP_CONNECT_RESP.
+func (c *CMPPClient) login(ctx context.Context, s *session) error {
+	tsStr, ts := cmpp.GenConnectTimestamp(nil)
+	auth := string(cmpp.GenConnectAuth(c.cfg.SourceAddr, c.cfg.Password, tsStr))
+
+	var req protocol.PDU
+	if c.is30() {
+		req = &cmpp30.Connect{
+			Header:              cmpp.Header{CommandID: cmpp.CommandConnect},
+			SourceAddr:          c.cfg.SourceAddr,
+			AuthenticatorSource: auth,
+			Version:             cmpp.Version30,
+			Timestamp:           ts,
+		}
+	} else {
+		req = &cmpp20.PduConnect{
+			Header:              cmpp.Header{CommandID: cmpp.CommandConnect},
+			SourceAddr:          c.cfg.SourceAddr,
+			AuthenticatorSource: auth,
+			Version:             cmpp.Version20,
+			Timestamp:           ts,
+		}
+	}
+
+	resp, err := s.request(ctx, req)
+	if err != nil {
+		return fmt.Errorf("connect error: %w", err)
+	}
+
+	// Status is 1 byte in CMPP 2.0 and 4 bytes in CMPP 3.0,
+	// and AuthenticatorISMG = MD5(Status + AuthenticatorSource + password).
+	var status uint32
+	var statusBytes []byte
+	var authISMG string
+	switch r := resp.(type) {
+	case *cmpp20.PduConnectResp:
+		status = uint32(r.Status)
+		statusBytes = []byte{uint8(r.Status)}
+		authISMG = r.AuthenticatorISMG
+	case *cmpp30.ConnectResp:
+		status = r.Status
+		statusBytes = make([]byte, 4)
+		binary.BigEndian.PutUint32(statusBytes, r.Status)
+		authISMG = r.AuthenticatorISMG
+	default:
+		return fmt.Errorf("connect error: unexpected response %s", resp.GetCommand().String())
+	}
+
+	if status != 0 {
+		return fmt.Errorf("connect error: %w", &CMPPResultError{Command: cmpp.CommandConnectResp, Result: status})
+	}
+	if c.cfg.SkipAuthISMG {
+		return nil
+	}
+
+	expected := cmpp.GenConnectRespAuthISMG(statusBytes, auth, c.cfg.Password)
+	if subtle.ConstantTimeCompare(expected, []byte(authISMG)) != 1 {
+		return fmt.Errorf("connect error: %w", ErrAuthISMG)
+	}
+	return nil
+}
+
+// handle answers the requests initiated by the ISMG.
+func (c *CMPPClient) handle(ctx context.Context, s *session, p protocol.PDU) protocol.PDU {
+	switch pdu := p.(type) {
+	case *cmpp20.PduDeliver:
+		resp := pdu.GenEmptyResponse().(*cmpp20.PduDeliverResp)
+		resp.MsgID = pdu.MsgID
+		if c.cfg.OnDeliver != nil {
+			resp.Result = uint8(c.cfg.OnDeliver(ctx, pdu))
+		}
+		return resp
+	case *cmpp30.Deliver:
+		resp := pdu.GenEmptyResponse().(*cmpp30.DeliverResp)
+		resp.MsgID = pdu.MsgID
+		if c.cfg.OnDeliver != nil {
+			resp.Result = c.cfg.OnDeliver(ctx, pdu)
+		}
+		return resp
+	default:
+		// CMPP_ACTIVE_TEST, CMPP_TERMINATE and others.
+		// After CMPP_TERMINATE_RESP is sent the ISMG closes the connection.
+		return p.GenEmptyResponse()
+	}
+}
+
+// Submit sends a CMPP_SUBMIT and waits for its CMPP_SUBMIT_RESP.
+// pdu must be a *cmpp20.PduSubmit or a *cmpp30.Submit according to CMPPConfig.Version.
+// A non-zero Result is returned as a *CMPPResultError.
+func (c *CMPPClient) Submit(ctx context.Context, pdu protocol.PDU) (msgID uint64, err error) {
+	switch p := pdu.(type) {
+	case *cmpp20.PduSubmit:
+		if c.is30() {
+			return 0, fmt.Errorf("submit %T on cmpp %s", pdu, c.cfg.Version.String())
+		}
+		p.Header.CommandID = cmpp.CommandSubmit
+	case *cmpp30.Submit:
+		if !c.is30() {
+			return 0, fmt.Errorf("submit %T on cmpp %s", pdu, c.cfg.Version.String())
+		}
+		p.Header.CommandID = cmpp.CommandSubmit
+	default:
+		return 0, fmt.Errorf("invalid submit pdu: %T", pdu)
+	}
+
+	resp, err := c.Request(ctx, pdu)
+	if err != nil {
+		return 0, err
+	}
+
+	var result uint32
+	switch r := resp.(type) {
+	case *cmpp20.PduSubmitResp:
+		msgID, result = r.MsgID, uint32(r.Result)
+	case *cmpp30.SubmitResp:
+		msgID, result = r.MsgID, r.Result
+	default:
+		return 0, fmt.Errorf("unexpected response %s", resp.GetCommand().String())
+	}
+	if result != 0 {
+		return msgID, &CMPPResultError{Command: cmpp.CommandSubmitResp, Result: result}
+	}
+	return msgID, nil
+}
+
+// Request sends any request PDU and waits for the response with the same sequence ID.
+// The sequence ID of pdu is overwritten.
+func (c *CMPPClient) Request(ctx context.Context, pdu protocol.PDU) (protocol.PDU, error) {
+	s, err := c.keeper.session()
+	if err != nil {
+		return nil, err
+	}
+	return s.request(ctx, pdu)
+}
+
+// Close sends CMPP_TERMINATE and closes the connection. Reconnecting is stopped.
+func (c *CMPPClient) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.requestTimeout)
+	defer cancel()
+	return c.keeper.close(ctx)
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/consts"
+)
+
+// ismgHandler answers connect, submit, active test and terminate for both CMPP versions.
+// The submit response carries the sequence ID as MsgID. If badAuth is set, AuthenticatorISMG only matches up to an embedded 0x00.
+// AuthenticatorSource is recomputed from the connect, the decoded one is cut at the first NUL byte of the digest.
+func ismgHandler(password string, badAuth bool, events chan<- protocol.PDU) func(c *fakeConn, p protocol.PDU) {
+	return func(c *fakeConn, p protocol.PDU) {
+		select {
+		case events <- p:
+		default:
+		}
+		switch pdu := p.(type) {
+		case *cmpp20.PduConnect:
+			resp := pdu.GenEmptyResponse().(*cmpp20.PduConnectResp)
+			resp.Version = cmpp.Version20
+			auth := cmpp.GenConnectAuth(pdu.SourceAddr, password, cmpp.TimeStamp2Str(pdu.Timestamp))
+			resp.AuthenticatorISMG = badAuthISMG(cmpp.GenConnectRespAuthISMG([]byte{0}, string(auth), password), badAuth)
+			c.send(resp)
+		case *cmpp30.Connect:
+			resp := pdu.GenEmptyResponse().(*cmpp30.ConnectResp)
+			resp.Version = cmpp.Version30
+			auth := cmpp.GenConnectAuth(pdu.SourceAddr, password, cmpp.TimeStamp2Str(pdu.Timestamp))
+			resp.AuthenticatorISMG = badAuthISMG(cmpp.GenConnectRespAuthISMG(make([]byte, 4), string(auth), password), badAuth)
+			c.send(resp)
+		case *cmpp20.PduSubmit:
+			resp := pdu.GenEmptyResponse().(*cmpp20.PduSubmitResp)
+			resp.MsgID = uint64(pdu.GetSequenceID())
+			c.send(resp)
+		case *cmpp30.Submit:
+			resp := pdu.GenEmptyResponse().(*cmpp30.SubmitResp)
+			resp.MsgID = uint64(pdu.GetSequenceID())
+			c.send(resp)
+		default:
+			if resp := p.GenEmptyResponse(); resp != nil {
+				c.send(resp)
+			}
+		}
+	}
+}
+
+// badAuthISMG returns the digest as is, or if bad is set, its first 8 bytes followed by 0x00 and different bytes.
+func badAuthISMG(digest []byte, bad bool) string {
+	if bad {
+		digest = append(digest[:8:8], 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff)
+	}
+	return string(digest)
+}
+
+func cmppDecoder(v consts.CMPPVersion) DecodeFunc {
+	if v == consts.CMPPVersion3_0 {
+		return cmpp30.DecodeCMPP30
+	}
+	return cmpp20.DecodeCMPP20
+}
+
+func newCMPPSubmit(v consts.CMPPVersion) protocol.PDU {
+	if v == consts.CMPPVersion3_0 {
+		return &cmpp30.Submit{PkTotal: 1, PkNumber: 1, DestUsrTL: 1, DestTerminalID: []string{"13800000000"}}
+	}
+	return &cmpp20.PduSubmit{PkTotal: 1, PkNumber: 1, DestUsrTL: 1, DestTerminalID: []string{"13800000000"}}
+}
+
+func TestCMPPClient_Submit(t *testing.T) {
+	for _, v := range []consts.CMPPVersion{consts.CMPPVersion2_0, consts.CMPPVersion3_0} {
+		t.Run(v.String(), func(t *testing.T) {
+			srv := newFakeServer(t, codec.NewCMPPCodec(), cmppDecoder(v), ismgHandler("pwd", false, nil))
+
+			ctx := context.Background()
+			cli, err := DialCMPP(ctx, CMPPConfig{Addr: srv.Addr(), Version: v, SourceAddr: "900001", Password: "pwd"}, WithWindow(4))
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer cli.Close()
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			msgIDs := make(map[uint64]struct{})
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					msgID, err := cli.Submit(ctx, newCMPPSubmit(v))
+					assert.Nil(t, err)
+					mu.Lock()
+					msgIDs[msgID] = struct{}{}
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, 20, len(msgIDs))
+
+			// the submit type must match the version
+			_, err = cli.Submit(ctx, newCMPPSubmit(consts.CMPPVersion2_0^consts.CMPPVersion3_0^v))
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestCMPPClient_AuthISMG(t *testing.T) {
+	for _, v := range []consts.CMPPVersion{consts.CMPPVersion2_0, consts.CMPPVersion3_0} {
+		t.Run(v.String(), func(t *testing.T) {
+			srv := newFakeServer(t, codec.NewCMPPCodec(), cmppDecoder(v), ismgHandler("pwd", true, nil))
+
+			cfg := CMPPConfig{Addr: srv.Addr(), Version: v, SourceAddr: "900001", Password: "pwd"}
+			_, err := DialCMPP(context.Background(), cfg)
+			assert.True(t, errors.Is(err, ErrAuthISMG))
+
+			cfg.SkipAuthISMG = true
+			cli, err := DialCMPP(context.Background(), cfg)
+			if assert.Nil(t, err) {
+				_ = cli.Close()
+			}
+		})
+	}
+}
+
+func TestCMPPClient_ConnectFailed(t *testing.T) {
+	srv := newFakeServer(t, codec.NewCMPPCodec(), cmpp30.DecodeCMPP30, func(c *fakeConn, p protocol.PDU) {
+		resp := p.GenEmptyResponse().(*cmpp30.ConnectResp)
+		resp.Status = uint32(cmpp20.ConnectRespStatusAuthError)
+		c.send(resp)
+	})
+
+	_, err := DialCMPP(context.Background(), CMPPConfig{Addr: srv.Addr(), Version: consts.CMPPVersion3_0, SourceAddr: "900001", Password: "bad"})
+	var re *CMPPResultError
+	if assert.True(t, errors.As(err, &re)) {
+		assert.Equal(t, uint32(3), re.Result)
+	}
+}
+
+func TestCMPPClient_DeliverAndActiveTest(t *testing.T) {
+	for _, v := range []consts.CMPPVersion{consts.CMPPVersion2_0, consts.CMPPVersion3_0} {
+		t.Run(v.String(), func(t *testing.T) {
+			events := make(chan protocol.PDU, 16)
+			srv := newFakeServer(t, codec.NewCMPPCodec(), cmppDecoder(v), func(c *fakeConn, p protocol.PDU) {
+				ismgHandler("pwd", false, events)(c, p)
+				if p.GetCommand() != cmpp.CommandConnect {
+					return
+				}
+				if v == consts.CMPPVersion3_0 {
+					c.send(&cmpp30.Deliver{Header: cmpp.Header{CommandID: cmpp.CommandDeliver, SequenceID: 7}, MsgID: 1, RegisteredDeliver: 1})
+					c.send(&cmpp30.ActiveTest{Header: cmpp.Header{CommandID: cmpp.CommandActiveTest, SequenceID: 8}})
+				} else {
+					c.send(&cmpp20.PduDeliver{Header: cmpp.Header{CommandID: cmpp.CommandDeliver, SequenceID: 7}, MsgID: 1, RegisteredDeliver: 1})
+					c.send(&cmpp20.PduActiveTest{Header: cmpp.Header{CommandID: cmpp.CommandActiveTest, SequenceID: 8}})
+				}
+			})
+
+			delivered := make(chan protocol.PDU, 1)
+			cli, err := DialCMPP(context.Background(), CMPPConfig{
+				Addr:       srv.Addr(),
+				Version:    v,
+				SourceAddr: "900001",
+				Password:   "pwd",
+				OnDeliver: func(ctx context.Context, pdu protocol.PDU) uint32 {
+					delivered <- pdu
+					return 9
+				},
+			}, WithKeepalive(20*time.Millisecond, 3))
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer cli.Close()
+
+			select {
+			case pdu := <-delivered:
+				assert.Equal(t, uint32(7), pdu.GetSequenceID())
+			case <-time.After(time.Second):
+				t.Fatal("deliver not received")
+			}
+
+			// connect, then in any order: deliver resp, active test resp and the client's own active test
+			assert.Equal(t, cmpp.CommandConnect, (<-events).GetCommand())
+			seen := make(map[string]protocol.PDU)
+			for len(seen) < 3 {
+				select {
+				case p := <-events:
+					seen[p.GetCommand().String()] = p
+				case <-time.After(time.Second):
+					t.Fatalf("missing pdus, got %v", seen)
+				}
+			}
+			assert.Contains(t, seen, cmpp.CommandActiveTest.String())
+			assert.Contains(t, seen, cmpp.CommandActiveTestResp.String())
+			switch resp := seen[cmpp.CommandDeliverResp.String()].(type) {
+			case *cmpp20.PduDeliverResp:
+				assert.Equal(t, uint8(9), resp.Result)
+			case *cmpp30.DeliverResp:
+				assert.Equal(t, uint32(9), resp.Result)
+			default:
+				t.Fatalf("unexpected deliver resp %T", resp)
+			}
+		})
+	}
+}
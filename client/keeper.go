@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// keeper holds the current session of a client and reconnects when it is lost.
+// The rules are the same for every protocol:
+//   - requests issued while there is no session fail fast with ErrNotConnected;
+//   - requests in flight on a lost session fail with the error that closed it;
+//   - when reconnecting is enabled, the client redials and binds again every reconnectInterval,
+//     giving up after maxReconnectAttempts consecutive failures.
+type keeper struct {
+	opts    *options
+	connect func(ctx context.Context) (*session, error)
+
+	mu  sync.RWMutex
+	cur *session
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newKeeper(opts *options, connect func(ctx context.Context) (*session, error)) *keeper {
+	return &keeper{
+		opts:    opts,
+		connect: connect,
+		closed:  make(chan struct{}),
+	}
+}
+
+// start connects for the first time and starts watching the session.
+func (k *keeper) start(ctx context.Context) error {
+	s, err := k.connect(ctx)
+	if err != nil {
+		return err
+	}
+	if !k.set(s) {
+		s.closeWithError(ErrClosed)
+		return ErrClosed
+	}
+	go k.watch(s)
+	return nil
+}
+
+// set replaces the current session. It reports false and keeps nothing if the keeper is closed,
+// the caller must close s then. closed is checked under mu, so close either sees s or set sees closed.
+func (k *keeper) set(s *session) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	select {
+	case <-k.closed:
+		k.cur = nil
+		return false
+	default:
+	}
+	k.cur = s
+	return true
+}
+
+// session returns the current session, or ErrNotConnected if there is none.
+func (k *keeper) session() (*session, error) {
+	select {
+	case <-k.closed:
+		return nil, ErrClosed
+	default:
+	}
+
+	k.mu.RLock()
+	s := k.cur
+	k.mu.RUnlock()
+	if s == nil || s.err() != nil {
+		return nil, ErrNotConnected
+	}
+	return s, nil
+}
+
+// watch waits for s to be closed and reconnects if enabled.
+func (k *keeper) watch(s *session) {
+	select {
+	case <-k.closed:
+		return
+	case <-s.Done():
+	}
+	k.set(nil)
+
+	if k.opts.reconnectInterval <= 0 {
+		k.opts.logger.Warnf("[%s] session closed: %v", s.RemoteAddr(), s.err())
+		return
+	}
+	k.opts.logger.Warnf("[%s] session closed: %v, reconnecting", s.RemoteAddr(), s.err())
+
+	for attempt := 1; k.opts.maxReconnectAttempts <= 0 || attempt <= k.opts.maxReconnectAttempts; attempt++ {
+		select {
+		case <-k.closed:
+			return
+		case <-time.After(k.opts.reconnectInterval):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), k.opts.dialTimeout+k.opts.requestTimeout)
+		ns, err := k.connect(ctx)
+		cancel()
+		if err != nil {
+			k.opts.logger.Warnf("reconnect failed(%d): %v", attempt, err)
+			continue
+		}
+
+		if !k.set(ns) {
+			ns.closeWithError(ErrClosed)
+			return
+		}
+		go k.watch(ns)
+		return
+	}
+	k.opts.logger.Errorf("give up reconnecting after %d attempts", k.opts.maxReconnectAttempts)
+}
+
+// close stops reconnecting and shuts down the current session gracefully.
+func (k *keeper) close(ctx context.Context) error {
+	var s *session
+	k.closeOnce.Do(func() {
+		close(k.closed)
+		k.mu.Lock()
+		s, k.cur = k.cur, nil
+		k.mu.Unlock()
+	})
+	if s == nil {
+		return nil
+	}
+	return s.shutdown(ctx)
+}
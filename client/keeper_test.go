@@ -0,0 +1,20 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeeper_SetAfterClose(t *testing.T) {
+	k := newKeeper(newOptions(), nil)
+	assert.True(t, k.set(nil))
+	assert.Nil(t, k.close(context.Background()))
+
+	// a reconnect finishing after close must not install its session
+	assert.False(t, k.set(&session{}))
+	_, err := k.session()
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.Nil(t, k.cur)
+}
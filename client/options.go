@@ -0,0 +1,122 @@
+package client
+
+import (
+	"time"
+
+	"github.com/hujm2023/hlog"
+
+	"github.com/hujm2023/go-sms-protocol/codec"
+)
+
+const (
+	defaultDialTimeout        = 5 * time.Second
+	defaultRequestTimeout     = 10 * time.Second
+	defaultWindow             = 16
+	defaultKeepaliveInterval  = 30 * time.Second
+	defaultMaxMissedKeepalive = 3
+)
+
+// options holds the settings shared by all protocol clients.
+type options struct {
+	dialTimeout    time.Duration // timeout for establishing the TCP connection
+	requestTimeout time.Duration // timeout for writing a request and waiting for its response
+	window         int           // max number of outstanding requests per session
+
+	keepaliveInterval  time.Duration // idle time before a heartbeat is sent, <=0 disables heartbeats
+	maxMissedKeepalive int           // consecutive failed heartbeats before the session is closed
+
+	reconnectInterval    time.Duration // delay between reconnect attempts, <=0 disables reconnecting
+	maxReconnectAttempts int           // max consecutive reconnect attempts, <=0 means unlimited
+
+	maxFrameLength int // max length of a received packet, <=0 means the default of the codec
+
+	logger hlog.FullLogger
+}
+
+// Option is the function option type for configuring a protocol client.
+type Option func(*options)
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		dialTimeout:        defaultDialTimeout,
+		requestTimeout:     defaultRequestTimeout,
+		window:             defaultWindow,
+		keepaliveInterval:  defaultKeepaliveInterval,
+		maxMissedKeepalive: defaultMaxMissedKeepalive,
+		logger:             hlog.DefaultLogger(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.window <= 0 {
+		o.window = 1
+	}
+	if o.maxMissedKeepalive <= 0 {
+		o.maxMissedKeepalive = 1
+	}
+	return o
+}
+
+// WithDialTimeout sets the timeout for establishing the TCP connection.
+func WithDialTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = d
+	}
+}
+
+// WithRequestTimeout sets how long a request may wait for its response.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.requestTimeout = d
+	}
+}
+
+// WithWindow sets the max number of outstanding requests on one session.
+// Requests beyond the window block until a slot is released or the context is done.
+func WithWindow(n int) Option {
+	return func(o *options) {
+		o.window = n
+	}
+}
+
+// WithKeepalive sets the heartbeat (enquire_link / active_test) behaviour.
+// A heartbeat is sent once nothing has been received for interval,
+// and the session is closed after maxMissed consecutive heartbeats got no response.
+// An interval <= 0 disables heartbeats.
+func WithKeepalive(interval time.Duration, maxMissed int) Option {
+	return func(o *options) {
+		o.keepaliveInterval = interval
+		o.maxMissedKeepalive = maxMissed
+	}
+}
+
+// WithReconnect enables reconnecting after the session is lost.
+// The client redials and binds again with the same credentials every interval,
+// giving up after maxAttempts consecutive failures (<=0 means never give up).
+func WithReconnect(interval time.Duration, maxAttempts int) Option {
+	return func(o *options) {
+		o.reconnectInterval = interval
+		o.maxReconnectAttempts = maxAttempts
+	}
+}
+
+// WithMaxFrameLength sets the max length of a packet received from the server.
+// The session is closed when a longer packet arrives. Defaults to codec.DefaultMaxFrameLength,
+// or smpp.MAX_PDU_SIZE for SMPP.
+func WithMaxFrameLength(n int) Option {
+	return func(o *options) {
+		o.maxFrameLength = n
+	}
+}
+
+// WithLogger sets the logger for the client.
+func WithLogger(logger hlog.FullLogger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// codecOptions returns the options for the codec of a session.
+func (o *options) codecOptions() []codec.Option {
+	return []codec.Option{codec.WithMaxFrameLength(o.maxFrameLength)}
+}
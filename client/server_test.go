@@ -0,0 +1,76 @@
+package client
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"testing"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+)
+
+// fakeConn is the server side of a connection accepted by fakeServer.
+type fakeConn struct {
+	net.Conn
+	mu sync.Mutex
+}
+
+func (c *fakeConn) send(p protocol.PDU) {
+	data, err := p.IEncode()
+	if err != nil {
+		panic(err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = c.Write(data)
+}
+
+// fakeServer is a minimal gateway for testing clients.
+// Every PDU received is passed to handle, which runs in the read goroutine of the connection.
+type fakeServer struct {
+	ln     net.Listener
+	codec  codec.Codec
+	decode DecodeFunc
+	handle func(c *fakeConn, p protocol.PDU)
+}
+
+func newFakeServer(t *testing.T, c codec.Codec, decode DecodeFunc, handle func(c *fakeConn, p protocol.PDU)) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{ln: ln, codec: c, decode: decode, handle: handle}
+	go s.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+func (s *fakeServer) Addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			c := &fakeConn{Conn: conn}
+			defer c.Close()
+			r := bufio.NewReader(conn)
+			for {
+				data, err := s.codec.DecodeBlocked(r)
+				if err != nil {
+					return
+				}
+				p, err := s.decode(data)
+				if err != nil {
+					continue
+				}
+				s.handle(c, p)
+			}
+		}()
+	}
+}
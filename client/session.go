@@ -0,0 +1,380 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+)
+
+var (
+	// ErrClosed indicates that the session has been closed locally.
+	ErrClosed = errors.New("session closed")
+	// ErrNotConnected indicates that the client has no bound session at the moment,
+	// e.g. while it is reconnecting.
+	ErrNotConnected = errors.New("not connected")
+	// ErrRequestTimeout indicates that no response was received within the request timeout.
+	ErrRequestTimeout = errors.New("request timeout")
+)
+
+// responseMask is the bit set in the command ID of every response PDU.
+// It is the same for SMPP, CMPP, SGIP and SMGP.
+const responseMask = 0x80000000
+
+// maxSequenceID keeps sequence IDs within the range allowed by SMPP (0x00000001~0x7FFFFFFF),
+// which is also valid for the other protocols.
+const maxSequenceID = 0x7FFFFFFF
+
+// DecodeFunc decodes one complete frame into a PDU, e.g. smpp34.DecodeSMPP34.
+type DecodeFunc func(data []byte) (protocol.PDU, error)
+
+// requestHandler handles a request initiated by the peer and returns the response to write back.
+// A nil response means nothing is written.
+type requestHandler func(ctx context.Context, s *session, p protocol.PDU) protocol.PDU
+
+// session is one TCP connection to a gateway.
+// It matches responses to requests by sequence ID, limits the outstanding requests with a window,
+// and sends heartbeats when the connection is idle.
+type session struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	codec  codec.Codec
+	decode DecodeFunc
+	opts   *options
+
+	handle      requestHandler                 // handler for requests initiated by the peer
+	unsupported func(data []byte) protocol.PDU // builds the response to a packet that cannot be decoded, may be nil
+	heartbeat   func() protocol.PDU            // builds the heartbeat request, nil disables heartbeats
+	unbind      func() protocol.PDU            // builds the request sent before a graceful close, may be nil
+	sequence    func() uint32                  // generates sequence IDs instead of the session, may be nil
+	prepare     func(p protocol.PDU)           // called before a request is written, may be nil
+	onClose     func(s *session, e error)      // called once after the session is closed, may be nil
+
+	sequenceIDGen uint32
+	window        chan struct{}
+	requests      chan protocol.PDU // requests initiated by the peer, served one by one in order
+
+	mu      sync.Mutex
+	pending map[uint32]chan protocol.PDU
+
+	wmu sync.Mutex
+
+	lastRecv int64 // unix nano of the last received PDU
+
+	closed    chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newSession(conn net.Conn, c codec.Codec, decode DecodeFunc, opts *options) *session {
+	return &session{
+		conn:     conn,
+		reader:   bufio.NewReader(conn),
+		codec:    c,
+		decode:   decode,
+		opts:     opts,
+		window:   make(chan struct{}, opts.window),
+		requests: make(chan protocol.PDU, opts.window),
+		pending:  make(map[uint32]chan protocol.PDU),
+		lastRecv: time.Now().UnixNano(),
+		closed:   make(chan struct{}),
+	}
+}
+
+// start runs the read loop, the serve loop and the heartbeat loop.
+func (s *session) start() {
+	go s.readLoop()
+	go s.serveLoop()
+	go s.keepalive()
+}
+
+// nextSequenceID returns the next sequence ID, wrapping around to 1 after maxSequenceID.
+func (s *session) nextSequenceID() uint32 {
+	for {
+		n := atomic.AddUint32(&s.sequenceIDGen, 1) & maxSequenceID
+		if n != 0 {
+			return n
+		}
+	}
+}
+
+// request assigns a sequence ID to p, writes it and waits for the matching response.
+func (s *session) request(ctx context.Context, p protocol.PDU) (protocol.PDU, error) {
+	select {
+	case s.window <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.closed:
+		return nil, s.err()
+	}
+	defer func() { <-s.window }()
+
+	var seq uint32
+	if s.sequence != nil {
+		seq = s.sequence()
+	} else {
+		seq = s.nextSequenceID()
+	}
+	p.SetSequenceID(seq)
+	if s.prepare != nil {
+		s.prepare(p)
+	}
+
+	ch := make(chan protocol.PDU, 1)
+	s.mu.Lock()
+	s.pending[seq] = ch
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, seq)
+		s.mu.Unlock()
+	}()
+
+	if err := s.write(p); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(s.opts.requestTimeout)
+	defer timer.Stop()
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-timer.C:
+		return nil, ErrRequestTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.closed:
+		return nil, s.err()
+	}
+}
+
+// write encodes p and writes it to the connection.
+func (s *session) write(p protocol.PDU) error {
+	data, err := p.IEncode()
+	if err != nil {
+		return fmt.Errorf("encode %s error: %w", p.GetCommand().String(), err)
+	}
+
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+
+	select {
+	case <-s.closed:
+		return s.err()
+	default:
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.opts.requestTimeout))
+	if _, err = s.conn.Write(data); err != nil {
+		s.closeWithError(err)
+		return err
+	}
+	return nil
+}
+
+// readLoop reads PDUs until the connection fails.
+// Responses are handed to the waiting request, requests are queued for serveLoop.
+// Reading blocks while the queue is full, so a slow handler slows the peer down.
+func (s *session) readLoop() {
+	for {
+		data, err := s.codec.DecodeBlocked(s.reader)
+		if err != nil {
+			s.closeWithError(err)
+			return
+		}
+
+		p, err := s.decode(data)
+		if err != nil {
+			if errors.Is(err, protocol.ErrUnsupportedPacket) {
+				s.opts.logger.Warnf("[%s] drop unsupported packet: %v", s.RemoteAddr(), data)
+				s.reject(data)
+				continue
+			}
+			s.closeWithError(fmt.Errorf("decode pdu error: %w", err))
+			return
+		}
+		atomic.StoreInt64(&s.lastRecv, time.Now().UnixNano())
+
+		if p.GetCommand().ToUint32()&responseMask != 0 {
+			s.dispatchResponse(p)
+			continue
+		}
+		select {
+		case s.requests <- p:
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// serveLoop serves the requests initiated by the peer one at a time, in the order they were received.
+func (s *session) serveLoop() {
+	for {
+		select {
+		case p := <-s.requests:
+			s.serve(p)
+		case <-s.closed:
+			return
+		}
+	}
+}
+
+// reject answers a packet that cannot be decoded, if the protocol has a response for it.
+func (s *session) reject(data []byte) {
+	if s.unsupported == nil {
+		return
+	}
+	resp := s.unsupported(data)
+	if resp == nil {
+		return
+	}
+	if err := s.write(resp); err != nil {
+		s.opts.logger.Errorf("[%s] write %s error: %v", s.RemoteAddr(), resp.GetCommand().String(), err)
+	}
+}
+
+// dispatchResponse hands resp to the request waiting for its sequence ID.
+func (s *session) dispatchResponse(resp protocol.PDU) {
+	s.mu.Lock()
+	ch, ok := s.pending[resp.GetSequenceID()]
+	s.mu.Unlock()
+	if !ok {
+		s.opts.logger.Warnf("[%s] drop unexpected response: %s", s.RemoteAddr(), resp.String())
+		return
+	}
+	select {
+	case ch <- resp:
+	default:
+		// a response for this sequence ID has already been delivered
+	}
+}
+
+// serve handles one request initiated by the peer and writes back the response.
+func (s *session) serve(p protocol.PDU) {
+	var resp protocol.PDU
+	if s.handle != nil {
+		resp = s.handle(context.Background(), s, p)
+	} else {
+		resp = p.GenEmptyResponse()
+	}
+	if resp == nil {
+		return
+	}
+	if err := s.write(resp); err != nil {
+		s.opts.logger.Errorf("[%s] write %s error: %v", s.RemoteAddr(), resp.GetCommand().String(), err)
+	}
+}
+
+// keepalive sends a heartbeat whenever nothing has been received for the keepalive interval,
+// and closes the session after too many heartbeats failed in a row.
+func (s *session) keepalive() {
+	if s.heartbeat == nil || s.opts.keepaliveInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.opts.keepaliveInterval)
+	defer ticker.Stop()
+
+	missed := 0
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+		}
+
+		idle := time.Since(time.Unix(0, atomic.LoadInt64(&s.lastRecv)))
+		if idle < s.opts.keepaliveInterval {
+			missed = 0
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opts.requestTimeout)
+		_, err := s.request(ctx, s.heartbeat())
+		cancel()
+		if err == nil {
+			missed = 0
+			continue
+		}
+
+		missed++
+		s.opts.logger.Warnf("[%s] heartbeat failed(%d/%d): %v", s.RemoteAddr(), missed, s.opts.maxMissedKeepalive, err)
+		if missed >= s.opts.maxMissedKeepalive {
+			s.closeWithError(fmt.Errorf("%d heartbeats missed", missed))
+			return
+		}
+	}
+}
+
+// shutdown sends the unbind request if any, waits for its response and closes the session.
+func (s *session) shutdown(ctx context.Context) error {
+	if s.unbind != nil {
+		if _, err := s.request(ctx, s.unbind()); err != nil {
+			s.opts.logger.Warnf("[%s] unbind error: %v", s.RemoteAddr(), err)
+		}
+	}
+	s.closeWithError(ErrClosed)
+	return nil
+}
+
+// closeWithError closes the session once, failing all pending requests with e.
+func (s *session) closeWithError(e error) {
+	s.closeOnce.Do(func() {
+		s.closeErr = e
+		close(s.closed)
+		_ = s.conn.Close()
+		if s.onClose != nil {
+			s.onClose(s, e)
+		}
+	})
+}
+
+// err returns the reason why the session was closed.
+func (s *session) err() error {
+	select {
+	case <-s.closed:
+		return s.closeErr
+	default:
+		return nil
+	}
+}
+
+// Done returns a channel that is closed when the session is closed.
+func (s *session) Done() <-chan struct{} {
+	return s.closed
+}
+
+// RemoteAddr returns the remote network address.
+func (s *session) RemoteAddr() string {
+	return s.conn.RemoteAddr().String()
+}
+
+// dial opens a TCP connection to addr and starts a session on it.
+// bind is then called to authenticate; the session is closed if it fails.
+func dial(ctx context.Context, addr string, c codec.Codec, decode DecodeFunc, opts *options, setup func(s *session), bind func(ctx context.Context, s *session) error) (*session, error) {
+	d := net.Dialer{Timeout: opts.dialTimeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s error: %w", addr, err)
+	}
+
+	s := newSession(conn, c, decode, opts)
+	if setup != nil {
+		setup(s)
+	}
+	s.start()
+
+	if err = bind(ctx, s); err != nil {
+		s.closeWithError(err)
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,377 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+)
+
+// SGIPResultError is returned when the SMG answers a request with a non-zero Result.
+type SGIPResultError struct {
+	Command sgip.CommandID
+	Result  sgip.RespStatus
+}
+
+func (e *SGIPResultError) Error() string {
+	return fmt.Sprintf("%s result: %d(%s)", e.Command.String(), uint8(e.Result), e.Result.String())
+}
+
+// sgipSequencer is implemented by every sgip12 PDU through the embedded sgip.Header.
+type sgipSequencer interface {
+	GetSequence() [3]uint32
+	SetSequence(sequence [3]uint32)
+}
+
+// SGIPConfig is the configuration of an SGIP 1.2 SP node.
+type SGIPConfig struct {
+	// NodeID is the node ID of the SP, used as the first part of every Sequence it generates.
+	NodeID uint32
+
+	// SMGAddr is the SMG address the node connects to (LoginType 1) for SGIP_SUBMIT.
+	// If empty, the node only receives.
+	SMGAddr  string
+	Name     string
+	Password string
+
+	// ListenAddr is the address the node listens on for the connections opened by the SMG (LoginType 2).
+	// If empty, the node only submits.
+	ListenAddr string
+
+	// SMGName and SMGPassword are the credentials the SMG must bind with.
+	// If both are empty, any name and password are accepted.
+	SMGName     string
+	SMGPassword string
+
+	// OnDeliver is called for every SGIP_DELIVER (MO) received from the SMG.
+	// The returned status is sent back in SGIP_DELIVER_RESP. If nil, sgip.STAT_OK is returned.
+	OnDeliver func(ctx context.Context, pdu *sgip12.Deliver) sgip.RespStatus
+
+	// OnReport is called for every SGIP_REPORT received from the SMG.
+	// pdu.SubmitSequence is the Sequence of the SGIP_SUBMIT it reports on.
+	// The returned status is sent back in SGIP_REPORT_RESP. If nil, sgip.STAT_OK is returned.
+	OnReport func(ctx context.Context, pdu *sgip12.Report) sgip.RespStatus
+}
+
+// SGIPNode is an SGIP 1.2 SP node.
+// In SGIP the SP opens a connection to the SMG for SGIP_SUBMIT, and the SMG opens
+// separate connections back to the SP for SGIP_DELIVER and SGIP_REPORT.
+// SGIPNode does both: an outbound client and an inbound listener sharing one NodeID and Sequence generator.
+type SGIPNode struct {
+	cfg  SGIPConfig
+	opts *options
+
+	keeper *keeper // outbound, nil if SMGAddr is empty
+
+	ln      net.Listener // inbound, nil if ListenAddr is empty
+	inMu    sync.Mutex
+	inbound map[*session]struct{}
+	wg      sync.WaitGroup
+
+	sequenceIDGen uint32
+	closed        chan struct{}
+	closeOnce     sync.Once
+}
+
+// NewSGIPNode starts the inbound listener if cfg.ListenAddr is set,
+// and binds to the SMG if cfg.SMGAddr is set.
+func NewSGIPNode(ctx context.Context, cfg SGIPConfig, opts ...Option) (*SGIPNode, error) {
+	if cfg.SMGAddr == "" && cfg.ListenAddr == "" {
+		return nil, fmt.Errorf("neither SMGAddr nor ListenAddr is set")
+	}
+
+	n := &SGIPNode{
+		cfg:     cfg,
+		opts:    newOptions(opts...),
+		inbound: make(map[*session]struct{}),
+		closed:  make(chan struct{}),
+	}
+
+	if cfg.ListenAddr != "" {
+		ln, err := net.Listen("tcp", cfg.ListenAddr)
+		if err != nil {
+			return nil, fmt.Errorf("listen %s error: %w", cfg.ListenAddr, err)
+		}
+		n.ln = ln
+		n.wg.Add(1)
+		go n.accept()
+	}
+
+	if cfg.SMGAddr != "" {
+		n.keeper = newKeeper(n.opts, n.connect)
+		if err := n.keeper.start(ctx); err != nil {
+			if n.ln != nil {
+				_ = n.ln.Close()
+				n.wg.Wait()
+			}
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// Addr returns the address of the inbound listener, nil if there is none.
+func (n *SGIPNode) Addr() net.Addr {
+	if n.ln == nil {
+		return nil
+	}
+	return n.ln.Addr()
+}
+
+// nextSequenceID returns the third part of the Sequence.
+// It is shared by all connections of the node, so a Sequence is unique within the node.
+func (n *SGIPNode) nextSequenceID() uint32 {
+	for {
+		if id := atomic.AddUint32(&n.sequenceIDGen, 1); id != 0 {
+			return id
+		}
+	}
+}
+
+// setup is used for both outbound and inbound sessions.
+func (n *SGIPNode) setup(s *session) {
+	s.sequence = n.nextSequenceID
+	s.prepare = func(p protocol.PDU) {
+		if h, ok := p.(sgipSequencer); ok {
+			h.SetSequence([3]uint32{n.cfg.NodeID, sgip.Timestamp(time.Now()), p.GetSequenceID()})
+		}
+	}
+	s.unbind = func() protocol.PDU {
+		return &sgip12.Unbind{Header: sgip.Header{CommandID: sgip.SGIP_UNBIND}}
+	}
+	// SGIP has no heartbeat, the SMG closes idle connections itself
+	s.heartbeat = nil
+}
+
+func (n *SGIPNode) connect(ctx context.Context) (*session, error) {
+	return dial(ctx, n.cfg.SMGAddr, codec.NewSGIPCodec(n.opts.codecOptions()...), sgip12.DecodeSGIP12, n.opts, func(s *session) {
+		n.setup(s)
+		s.handle = n.handleOutbound
+	}, n.bind)
+}
+
+// bind sends SGIP_BIND with LoginType 1.
+func (n *SGIPNode) bind(ctx context.Context, s *session) error {
+	req := &sgip12.Bind{
+		Header:   sgip.Header{CommandID: sgip.SGIP_BIND},
+		Type:     sgip.SP_SMG,
+		Name:     n.cfg.Name,
+		Password: n.cfg.Password,
+	}
+	resp, err := s.request(ctx, req)
+	if err != nil {
+		return fmt.Errorf("bind error: %w", err)
+	}
+	r, ok := resp.(*sgip12.BindResp)
+	if !ok {
+		return fmt.Errorf("bind error: unexpected response %s", resp.GetCommand().String())
+	}
+	if r.Result != sgip.STAT_OK {
+		return fmt.Errorf("bind error: %w", &SGIPResultError{Command: sgip.SGIP_BIND_REP, Result: r.Result})
+	}
+	return nil
+}
+
+// handleOutbound answers the requests sent by the SMG on the submit connection.
+func (n *SGIPNode) handleOutbound(ctx context.Context, s *session, p protocol.PDU) protocol.PDU {
+	if pdu, ok := p.(*sgip12.Unbind); ok {
+		n.replyUnbind(s, pdu)
+		return nil
+	}
+	return n.reply(p, p.GenEmptyResponse())
+}
+
+// replyUnbind answers SGIP_UNBIND and closes the connection.
+func (n *SGIPNode) replyUnbind(s *session, pdu *sgip12.Unbind) {
+	if err := s.write(n.reply(pdu, pdu.GenEmptyResponse())); err != nil {
+		n.opts.logger.Warnf("[%s] write unbind resp error: %v", s.RemoteAddr(), err)
+	}
+	s.closeWithError(ErrClosed)
+}
+
+// reply makes resp carry the whole Sequence of req, as SGIP requires.
+func (n *SGIPNode) reply(req, resp protocol.PDU) protocol.PDU {
+	if resp == nil {
+		return nil
+	}
+	src, ok1 := req.(sgipSequencer)
+	dst, ok2 := resp.(sgipSequencer)
+	if ok1 && ok2 {
+		dst.SetSequence(src.GetSequence())
+	}
+	return resp
+}
+
+// accept serves the connections opened by the SMG.
+func (n *SGIPNode) accept() {
+	defer n.wg.Done()
+	for {
+		conn, err := n.ln.Accept()
+		if err != nil {
+			select {
+			case <-n.closed:
+			default:
+				n.opts.logger.Errorf("sgip accept error: %v", err)
+			}
+			return
+		}
+
+		s := newSession(conn, codec.NewSGIPCodec(n.opts.codecOptions()...), sgip12.DecodeSGIP12, n.opts)
+		n.setup(s)
+
+		var bound int32
+		var bindTimer *time.Timer
+		s.handle = func(ctx context.Context, s *session, p protocol.PDU) protocol.PDU {
+			resp := n.handleInbound(ctx, s, p, &bound)
+			if _, ok := p.(*sgip12.Bind); ok && atomic.LoadInt32(&bound) == 1 {
+				bindTimer.Stop()
+			}
+			return resp
+		}
+		s.onClose = func(s *session, _ error) {
+			n.inMu.Lock()
+			delete(n.inbound, s)
+			n.inMu.Unlock()
+		}
+
+		n.inMu.Lock()
+		n.inbound[s] = struct{}{}
+		n.inMu.Unlock()
+
+		// the SMG must bind within the request timeout
+		bindTimer = time.AfterFunc(n.opts.requestTimeout, func() {
+			if atomic.LoadInt32(&bound) == 0 {
+				s.closeWithError(fmt.Errorf("bind timeout"))
+			}
+		})
+		s.start()
+	}
+}
+
+// handleInbound answers the requests sent by the SMG on a connection it opened.
+func (n *SGIPNode) handleInbound(ctx context.Context, s *session, p protocol.PDU, bound *int32) protocol.PDU {
+	if pdu, ok := p.(*sgip12.Bind); ok {
+		resp := n.reply(pdu, pdu.GenEmptyResponse()).(*sgip12.BindResp)
+		switch {
+		case atomic.LoadInt32(bound) == 1:
+			resp.Result = sgip.STAT_RPTLOGIN
+		case pdu.Type != sgip.SMG_SP:
+			resp.Result = sgip.STAT_ERLGNTYPE
+		case (n.cfg.SMGName != "" || n.cfg.SMGPassword != "") &&
+			(pdu.Name != n.cfg.SMGName || pdu.Password != n.cfg.SMGPassword):
+			resp.Result = sgip.STAT_ILLLOGIN
+		case atomic.CompareAndSwapInt32(bound, 0, 1):
+			return resp
+		default: // a pipelined Bind won the race
+			resp.Result = sgip.STAT_RPTLOGIN
+		}
+		// reject and close
+		if err := s.write(resp); err != nil {
+			n.opts.logger.Warnf("[%s] write bind resp error: %v", s.RemoteAddr(), err)
+		}
+		s.closeWithError(&SGIPResultError{Command: sgip.SGIP_BIND_REP, Result: resp.Result})
+		return nil
+	}
+
+	if atomic.LoadInt32(bound) == 0 {
+		n.opts.logger.Warnf("[%s] %s before bind, close", s.RemoteAddr(), p.GetCommand().String())
+		s.closeWithError(fmt.Errorf("%s before bind", p.GetCommand().String()))
+		return nil
+	}
+
+	switch pdu := p.(type) {
+	case *sgip12.Deliver:
+		resp := n.reply(pdu, pdu.GenEmptyResponse()).(*sgip12.DeliverResp)
+		if n.cfg.OnDeliver != nil {
+			resp.Result = n.cfg.OnDeliver(ctx, pdu)
+		}
+		return resp
+	case *sgip12.Report:
+		resp := n.reply(pdu, pdu.GenEmptyResponse()).(*sgip12.ReportResp)
+		if n.cfg.OnReport != nil {
+			resp.Result = n.cfg.OnReport(ctx, pdu)
+		}
+		return resp
+	case *sgip12.Unbind:
+		n.replyUnbind(s, pdu)
+		return nil
+	default:
+		return n.reply(p, p.GenEmptyResponse())
+	}
+}
+
+// Submit sends an SGIP_SUBMIT on the outbound connection and waits for its SGIP_SUBMIT_RESP.
+// On return pdu.Header.Sequence holds the Sequence assigned to the submit,
+// which is the SubmitSequence of the SGIP_REPORT for it.
+// A non-zero Result is returned as a *SGIPResultError.
+func (n *SGIPNode) Submit(ctx context.Context, pdu *sgip12.Submit) (*sgip12.SubmitResp, error) {
+	pdu.Header.CommandID = sgip.SGIP_SUBMIT
+	resp, err := n.Request(ctx, pdu)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := resp.(*sgip12.SubmitResp)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %s", resp.GetCommand().String())
+	}
+	if r.Result != sgip.STAT_OK {
+		return r, &SGIPResultError{Command: sgip.SGIP_SUBMIT_REP, Result: r.Result}
+	}
+	return r, nil
+}
+
+// Request sends any request PDU on the outbound connection and waits for the response.
+// The Sequence of pdu is overwritten.
+func (n *SGIPNode) Request(ctx context.Context, pdu protocol.PDU) (protocol.PDU, error) {
+	if n.keeper == nil {
+		return nil, ErrNotConnected
+	}
+	s, err := n.keeper.session()
+	if err != nil {
+		return nil, err
+	}
+	return s.request(ctx, pdu)
+}
+
+// Close unbinds the outbound connection and every inbound connection, then stops the listener.
+func (n *SGIPNode) Close() error {
+	n.closeOnce.Do(func() {
+		close(n.closed)
+		if n.ln != nil {
+			_ = n.ln.Close()
+		}
+	})
+	n.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.opts.requestTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	if n.keeper != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = n.keeper.close(ctx)
+		}()
+	}
+
+	n.inMu.Lock()
+	for s := range n.inbound {
+		wg.Add(1)
+		go func(s *session) {
+			defer wg.Done()
+			_ = s.shutdown(ctx)
+		}(s)
+	}
+	n.inMu.Unlock()
+
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+)
+
+// smgHandler answers bind, submit and unbind on the connection opened by the SP.
+func smgHandler(events chan<- protocol.PDU) func(c *fakeConn, p protocol.PDU) {
+	return func(c *fakeConn, p protocol.PDU) {
+		select {
+		case events <- p:
+		default:
+		}
+		switch pdu := p.(type) {
+		case *sgip12.Bind:
+			resp := pdu.GenEmptyResponse().(*sgip12.BindResp)
+			if pdu.Type != sgip.SP_SMG || pdu.Password != "pwd" {
+				resp.Result = sgip.STAT_ILLLOGIN
+			}
+			c.send(resp)
+		case *sgip12.Submit:
+			resp := pdu.GenEmptyResponse().(*sgip12.SubmitResp)
+			resp.Sequence = pdu.Sequence
+			if pdu.UserCount == 0 {
+				resp.Result = sgip.STAT_ILLUSRNUM
+			}
+			c.send(resp)
+		default:
+			if resp := p.GenEmptyResponse(); resp != nil {
+				c.send(resp)
+			}
+		}
+	}
+}
+
+func TestSGIPNode_Submit(t *testing.T) {
+	events := make(chan protocol.PDU, 16)
+	srv := newFakeServer(t, codec.NewSGIPCodec(), sgip12.DecodeSGIP12, smgHandler(events))
+
+	ctx := context.Background()
+	node, err := NewSGIPNode(ctx, SGIPConfig{NodeID: 3000012345, SMGAddr: srv.Addr(), Name: "sp", Password: "pwd"})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	bind := (<-events).(*sgip12.Bind)
+	assert.Equal(t, uint32(3000012345), bind.Sequence[0])
+	assert.NotZero(t, bind.Sequence[1])
+
+	submit := &sgip12.Submit{UserCount: 1, UserNumber: []string{"8613800000000"}}
+	_, err = node.Submit(ctx, submit)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3000012345), submit.Sequence[0])
+	assert.Equal(t, bind.Sequence[2]+1, submit.Sequence[2])
+	assert.Equal(t, submit.Sequence, (<-events).(*sgip12.Submit).Sequence)
+
+	_, err = node.Submit(ctx, &sgip12.Submit{})
+	var re *SGIPResultError
+	if assert.True(t, errors.As(err, &re)) {
+		assert.Equal(t, sgip.STAT_ILLUSRNUM, re.Result)
+	}
+	<-events
+
+	assert.Nil(t, node.Close())
+	assert.IsType(t, &sgip12.Unbind{}, <-events)
+}
+
+func TestSGIPNode_BindFailed(t *testing.T) {
+	srv := newFakeServer(t, codec.NewSGIPCodec(), sgip12.DecodeSGIP12, smgHandler(nil))
+
+	_, err := NewSGIPNode(context.Background(), SGIPConfig{SMGAddr: srv.Addr(), Name: "sp", Password: "bad"})
+	var re *SGIPResultError
+	if assert.True(t, errors.As(err, &re)) {
+		assert.Equal(t, sgip.STAT_ILLLOGIN, re.Result)
+	}
+}
+
+// smgConn is the SMG side of a connection to the inbound listener of SGIPNode.
+type smgConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialSMG(t *testing.T, addr string) *smgConn {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &smgConn{conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (c *smgConn) roundTrip(t *testing.T, p protocol.PDU) protocol.PDU {
+	data, err := p.IEncode()
+	assert.Nil(t, err)
+	_, err = c.conn.Write(data)
+	assert.Nil(t, err)
+	return c.read(t)
+}
+
+func (c *smgConn) read(t *testing.T) protocol.PDU {
+	_ = c.conn.SetReadDeadline(time.Now().Add(time.Second))
+	data, err := codec.NewSGIPCodec().DecodeBlocked(c.r)
+	if err != nil {
+		return nil
+	}
+	p, err := sgip12.DecodeSGIP12(data)
+	assert.Nil(t, err)
+	return p
+}
+
+func TestSGIPNode_Inbound(t *testing.T) {
+	delivers := make(chan *sgip12.Deliver, 1)
+	reports := make(chan *sgip12.Report, 1)
+	node, err := NewSGIPNode(context.Background(), SGIPConfig{
+		NodeID:      3000012345,
+		ListenAddr:  "127.0.0.1:0",
+		SMGName:     "smg",
+		SMGPassword: "pwd",
+		OnDeliver: func(ctx context.Context, pdu *sgip12.Deliver) sgip.RespStatus {
+			delivers <- pdu
+			return sgip.STAT_OK
+		},
+		OnReport: func(ctx context.Context, pdu *sgip12.Report) sgip.RespStatus {
+			reports <- pdu
+			return sgip.STAT_NODEBUSY
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// wrong login type
+	smg := dialSMG(t, node.Addr().String())
+	resp := smg.roundTrip(t, sgip12.NewBind("smg", "pwd", 1, 1))
+	assert.Equal(t, sgip.STAT_ERLGNTYPE, resp.(*sgip12.BindResp).Result)
+	assert.Nil(t, smg.read(t))
+
+	// request before bind
+	smg = dialSMG(t, node.Addr().String())
+	assert.Nil(t, smg.roundTrip(t, &sgip12.Deliver{Header: sgip.NewHeader(0, sgip.SGIP_DELIVER, 1, 1)}))
+
+	smg = dialSMG(t, node.Addr().String())
+	bind := sgip12.NewBind("smg", "pwd", 1, 1)
+	bind.Type = sgip.SMG_SP
+	bindResp := smg.roundTrip(t, bind).(*sgip12.BindResp)
+	assert.Equal(t, sgip.STAT_OK, bindResp.Result)
+	assert.Equal(t, bind.Sequence, bindResp.Sequence)
+
+	deliver := &sgip12.Deliver{Header: sgip.NewHeader(0, sgip.SGIP_DELIVER, 1, 2), UserNumber: "8613800000000", MessageLength: 2, MessageContent: []byte("hi")}
+	deliverResp := smg.roundTrip(t, deliver).(*sgip12.DeliverResp)
+	assert.Equal(t, sgip.STAT_OK, deliverResp.Result)
+	assert.Equal(t, deliver.Sequence, deliverResp.Sequence)
+	assert.Equal(t, "8613800000000", (<-delivers).UserNumber)
+
+	report := &sgip12.Report{Header: sgip.NewHeader(0, sgip.SGIP_REPORT, 1, 3), SubmitSequence: [3]uint32{3000012345, 1, 1}}
+	reportResp := smg.roundTrip(t, report).(*sgip12.ReportResp)
+	assert.Equal(t, sgip.STAT_NODEBUSY, reportResp.Result)
+	assert.Equal(t, [3]uint32{3000012345, 1, 1}, (<-reports).SubmitSequence)
+
+	// the node unbinds the inbound connection on close
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Nil(t, node.Close())
+	}()
+	unbind := smg.read(t).(*sgip12.Unbind)
+	assert.Equal(t, uint32(3000012345), unbind.Sequence[0])
+	data, _ := unbind.GenEmptyResponse().IEncode()
+	_, _ = smg.conn.Write(data)
+	<-done
+}
+
+func TestSGIPNode_InboundPipelinedBind(t *testing.T) {
+	node, err := NewSGIPNode(context.Background(), SGIPConfig{NodeID: 3000012345, ListenAddr: "127.0.0.1:0"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer node.Close()
+
+	// two binds in one write are handled concurrently, only one is accepted
+	smg := dialSMG(t, node.Addr().String())
+	var data []byte
+	for seq := uint32(1); seq <= 2; seq++ {
+		bind := sgip12.NewBind("smg", "pwd", 1, seq)
+		bind.Type = sgip.SMG_SP
+		b, err := bind.IEncode()
+		assert.Nil(t, err)
+		data = append(data, b...)
+	}
+	_, err = smg.conn.Write(data)
+	assert.Nil(t, err)
+
+	results := map[sgip.RespStatus]int{}
+	for p := smg.read(t); p != nil; p = smg.read(t) {
+		results[p.(*sgip12.BindResp).Result]++
+	}
+	assert.Equal(t, map[sgip.RespStatus]int{sgip.STAT_OK: 1, sgip.STAT_RPTLOGIN: 1}, results)
+}
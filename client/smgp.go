@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+)
+
+// SMGPResultError is returned when the ISMG answers a request with a non-zero Status.
+type SMGPResultError struct {
+	Command smgp.CommandID
+	Status  uint32
+}
+
+func (e *SMGPResultError) Error() string {
+	return fmt.Sprintf("%s status: %d", e.Command.String(), e.Status)
+}
+
+// SMGPConfig is the configuration of an SMGP 3.0 client.
+type SMGPConfig struct {
+	// Addr is the ISMG address, e.g. "127.0.0.1:8890".
+	Addr string
+
+	ClientID string
+	Password string
+
+	// LoginMode is smgp.SEND_MODE(0), smgp.RECEIVE_MODE(1) or smgp.TRANSMIT_MODE(2).
+	// Note that the zero value is smgp.SEND_MODE, in which the ISMG sends no Deliver.
+	LoginMode uint8
+
+	// OnDeliver is called for every MO Deliver (IsReport=0).
+	// The returned status is sent back in DeliverResp. If nil, 0 is returned.
+	OnDeliver func(ctx context.Context, pdu *smgp30.Deliver) smgp.Status
+
+	// OnReport is called for every report Deliver (IsReport=1), with the receipt parsed from MsgContent.
+	// The returned status is sent back in DeliverResp. If nil, 0 is returned.
+	OnReport func(ctx context.Context, pdu *smgp30.Deliver, receipt *smgp30.DeliveryReceipt) smgp.Status
+}
+
+// SMGPClient is an SMGP 3.0 client.
+// Requests are matched with responses by sequence ID, ActiveTest is sent automatically
+// when the session is idle and answered when received, and Exit from the ISMG is answered.
+type SMGPClient struct {
+	cfg    SMGPConfig
+	opts   *options
+	keeper *keeper
+}
+
+// DialSMGP connects to the ISMG and logs in with cfg.LoginMode.
+func DialSMGP(ctx context.Context, cfg SMGPConfig, opts ...Option) (*SMGPClient, error) {
+	switch cfg.LoginMode {
+	case smgp.SEND_MODE, smgp.RECEIVE_MODE, smgp.TRANSMIT_MODE:
+	default:
+		return nil, fmt.Errorf("invalid login mode: %d", cfg.LoginMode)
+	}
+
+	c := &SMGPClient{cfg: cfg, opts: newOptions(opts...)}
+	c.keeper = newKeeper(c.opts, c.connect)
+	if err := c.keeper.start(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *SMGPClient) connect(ctx context.Context) (*session, error) {
+	return dial(ctx, c.cfg.Addr, codec.NewSMGPCodec(c.opts.codecOptions()...), smgp30.DecodeSMGP30, c.opts, c.setup, c.login)
+}
+
+func (c *SMGPClient) setup(s *session) {
+	s.handle = c.handle
+	s.heartbeat = func() protocol.PDU {
+		return &smgp30.ActiveTest{Header: smgp.NewHeader(0, smgp.CommandActiveTest, 0)}
+	}
+	s.unbind = func() protocol.PDU {
+		return &smgp30.Exit{Header: smgp.NewHeader(0, smgp.CommandExit, 0)}
+	}
+}
+
+func (c *SMGPClient) login(ctx context.Context, s *session) error {
+	req := smgp30.NewLogin(c.cfg.ClientID, c.cfg.Password, 0)
+	req.LoginMode = c.cfg.LoginMode
+
+	resp, err := s.request(ctx, req)
+	if err != nil {
+		return fmt.Errorf("login error: %w", err)
+	}
+	r, ok := resp.(*smgp30.LoginResp)
+	if !ok {
+		return fmt.Errorf("login error: unexpected response %s", resp.GetCommand().String())
+	}
+	if r.Status != smgp30.LoginRespStatusSuccess {
+		return fmt.Errorf("login error: %w", &SMGPResultError{Command: smgp.CommandLoginResp, Status: uint32(r.Status)})
+	}
+	return nil
+}
+
+// handle answers the requests initiated by the ISMG.
+func (c *SMGPClient) handle(ctx context.Context, s *session, p protocol.PDU) protocol.PDU {
+	pdu, ok := p.(*smgp30.Deliver)
+	if !ok {
+		// ActiveTest, Exit and others.
+		// After ExitResp is sent the ISMG closes the connection.
+		return p.GenEmptyResponse()
+	}
+
+	resp := pdu.GenEmptyResponse().(*smgp30.DeliverResp)
+	resp.MsgID = pdu.MsgID
+
+	if pdu.IsReport != smgp.IS_REPORT {
+		if c.cfg.OnDeliver != nil {
+			resp.Result = c.cfg.OnDeliver(ctx, pdu)
+		}
+		return resp
+	}
+
+	receipt := new(smgp30.DeliveryReceipt)
+	_ = receipt.IDecode(pdu.MsgContent)
+	if !receipt.Valid() {
+		c.opts.logger.Warnf("[%s] invalid delivery receipt: %s", s.RemoteAddr(), pdu.String())
+	}
+	if c.cfg.OnReport != nil {
+		resp.Result = c.cfg.OnReport(ctx, pdu, receipt)
+	}
+	return resp
+}
+
+// Submit sends a Submit and waits for its SubmitResp.
+// A non-zero Status is returned as a *SMGPResultError together with the response.
+func (c *SMGPClient) Submit(ctx context.Context, pdu *smgp30.Submit) (*smgp30.SubmitResp, error) {
+	if c.cfg.LoginMode == smgp.RECEIVE_MODE {
+		return nil, fmt.Errorf("submit is not allowed in RECEIVE_MODE")
+	}
+
+	pdu.Header.CommandID = smgp.CommandSubmit
+	resp, err := c.Request(ctx, pdu)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := resp.(*smgp30.SubmitResp)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %s", resp.GetCommand().String())
+	}
+	if r.Status != 0 {
+		return r, &SMGPResultError{Command: smgp.CommandSubmitResp, Status: r.Status}
+	}
+	return r, nil
+}
+
+// Request sends any request PDU and waits for the response with the same sequence ID.
+// The sequence ID of pdu is overwritten.
+func (c *SMGPClient) Request(ctx context.Context, pdu protocol.PDU) (protocol.PDU, error) {
+	s, err := c.keeper.session()
+	if err != nil {
+		return nil, err
+	}
+	return s.request(ctx, pdu)
+}
+
+// Close sends Exit and closes the connection. Reconnecting is stopped.
+func (c *SMGPClient) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.requestTimeout)
+	defer cancel()
+	return c.keeper.close(ctx)
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+)
+
+// smgwHandler answers login, submit, active test and exit.
+// The submit response carries the sequence ID in MsgID, and an empty DestTermID is rejected with status 9.
+func smgwHandler(events chan<- protocol.PDU) func(c *fakeConn, p protocol.PDU) {
+	return func(c *fakeConn, p protocol.PDU) {
+		select {
+		case events <- p:
+		default:
+		}
+		switch pdu := p.(type) {
+		case *smgp30.Login:
+			resp := pdu.GenEmptyResponse().(*smgp30.LoginResp)
+			if pdu.ClientID != "sp" {
+				resp.Status = smgp30.LoginRespStatusInvalidSourceAddr
+			}
+			c.send(resp)
+		case *smgp30.Submit:
+			resp := pdu.GenEmptyResponse().(*smgp30.SubmitResp)
+			resp.MsgID = fmt.Sprintf("%020d", pdu.GetSequenceID())
+			if pdu.DestTermIDCount == 0 {
+				resp.Status = 9
+			}
+			c.send(resp)
+		default:
+			if resp := p.GenEmptyResponse(); resp != nil {
+				c.send(resp)
+			}
+		}
+	}
+}
+
+func TestSMGPClient_Submit(t *testing.T) {
+	events := make(chan protocol.PDU, 64)
+	srv := newFakeServer(t, codec.NewSMGPCodec(), smgp30.DecodeSMGP30, smgwHandler(events))
+
+	ctx := context.Background()
+	cli, err := DialSMGP(ctx, SMGPConfig{Addr: srv.Addr(), ClientID: "sp", Password: "pwd", LoginMode: smgp.SEND_MODE}, WithWindow(4))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint8(smgp.SEND_MODE), (<-events).(*smgp30.Login).LoginMode)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			submit := &smgp30.Submit{DestTermIDCount: 1, DestTermID: []string{"13300000000"}}
+			resp, err := cli.Submit(ctx, submit)
+			if assert.Nil(t, err) {
+				assert.Equal(t, fmt.Sprintf("%020d", submit.GetSequenceID()), resp.MsgID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	resp, err := cli.Submit(ctx, &smgp30.Submit{})
+	var re *SMGPResultError
+	if assert.True(t, errors.As(err, &re)) {
+		assert.Equal(t, uint32(9), re.Status)
+		assert.Equal(t, uint32(9), resp.Status)
+	}
+
+	for len(events) > 0 {
+		<-events
+	}
+	assert.Nil(t, cli.Close())
+	assert.IsType(t, &smgp30.Exit{}, <-events)
+}
+
+func TestSMGPClient_Login(t *testing.T) {
+	events := make(chan protocol.PDU, 1)
+	srv := newFakeServer(t, codec.NewSMGPCodec(), smgp30.DecodeSMGP30, smgwHandler(events))
+	ctx := context.Background()
+
+	_, err := DialSMGP(ctx, SMGPConfig{Addr: srv.Addr(), ClientID: "bad", LoginMode: smgp.TRANSMIT_MODE})
+	var re *SMGPResultError
+	if assert.True(t, errors.As(err, &re)) {
+		assert.Equal(t, uint32(smgp30.LoginRespStatusInvalidSourceAddr), re.Status)
+	}
+	<-events
+
+	_, err = DialSMGP(ctx, SMGPConfig{Addr: srv.Addr(), ClientID: "sp", LoginMode: 3})
+	assert.NotNil(t, err)
+
+	cli, err := DialSMGP(ctx, SMGPConfig{Addr: srv.Addr(), ClientID: "sp", LoginMode: smgp.RECEIVE_MODE})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+	assert.Equal(t, uint8(smgp.RECEIVE_MODE), (<-events).(*smgp30.Login).LoginMode)
+
+	// no submit in RECEIVE_MODE
+	_, err = cli.Submit(ctx, &smgp30.Submit{DestTermIDCount: 1, DestTermID: []string{"13300000000"}})
+	assert.NotNil(t, err)
+}
+
+func TestSMGPClient_DeliverAndReport(t *testing.T) {
+	receipt := &smgp30.DeliveryReceipt{ID: "01006101161700012345", Sub: "001", Dlvrd: "001", SubDate: "2310171200", DoneDate: "2310171201", Stat: "DELIVRD", Err: "000"}
+	content, err := receipt.IEncode()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	events := make(chan protocol.PDU, 16)
+	srv := newFakeServer(t, codec.NewSMGPCodec(), smgp30.DecodeSMGP30, func(c *fakeConn, p protocol.PDU) {
+		smgwHandler(events)(c, p)
+		if p.GetCommand() != smgp.CommandLogin {
+			return
+		}
+		c.send(&smgp30.Deliver{Header: smgp.NewHeader(0, smgp.CommandDeliver, 7), MsgID: "01006101161700000007", MsgLength: 2, MsgContent: []byte("hi")})
+		c.send(&smgp30.Deliver{Header: smgp.NewHeader(0, smgp.CommandDeliver, 8), MsgID: "01006101161700000008", IsReport: smgp.IS_REPORT, MsgLength: uint8(len(content)), MsgContent: content})
+		c.send(&smgp30.ActiveTest{Header: smgp.NewHeader(0, smgp.CommandActiveTest, 9)})
+	})
+
+	delivers := make(chan *smgp30.Deliver, 1)
+	reports := make(chan *smgp30.DeliveryReceipt, 1)
+	cli, err := DialSMGP(context.Background(), SMGPConfig{
+		Addr:      srv.Addr(),
+		ClientID:  "sp",
+		LoginMode: smgp.TRANSMIT_MODE,
+		OnDeliver: func(ctx context.Context, pdu *smgp30.Deliver) smgp.Status {
+			delivers <- pdu
+			return 0
+		},
+		OnReport: func(ctx context.Context, pdu *smgp30.Deliver, receipt *smgp30.DeliveryReceipt) smgp.Status {
+			reports <- receipt
+			return 9
+		},
+	}, WithKeepalive(20*time.Millisecond, 3))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	select {
+	case pdu := <-delivers:
+		assert.Equal(t, []byte("hi"), pdu.MsgContent)
+	case <-time.After(time.Second):
+		t.Fatal("deliver not received")
+	}
+	select {
+	case r := <-reports:
+		assert.Equal(t, receipt.ID, r.ID)
+		assert.Equal(t, "DELIVRD", r.Stat)
+	case <-time.After(time.Second):
+		t.Fatal("report not received")
+	}
+
+	// login, then in any order: two deliver resps, active test resp and the client's own active test
+	assert.Equal(t, smgp.CommandLogin, (<-events).GetCommand())
+	deliverResps := make(map[string]*smgp30.DeliverResp)
+	seen := make(map[string]struct{})
+	for len(deliverResps) < 2 || len(seen) < 2 {
+		select {
+		case p := <-events:
+			if resp, ok := p.(*smgp30.DeliverResp); ok {
+				deliverResps[resp.MsgID] = resp
+				continue
+			}
+			seen[p.GetCommand().String()] = struct{}{}
+		case <-time.After(time.Second):
+			t.Fatalf("missing pdus, got %v %v", deliverResps, seen)
+		}
+	}
+	assert.Contains(t, seen, smgp.CommandActiveTest.String())
+	assert.Contains(t, seen, smgp.CommandActiveTestResp.String())
+	assert.Equal(t, smgp.Status(0), deliverResps["01006101161700000007"].Result)
+	assert.Equal(t, smgp.Status(9), deliverResps["01006101161700000008"].Result)
+}
+
+func TestSMGPClient_Exit(t *testing.T) {
+	events := make(chan protocol.PDU, 16)
+	var mu sync.Mutex
+	logins := 0
+	srv := newFakeServer(t, codec.NewSMGPCodec(), smgp30.DecodeSMGP30, func(c *fakeConn, p protocol.PDU) {
+		smgwHandler(events)(c, p)
+		if _, ok := p.(*smgp30.ExitResp); ok {
+			_ = c.Close()
+			return
+		}
+		if p.GetCommand() != smgp.CommandLogin {
+			return
+		}
+		mu.Lock()
+		logins++
+		first := logins == 1
+		mu.Unlock()
+		if first {
+			// the ISMG asks the first session to exit and closes it after ExitResp
+			c.send(&smgp30.Exit{Header: smgp.NewHeader(0, smgp.CommandExit, 1)})
+		}
+	})
+
+	cli, err := DialSMGP(context.Background(), SMGPConfig{Addr: srv.Addr(), ClientID: "sp", LoginMode: smgp.TRANSMIT_MODE},
+		WithReconnect(10*time.Millisecond, 0))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	assert.Equal(t, smgp.CommandLogin, (<-events).GetCommand())
+	select {
+	case p := <-events:
+		assert.IsType(t, &smgp30.ExitResp{}, p)
+	case <-time.After(time.Second):
+		t.Fatal("exit resp not received")
+	}
+
+	// the client logs in again and keeps working
+	select {
+	case p := <-events:
+		assert.Equal(t, smgp.CommandLogin, p.GetCommand())
+	case <-time.After(time.Second):
+		t.Fatal("no re-login")
+	}
+	assert.Eventually(t, func() bool {
+		_, err := cli.Submit(context.Background(), &smgp30.Submit{DestTermIDCount: 1, DestTermID: []string{"13300000000"}})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// SMPPConfig is the configuration of an SMPP 3.4 ESME client.
+type SMPPConfig struct {
+	// Addr is the SMSC address, e.g. "127.0.0.1:2775".
+	Addr string
+
+	SystemID   string
+	Password   string
+	SystemType string
+
+	// BindType is one of smpp.BIND_TRANSMITTER, smpp.BIND_RECEIVER and smpp.BIND_TRANSCEIVER.
+	// Defaults to smpp.BIND_TRANSCEIVER.
+	BindType smpp.CMDId
+
+	AddrTon      uint8
+	AddrNpi      uint8
+	AddressRange string
+
+	// OnDeliverSm is called for every deliver_sm received from the SMSC, including delivery receipts.
+	// The returned status is sent back in deliver_sm_resp. If nil, ESME_ROK is returned.
+	OnDeliverSm func(ctx context.Context, pdu *smpp34.DeliverSm) smpp.CMDStatus
+
+	// OnDataSm is called for every data_sm received from the SMSC, which some SMSCs use for MO messages
+	// and delivery receipts. The message is in the message_payload TLV, see DataSm.MessageContent.
+	// The returned status is sent back in data_sm_resp. If nil, ESME_ROK is returned.
+	OnDataSm func(ctx context.Context, pdu *smpp34.DataSm) smpp.CMDStatus
+}
+
+// SMPPClient is an SMPP 3.4 ESME client.
+// Requests are matched with responses by sequence number, enquire_link is sent automatically
+// when the session is idle, and deliver_sm and data_sm are passed to SMPPConfig.OnDeliverSm and OnDataSm.
+type SMPPClient struct {
+	cfg    SMPPConfig
+	opts   *options
+	keeper *keeper
+}
+
+// DialSMPP connects to the SMSC and binds with cfg.BindType.
+func DialSMPP(ctx context.Context, cfg SMPPConfig, opts ...Option) (*SMPPClient, error) {
+	if cfg.BindType == 0 {
+		cfg.BindType = smpp.BIND_TRANSCEIVER
+	}
+	switch cfg.BindType {
+	case smpp.BIND_TRANSMITTER, smpp.BIND_RECEIVER, smpp.BIND_TRANSCEIVER:
+	default:
+		return nil, fmt.Errorf("invalid bind type: %s", cfg.BindType.String())
+	}
+
+	c := &SMPPClient{cfg: cfg, opts: newOptions(opts...)}
+	c.keeper = newKeeper(c.opts, c.connect)
+	if err := c.keeper.start(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *SMPPClient) connect(ctx context.Context) (*session, error) {
+	return dial(ctx, c.cfg.Addr, codec.NewSMPPCodec(c.opts.codecOptions()...), smpp34.DecodeSMPP34, c.opts, c.setup, c.bind)
+}
+
+func (c *SMPPClient) setup(s *session) {
+	s.handle = c.handle
+	s.unsupported = c.unsupported
+	s.heartbeat = func() protocol.PDU {
+		return &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK}}
+	}
+	s.unbind = func() protocol.PDU {
+		return &smpp34.Unbind{Header: smpp.Header{ID: smpp.UNBIND}}
+	}
+}
+
+func (c *SMPPClient) bind(ctx context.Context, s *session) error {
+	req := &smpp34.Bind{
+		Header:           smpp.Header{ID: c.cfg.BindType},
+		SystemID:         c.cfg.SystemID,
+		Password:         c.cfg.Password,
+		SystemType:       c.cfg.SystemType,
+		InterfaceVersion: uint8(consts.SMPPVersion3_4),
+		AddrTon:          c.cfg.AddrTon,
+		AddrNpi:          c.cfg.AddrNpi,
+		AddressRange:     c.cfg.AddressRange,
+	}
+	resp, err := s.request(ctx, req)
+	if err != nil {
+		return fmt.Errorf("bind error: %w", err)
+	}
+
+	switch r := resp.(type) {
+	case *smpp34.BindResp:
+		if r.Header.Status != smpp.ESME_ROK {
+			return fmt.Errorf("bind error: %w", r.Header.Status)
+		}
+		return nil
+	case *smpp34.GenericNack:
+		return fmt.Errorf("bind error: %w", r.Header.Status)
+	default:
+		return fmt.Errorf("bind error: unexpected response %s", resp.GetCommand().String())
+	}
+}
+
+// handle answers the requests initiated by the SMSC.
+func (c *SMPPClient) handle(ctx context.Context, s *session, p protocol.PDU) protocol.PDU {
+	switch pdu := p.(type) {
+	case *smpp34.DeliverSm:
+		status := smpp.ESME_ROK
+		if c.cfg.OnDeliverSm != nil {
+			status = c.cfg.OnDeliverSm(ctx, pdu)
+		}
+		resp := pdu.GenEmptyResponse().(*smpp34.DeliverSmResp)
+		resp.Header.Status = status
+		return resp
+	case *smpp34.DataSm:
+		status := smpp.ESME_ROK
+		if c.cfg.OnDataSm != nil {
+			status = c.cfg.OnDataSm(ctx, pdu)
+		}
+		resp := pdu.GenEmptyResponse().(*smpp34.DataSmResp)
+		resp.Header.Status = status
+		return resp
+	case *smpp34.Unbind:
+		// the SMSC is going away, reply and let the connection be closed by the peer
+		return pdu.GenEmptyResponse()
+	case *smpp34.EnquireLink:
+		return pdu.GenEmptyResponse()
+	default:
+		if resp := p.GenEmptyResponse(); resp != nil {
+			return resp
+		}
+		return &smpp34.GenericNack{Header: smpp.Header{
+			ID:       smpp.GENERIC_NACK,
+			Status:   smpp.ESME_RINVCMDID,
+			Sequence: p.GetSequenceID(),
+		}}
+	}
+}
+
+// unsupported answers a request with an unknown command ID with generic_nack.
+func (c *SMPPClient) unsupported(data []byte) protocol.PDU {
+	h, err := smpp.PeekHeader(data)
+	if err != nil || h.ID.ToUint32()&responseMask != 0 {
+		return nil
+	}
+	return &smpp34.GenericNack{Header: smpp.Header{
+		ID:       smpp.GENERIC_NACK,
+		Status:   smpp.ESME_RINVCMDID,
+		Sequence: h.Sequence,
+	}}
+}
+
+// Submit sends a submit_sm and waits for its submit_sm_resp.
+// A non-OK command status is returned as the error (of type smpp.CMDStatus) together with the response.
+func (c *SMPPClient) Submit(ctx context.Context, pdu *smpp34.SubmitSm) (*smpp34.SubmitSmResp, error) {
+	pdu.Header.ID = smpp.SUBMIT_SM
+	resp, err := c.Request(ctx, pdu)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := resp.(type) {
+	case *smpp34.SubmitSmResp:
+		if r.Header.Status != smpp.ESME_ROK {
+			return r, r.Header.Status
+		}
+		return r, nil
+	case *smpp34.GenericNack:
+		return nil, r.Header.Status
+	default:
+		return nil, fmt.Errorf("unexpected response %s", resp.GetCommand().String())
+	}
+}
+
+// Request sends any request PDU and waits for the response with the same sequence number.
+// The sequence number of pdu is overwritten.
+func (c *SMPPClient) Request(ctx context.Context, pdu protocol.PDU) (protocol.PDU, error) {
+	s, err := c.keeper.session()
+	if err != nil {
+		return nil, err
+	}
+	return s.request(ctx, pdu)
+}
+
+// Close unbinds from the SMSC and closes the connection. Reconnecting is stopped.
+func (c *SMPPClient) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.requestTimeout)
+	defer cancel()
+	return c.keeper.close(ctx)
+}
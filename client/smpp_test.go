@@ -0,0 +1,294 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// smscHandler answers bind, submit_sm, enquire_link and unbind.
+// submit_sm_resp carries the destination address as the message id, and is written after a random delay
+// so responses come back out of order.
+func smscHandler(events chan<- protocol.PDU) func(c *fakeConn, p protocol.PDU) {
+	return func(c *fakeConn, p protocol.PDU) {
+		select {
+		case events <- p:
+		default:
+		}
+		switch pdu := p.(type) {
+		case *smpp34.Bind:
+			resp := &smpp34.BindResp{Header: smpp.Header{ID: pdu.Header.ID | 0x80000000, Sequence: pdu.Header.Sequence}, SystemID: "smsc"}
+			if pdu.Password != "pwd" {
+				resp.Header.Status = smpp.ESME_RINVPASWD
+			}
+			c.send(resp)
+		case *smpp34.SubmitSm:
+			go func() {
+				time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+				c.send(&smpp34.SubmitSmResp{
+					Header:    smpp.Header{ID: smpp.SUBMIT_SM_RESP, Sequence: pdu.Header.Sequence},
+					MessageID: pdu.DestinationAddr,
+				})
+			}()
+		case *smpp34.EnquireLink, *smpp34.Unbind:
+			c.send(p.GenEmptyResponse())
+		}
+	}
+}
+
+func TestSMPPClient_Submit(t *testing.T) {
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, smscHandler(nil))
+
+	ctx := context.Background()
+	cli, err := DialSMPP(ctx, SMPPConfig{Addr: srv.Addr(), SystemID: "sp", Password: "pwd"}, WithWindow(4))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dest := fmt.Sprintf("1380000%04d", i)
+			resp, err := cli.Submit(ctx, &smpp34.SubmitSm{DestinationAddr: dest, SmLength: 5, ShortMessage: []byte("hello")})
+			if assert.Nil(t, err) {
+				assert.Equal(t, dest, resp.MessageID)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestSMPPClient_BindFailed(t *testing.T) {
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, smscHandler(nil))
+
+	_, err := DialSMPP(context.Background(), SMPPConfig{Addr: srv.Addr(), SystemID: "sp", Password: "bad", BindType: smpp.BIND_TRANSMITTER})
+	assert.True(t, errors.Is(err, smpp.ESME_RINVPASWD))
+}
+
+func TestSMPPClient_DeliverSm(t *testing.T) {
+	events := make(chan protocol.PDU, 16)
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, func(c *fakeConn, p protocol.PDU) {
+		smscHandler(events)(c, p)
+		if _, ok := p.(*smpp34.Bind); ok {
+			c.send(&smpp34.DeliverSm{
+				Header:       smpp.Header{ID: smpp.DELIVER_SM, Sequence: 100},
+				SmLength:     2,
+				SourceAddr:   "13800000000",
+				ShortMessage: []byte("hi"),
+			})
+		}
+	})
+
+	received := make(chan *smpp34.DeliverSm, 1)
+	cli, err := DialSMPP(context.Background(), SMPPConfig{
+		Addr:     srv.Addr(),
+		SystemID: "sp",
+		Password: "pwd",
+		BindType: smpp.BIND_RECEIVER,
+		OnDeliverSm: func(ctx context.Context, pdu *smpp34.DeliverSm) smpp.CMDStatus {
+			received <- pdu
+			return smpp.ESME_ROK
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	select {
+	case pdu := <-received:
+		assert.Equal(t, "13800000000", pdu.SourceAddr)
+		assert.Equal(t, []byte("hi"), pdu.ShortMessage)
+	case <-time.After(time.Second):
+		t.Fatal("deliver_sm not received")
+	}
+
+	// bind, deliver_sm_resp and unbind
+	assert.Equal(t, smpp.BIND_RECEIVER, (<-events).(*smpp34.Bind).Header.ID)
+	resp := (<-events).(*smpp34.DeliverSmResp)
+	assert.Equal(t, uint32(100), resp.Header.Sequence)
+
+	assert.Nil(t, cli.Close())
+	assert.IsType(t, &smpp34.Unbind{}, <-events)
+
+	_, err = cli.Submit(context.Background(), &smpp34.SubmitSm{})
+	assert.Equal(t, ErrClosed, err)
+}
+
+func TestSMPPClient_DeliverSmOrder(t *testing.T) {
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, func(c *fakeConn, p protocol.PDU) {
+		smscHandler(nil)(c, p)
+		if _, ok := p.(*smpp34.Bind); ok {
+			for i := 1; i <= 50; i++ {
+				c.send(&smpp34.DeliverSm{Header: smpp.Header{ID: smpp.DELIVER_SM, Sequence: uint32(i)}})
+			}
+		}
+	})
+
+	var running int32
+	received := make(chan uint32, 50)
+	cli, err := DialSMPP(context.Background(), SMPPConfig{
+		Addr:     srv.Addr(),
+		SystemID: "sp",
+		Password: "pwd",
+		OnDeliverSm: func(ctx context.Context, pdu *smpp34.DeliverSm) smpp.CMDStatus {
+			assert.Equal(t, int32(1), atomic.AddInt32(&running, 1))
+			time.Sleep(time.Millisecond)
+			received <- pdu.Header.Sequence
+			atomic.AddInt32(&running, -1)
+			return smpp.ESME_ROK
+		},
+	}, WithWindow(4))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	for i := 1; i <= 50; i++ {
+		select {
+		case seq := <-received:
+			assert.Equal(t, uint32(i), seq)
+		case <-time.After(time.Second):
+			t.Fatalf("deliver_sm %d not received", i)
+		}
+	}
+}
+
+func TestSMPPClient_UnsupportedRequest(t *testing.T) {
+	events := make(chan protocol.PDU, 16)
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, func(c *fakeConn, p protocol.PDU) {
+		smscHandler(events)(c, p)
+		if _, ok := p.(*smpp34.Bind); ok {
+			// a request with an unknown command ID
+			c.mu.Lock()
+			_, _ = c.Write([]byte{0, 0, 0, 16, 0, 0, 0x01, 0x99, 0, 0, 0, 0, 0, 0, 0, 9})
+			c.mu.Unlock()
+		}
+	})
+
+	cli, err := DialSMPP(context.Background(), SMPPConfig{Addr: srv.Addr(), SystemID: "sp", Password: "pwd"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	<-events // bind
+	select {
+	case p := <-events:
+		nack, ok := p.(*smpp34.GenericNack)
+		if assert.True(t, ok, p.String()) {
+			assert.Equal(t, smpp.ESME_RINVCMDID, nack.Header.Status)
+			assert.Equal(t, uint32(9), nack.Header.Sequence)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("generic_nack not sent")
+	}
+}
+
+func TestSMPPClient_EnquireLink(t *testing.T) {
+	events := make(chan protocol.PDU, 16)
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, smscHandler(events))
+
+	cli, err := DialSMPP(context.Background(), SMPPConfig{Addr: srv.Addr(), SystemID: "sp", Password: "pwd"},
+		WithKeepalive(20*time.Millisecond, 3))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	<-events // bind
+	select {
+	case p := <-events:
+		assert.IsType(t, &smpp34.EnquireLink{}, p)
+	case <-time.After(time.Second):
+		t.Fatal("enquire_link not sent")
+	}
+}
+
+func TestSMPPClient_Reconnect(t *testing.T) {
+	var mu sync.Mutex
+	var conns []*fakeConn
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, func(c *fakeConn, p protocol.PDU) {
+		if _, ok := p.(*smpp34.Bind); ok {
+			mu.Lock()
+			conns = append(conns, c)
+			mu.Unlock()
+		}
+		smscHandler(nil)(c, p)
+	})
+
+	cli, err := DialSMPP(context.Background(), SMPPConfig{Addr: srv.Addr(), SystemID: "sp", Password: "pwd"},
+		WithReconnect(10*time.Millisecond, 0))
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	mu.Lock()
+	_ = conns[0].Close()
+	mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		_, err := cli.Submit(context.Background(), &smpp34.SubmitSm{DestinationAddr: "1"})
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, 2, len(conns))
+	mu.Unlock()
+}
+
+func TestSMPPClient_DataSm(t *testing.T) {
+	events := make(chan protocol.PDU, 16)
+	srv := newFakeServer(t, codec.NewSMPPCodec(), smpp34.DecodeSMPP34, func(c *fakeConn, p protocol.PDU) {
+		smscHandler(events)(c, p)
+		if _, ok := p.(*smpp34.Bind); ok {
+			d := &smpp34.DataSm{Header: smpp.Header{ID: smpp.DATA_SM, Sequence: 101}, SourceAddr: "13800000000"}
+			// larger than codec.DefaultMaxFrameLength
+			d.TLVs.SetTLV(smpp.NewTLVByString(smpp.MESSAGE_PAYLOAD, strings.Repeat("x", 60000)))
+			c.send(d)
+		}
+	})
+
+	received := make(chan *smpp34.DataSm, 1)
+	cli, err := DialSMPP(context.Background(), SMPPConfig{
+		Addr:     srv.Addr(),
+		SystemID: "sp",
+		Password: "pwd",
+		OnDataSm: func(ctx context.Context, pdu *smpp34.DataSm) smpp.CMDStatus {
+			received <- pdu
+			return smpp.ESME_RX_T_APPN
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer cli.Close()
+
+	select {
+	case pdu := <-received:
+		assert.Equal(t, []byte(strings.Repeat("x", 60000)), pdu.MessageContent())
+	case <-time.After(time.Second):
+		t.Fatal("data_sm not received")
+	}
+
+	<-events
+	resp := (<-events).(*smpp34.DataSmResp)
+	assert.Equal(t, uint32(101), resp.Header.Sequence)
+	assert.Equal(t, smpp.ESME_RX_T_APPN, resp.Header.Status)
+}
@@ -32,6 +32,12 @@ const (
 	MaxQueryLength = HeaderLength + 8 + 1 + 10 + 8
 	// MaxQueryRespLength is the maximum length of a QueryResp PDU (51 bytes).
 	MaxQueryRespLength = HeaderLength + 8 + 1 + 10 + 4 + 4 + 4 + 4 + 4 + 4 + 4 + 4
+	// MaxCancelLength is the maximum length of a Cancel PDU (20 bytes).
+	MaxCancelLength = HeaderLength + 8
+	// MaxCancelRespLength is the maximum length of a CancelResp PDU (13 bytes).
+	MaxCancelRespLength = HeaderLength + 1
+	// MaxFwdRespLength is the maximum length of a FwdResp PDU (23 bytes).
+	MaxFwdRespLength = HeaderLength + 8 + 1 + 1 + 1
 )
 
 const (
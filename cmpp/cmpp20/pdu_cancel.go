@@ -0,0 +1,158 @@
+package cmpp20
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/packet"
+)
+
+// PduCancel represents a CMPP Cancel PDU.
+// It is used by the SP to request the deletion of a previously submitted (e.g. scheduled) short message.
+type PduCancel struct {
+	cmpp.Header
+
+	// MsgID is the message identifier of the message to be cancelled (8 bytes).
+	MsgID uint64
+}
+
+// NewCancel creates a new PduCancel PDU for the MsgID in the form returned by cmpp.MsgID2String.
+func NewCancel(msgID string, seqID uint32) (*PduCancel, error) {
+	id, err := cmpp.ParseMsgID(msgID)
+	if err != nil {
+		return nil, err
+	}
+	return &PduCancel{
+		Header: cmpp.NewHeader(MaxCancelLength, cmpp.CommandCancel, seqID),
+		MsgID:  id,
+	}, nil
+}
+
+// IEncode encodes the PduCancel PDU into a byte slice.
+func (p *PduCancel) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter(0)
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(p.Header, b)
+	b.WriteUint64(p.MsgID)
+
+	return b.BytesWithLength()
+}
+
+// IDecode decodes the byte slice into a PduCancel PDU.
+func (p *PduCancel) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return ErrInvalidPudLength
+	}
+
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = cmpp.ReadHeader(b)
+	p.MsgID = b.ReadUint64()
+
+	return b.Error()
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (p *PduCancel) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (p *PduCancel) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+// GetCommand returns the command ID of the PDU.
+func (p *PduCancel) GetCommand() sms.ICommander {
+	return cmpp.CommandCancel
+}
+
+// GenEmptyResponse generates an empty response PDU for the PduCancel.
+func (p *PduCancel) GenEmptyResponse() sms.PDU {
+	return &PduCancelResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandCancelResp,
+			SequenceID: p.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the PduCancel PDU.
+func (p *PduCancel) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("MsgID", cmpp.MsgID2String(p.MsgID))
+
+	return w.String()
+}
+
+// --------------
+
+// PduCancelResp represents a CMPP CancelResp PDU.
+// It is the response to a PduCancel.
+type PduCancelResp struct {
+	cmpp.Header
+
+	// SuccessID indicates the result of the cancel operation (1 byte): 0 for success, 1 for failure.
+	SuccessID uint8
+}
+
+// IEncode encodes the PduCancelResp PDU into a byte slice.
+func (p *PduCancelResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter(0)
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(p.Header, b)
+	b.WriteUint8(p.SuccessID)
+
+	return b.BytesWithLength()
+}
+
+// IDecode decodes the byte slice into a PduCancelResp PDU.
+func (p *PduCancelResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return ErrInvalidPudLength
+	}
+
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = cmpp.ReadHeader(b)
+	p.SuccessID = b.ReadUint8()
+
+	return b.Error()
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (p *PduCancelResp) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (p *PduCancelResp) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+// GetCommand returns the command ID of the PDU.
+func (p *PduCancelResp) GetCommand() sms.ICommander {
+	return cmpp.CommandCancelResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for CancelResp).
+func (p *PduCancelResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the PduCancelResp PDU.
+func (p *PduCancelResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("SuccessID", p.SuccessID)
+
+	return w.String()
+}
@@ -0,0 +1,49 @@
+package cmpp20
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+)
+
+func TestPduCancel(t *testing.T) {
+	dataExpected := []byte{
+		0x00, 0x00, 0x00, 0x14, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x17,
+		0x8f, 0x2b, 0x80, 0x00, 0x01, 0x2c, 0x80, 0x00,
+	}
+	c, err := NewCancel("0830105600000030032768", 0x17)
+	assert.Nil(t, err)
+	data, err := c.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, dataExpected, data)
+
+	pdu, err := DecodeCMPP20(dataExpected)
+	assert.Nil(t, err)
+	assert.Equal(t, c, pdu)
+	assert.Equal(t, "0830105600000030032768", cmpp.MsgID2String(pdu.(*PduCancel).MsgID))
+
+	_, err = NewCancel("0830105600000030099999", 1)
+	assert.True(t, errors.Is(err, cmpp.ErrInvalidMsgID))
+}
+
+func TestPduCancelResp(t *testing.T) {
+	dataExpected := []byte{
+		0x00, 0x00, 0x00, 0x0d, 0x80, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x17,
+		0x01,
+	}
+	pdu, err := DecodeCMPP20(dataExpected)
+	assert.Nil(t, err)
+	resp, ok := pdu.(*PduCancelResp)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint32(MaxCancelRespLength), resp.TotalLength)
+		assert.Equal(t, uint8(1), resp.SuccessID)
+		assert.Nil(t, resp.GenEmptyResponse())
+	}
+
+	data, err := resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, dataExpected, data)
+}
@@ -106,6 +106,7 @@ type PduConnectResp struct {
 	Status ConnectRespStatus
 
 	// AuthenticatorISMG is the ISMG authenticator code (16 bytes, MD5(Status + req.AuthenticatorSource + password)).
+	// It is a digest, so all 16 bytes are kept on decode.
 	AuthenticatorISMG string
 
 	// Version is the highest version number supported by the server (1 byte).
@@ -134,7 +135,7 @@ func (pr *PduConnectResp) IDecode(data []byte) error {
 	buf := packet.NewPacketReader(data)
 	pr.Header = cmpp.ReadHeader(buf)
 	pr.Status = ConnectRespStatus(buf.ReadUint8())
-	pr.AuthenticatorISMG = buf.ReadCStringN(16)
+	pr.AuthenticatorISMG = buf.ReadCStringNWithoutTrim(16)
 	pr.Version = buf.ReadUint8()
 
 	return buf.Error()
@@ -42,7 +42,12 @@ func TestPduConnectResp(t *testing.T) {
 	assert.Equal(t, uint32(38), c.SequenceID)
 	assert.Equal(t, ConnectRespStatus(0), c.Status)
 	assert.Equal(t, ConnectRespStatusSuccess.String(), c.Status.String())
-	assert.Equal(t, "", c.AuthenticatorISMG)
+	assert.Equal(t, string(make([]byte, 16)), c.AuthenticatorISMG)
 	assert.Equal(t, uint8(0), c.Version)
 	t.Log(c.String())
+
+	// the digest is kept whole, bytes after a 0x00 are not dropped
+	copy(data[13:], "ab\x00cd")
+	assert.Nil(t, c.IDecode(data))
+	assert.Equal(t, "ab\x00cd"+string(make([]byte, 11)), c.AuthenticatorISMG)
 }
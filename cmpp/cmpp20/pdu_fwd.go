@@ -0,0 +1,325 @@
+package cmpp20
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/packet"
+)
+
+// PduFwd represents a CMPP 2.0 Fwd PDU.
+// It is used between ISMGs to forward MT/MO messages and their status reports.
+type PduFwd struct {
+	cmpp.Header
+
+	// SourceID is the code of the source ISMG (6 bytes).
+	SourceID string
+
+	// DestinationID is the code of the destination ISMG (6 bytes).
+	DestinationID string
+
+	// NodesCount is the number of ISMGs the message has passed through (1 byte), increased by each forwarding ISMG.
+	NodesCount uint8
+
+	// MsgFwdType is the type of the forwarded message (1 byte): 0=MT, 1=MO, 2=MT status report, 3=MO status report.
+	// See cmpp.MsgFwdTypeMT etc.
+	MsgFwdType uint8
+
+	// MsgID is the message identifier (8 bytes).
+	MsgID uint64
+
+	// PkTotal is the total number of packets for the same MsgID (1 byte, starts from 1).
+	PkTotal uint8
+
+	// PkNumber is the sequence number for the same MsgID (1 byte, starts from 1).
+	PkNumber uint8
+
+	// RegisteredDelivery indicates if a status report is required (1 byte): 0=No, 1=Yes.
+	// For status reports (MsgFwdType 2 and 3) it is always 1.
+	RegisteredDelivery uint8
+
+	// MsgLevel is the message priority level (1 byte).
+	MsgLevel uint8
+
+	// ServiceID is the service type (10 bytes), a combination of digits, letters, and symbols.
+	ServiceID string
+
+	// FeeUserType indicates the billing user type (1 byte): 0=Destination terminal, 1=Source terminal, 2=SP, 3=Field invalid (refer to FeeTerminalID).
+	FeeUserType uint8
+
+	// FeeTerminalID is the billed user's number (21 bytes).
+	FeeTerminalID string
+
+	// TpPID is the GSM protocol type (1 byte). See GSM 03.40 section 9.2.3.9.
+	TpPID uint8
+
+	// TpUDHI is the GSM protocol type (1 byte). See GSM 03.40 section 9.2.3.23 (only 1 bit used, right-aligned).
+	TpUDHI uint8
+
+	// MsgFmt is the message format (1 byte): 0=ASCII, 3=SMS Write Card, 4=Binary, 8=UCS2, 15=GB Hanzi.
+	MsgFmt uint8
+
+	// MsgSrc is the message source (SP ID) (6 bytes).
+	MsgSrc string
+
+	// FeeType is the fee category (2 bytes).
+	FeeType string
+
+	// FeeCode is the fee code (6 bytes, in cents).
+	FeeCode string
+
+	// ValIDTime is the validity period (17 bytes, SMPP 3.3 format).
+	ValIDTime string
+
+	// AtTime is the scheduled delivery time (17 bytes, SMPP 3.3 format).
+	AtTime string
+
+	// SrcTerminalID is the source number (21 bytes): the SP's service code for MT, the MSISDN for MO.
+	SrcTerminalID string
+
+	// DestUsrTL is the number of recipient users (1 byte, < 100).
+	DestUsrTL uint8
+
+	// DestTerminalID is the list of recipient numbers (21 * DestUsrTL bytes).
+	DestTerminalID []string
+
+	// MsgLength is the message length (1 byte).
+	MsgLength uint8
+
+	// MsgContent is the message content (MsgLength bytes).
+	// For status reports it has the same layout as the status report of a Deliver PDU.
+	MsgContent []byte
+
+	// Reserve is a reserved field (8 bytes).
+	Reserve string
+}
+
+// IEncode encodes the PduFwd PDU into a byte slice.
+func (p *PduFwd) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter(0)
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(p.Header, b)
+	b.WriteFixedLenString(p.SourceID, 6)
+	b.WriteFixedLenString(p.DestinationID, 6)
+	b.WriteUint8(p.NodesCount)
+	b.WriteUint8(p.MsgFwdType)
+	b.WriteUint64(p.MsgID)
+	b.WriteUint8(p.PkTotal)
+	b.WriteUint8(p.PkNumber)
+	b.WriteUint8(p.RegisteredDelivery)
+	b.WriteUint8(p.MsgLevel)
+	b.WriteFixedLenString(p.ServiceID, 10)
+	b.WriteUint8(p.FeeUserType)
+	b.WriteFixedLenString(p.FeeTerminalID, 21)
+	b.WriteUint8(p.TpPID)
+	b.WriteUint8(p.TpUDHI)
+	b.WriteUint8(p.MsgFmt)
+	b.WriteFixedLenString(p.MsgSrc, 6)
+	b.WriteFixedLenString(p.FeeType, 2)
+	b.WriteFixedLenString(p.FeeCode, 6)
+	b.WriteFixedLenString(p.ValIDTime, 17)
+	b.WriteFixedLenString(p.AtTime, 17)
+	b.WriteFixedLenString(p.SrcTerminalID, 21)
+	b.WriteUint8(p.DestUsrTL)
+	for _, dest := range p.DestTerminalID {
+		b.WriteFixedLenString(dest, 21)
+	}
+	b.WriteUint8(p.MsgLength)
+	b.WriteBytes(p.MsgContent)
+	b.WriteFixedLenString(p.Reserve, 8)
+
+	return b.BytesWithLength()
+}
+
+// IDecode decodes the byte slice into a PduFwd PDU.
+func (p *PduFwd) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = cmpp.ReadHeader(b)
+	p.SourceID = b.ReadCStringN(6)
+	p.DestinationID = b.ReadCStringN(6)
+	p.NodesCount = b.ReadUint8()
+	p.MsgFwdType = b.ReadUint8()
+	p.MsgID = b.ReadUint64()
+	p.PkTotal = b.ReadUint8()
+	p.PkNumber = b.ReadUint8()
+	p.RegisteredDelivery = b.ReadUint8()
+	p.MsgLevel = b.ReadUint8()
+	p.ServiceID = b.ReadCStringN(10)
+	p.FeeUserType = b.ReadUint8()
+	p.FeeTerminalID = b.ReadCStringN(21)
+	p.TpPID = b.ReadUint8()
+	p.TpUDHI = b.ReadUint8()
+	p.MsgFmt = b.ReadUint8()
+	p.MsgSrc = b.ReadCStringN(6)
+	p.FeeType = b.ReadCStringN(2)
+	p.FeeCode = b.ReadCStringN(6)
+	p.ValIDTime = b.ReadCStringN(17)
+	p.AtTime = b.ReadCStringN(17)
+	p.SrcTerminalID = b.ReadCStringN(21)
+	p.DestUsrTL = b.ReadUint8()
+	p.DestTerminalID = make([]string, p.DestUsrTL)
+	for i := 0; i < int(p.DestUsrTL); i++ {
+		p.DestTerminalID[i] = b.ReadCStringN(21)
+	}
+	p.MsgLength = b.ReadUint8()
+	p.MsgContent = b.ReadNBytes(int(p.MsgLength))
+	p.Reserve = b.ReadCStringN(8)
+
+	return b.Error()
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (p *PduFwd) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (p *PduFwd) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+// GetCommand returns the command ID of the PDU.
+func (p *PduFwd) GetCommand() sms.ICommander {
+	return cmpp.CommandFwd
+}
+
+// GenEmptyResponse generates an empty response PDU for the PduFwd, with MsgID, PkTotal and PkNumber copied.
+func (p *PduFwd) GenEmptyResponse() sms.PDU {
+	return &PduFwdResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandFwdResp,
+			SequenceID: p.GetSequenceID(),
+		},
+		MsgID:    p.MsgID,
+		PkTotal:  p.PkTotal,
+		PkNumber: p.PkNumber,
+	}
+}
+
+// String returns a string representation of the PduFwd PDU.
+func (p *PduFwd) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("SourceID", p.SourceID)
+	w.Write("DestinationID", p.DestinationID)
+	w.Write("NodesCount", p.NodesCount)
+	w.Write("MsgFwdType", p.MsgFwdType)
+	w.Write("MsgID", p.MsgID)
+	w.Write("PkTotal", p.PkTotal)
+	w.Write("PkNumber", p.PkNumber)
+	w.Write("RegisteredDelivery", p.RegisteredDelivery)
+	w.Write("MsgLevel", p.MsgLevel)
+	w.Write("ServiceID", p.ServiceID)
+	w.Write("FeeUserType", p.FeeUserType)
+	w.Write("FeeTerminalID", p.FeeTerminalID)
+	w.Write("TpPID", p.TpPID)
+	w.Write("TpUDHI", p.TpUDHI)
+	w.Write("MsgFmt", p.MsgFmt)
+	w.Write("MsgSrc", p.MsgSrc)
+	w.Write("FeeType", p.FeeType)
+	w.Write("FeeCode", p.FeeCode)
+	w.Write("ValIDTime", p.ValIDTime)
+	w.Write("AtTime", p.AtTime)
+	w.Write("SrcTerminalID", p.SrcTerminalID)
+	w.Write("DestUsrTL", p.DestUsrTL)
+	w.Write("DestTerminalID", p.DestTerminalID)
+	w.Write("MsgLength", p.MsgLength)
+	w.WriteWithBytes("MsgContent", p.MsgContent)
+	w.Write("Reserve", p.Reserve)
+
+	return w.String()
+}
+
+// --------------
+
+// PduFwdResp represents a CMPP 2.0 FwdResp PDU.
+// It is the response to a PduFwd.
+type PduFwdResp struct {
+	cmpp.Header
+
+	// MsgID is the message identifier of the PduFwd (8 bytes).
+	MsgID uint64
+
+	// PkTotal is the PkTotal of the PduFwd (1 byte).
+	PkTotal uint8
+
+	// PkNumber is the PkNumber of the PduFwd (1 byte).
+	PkNumber uint8
+
+	// Result is the result of the forwarding (1 byte): 0=Success, 1=Invalid message structure, 2=Invalid command ID,
+	// 3=Duplicate sequence ID, 4=Invalid message length, 5=Invalid fee code, 6=Message length exceeds maximum,
+	// 7=Invalid service ID, 8=Flow control error, 9=No route to the destination ISMG, 10+=Other errors.
+	Result uint8
+}
+
+// IEncode encodes the PduFwdResp PDU into a byte slice.
+func (p *PduFwdResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter(0)
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(p.Header, b)
+	b.WriteUint64(p.MsgID)
+	b.WriteUint8(p.PkTotal)
+	b.WriteUint8(p.PkNumber)
+	b.WriteUint8(p.Result)
+
+	return b.BytesWithLength()
+}
+
+// IDecode decodes the byte slice into a PduFwdResp PDU.
+func (p *PduFwdResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = cmpp.ReadHeader(b)
+	p.MsgID = b.ReadUint64()
+	p.PkTotal = b.ReadUint8()
+	p.PkNumber = b.ReadUint8()
+	p.Result = b.ReadUint8()
+
+	return b.Error()
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (p *PduFwdResp) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (p *PduFwdResp) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+// GetCommand returns the command ID of the PDU.
+func (p *PduFwdResp) GetCommand() sms.ICommander {
+	return cmpp.CommandFwdResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for FwdResp).
+func (p *PduFwdResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the PduFwdResp PDU.
+func (p *PduFwdResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("MsgID", p.MsgID)
+	w.Write("PkTotal", p.PkTotal)
+	w.Write("PkNumber", p.PkNumber)
+	w.Write("Result", p.Result)
+
+	return w.String()
+}
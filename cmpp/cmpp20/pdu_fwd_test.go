@@ -0,0 +1,59 @@
+package cmpp20
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+)
+
+func TestPduFwd(t *testing.T) {
+	fwd := &PduFwd{
+		Header:             cmpp.NewHeader(0, cmpp.CommandFwd, 0x17),
+		SourceID:           "010001",
+		DestinationID:      "020001",
+		NodesCount:         1,
+		MsgFwdType:         cmpp.MsgFwdTypeMT,
+		MsgID:              cmpp.CombineMsgID(8, 30, 10, 56, 0, 300, 32768),
+		PkTotal:            1,
+		PkNumber:           1,
+		RegisteredDelivery: 1,
+		ServiceID:          "test",
+		MsgSrc:             "901234",
+		FeeType:            "01",
+		FeeCode:            "000000",
+		SrcTerminalID:      "1065",
+		DestUsrTL:          1,
+		DestTerminalID:     []string{"13800138000"},
+		MsgLength:          2,
+		MsgContent:         []byte("hi"),
+	}
+	data, err := fwd.IEncode()
+	assert.Nil(t, err)
+	assert.Len(t, data, 175)
+	assert.Equal(t, []byte("010001"), data[12:18])
+	assert.Equal(t, []byte("020001"), data[18:24])
+	assert.Equal(t, []byte{1, cmpp.MsgFwdTypeMT}, data[24:26])
+
+	pdu, err := DecodeCMPP20(data)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*PduFwd)
+	if assert.True(t, ok) {
+		fwd.TotalLength = 175
+		assert.Equal(t, fwd, decoded)
+		assert.Contains(t, decoded.String(), "13800138000")
+	}
+
+	resp := fwd.GenEmptyResponse().(*PduFwdResp)
+	assert.Equal(t, fwd.MsgID, resp.MsgID)
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Len(t, data, MaxFwdRespLength)
+
+	pdu, err = DecodeCMPP20(data)
+	assert.Nil(t, err)
+	resp.TotalLength = MaxFwdRespLength
+	assert.Equal(t, resp, pdu)
+	assert.Equal(t, "CMPP_FWD_RESP", pdu.GetCommand().String())
+}
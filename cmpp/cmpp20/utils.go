@@ -9,6 +9,7 @@ import (
 
 	sms "github.com/hujm2023/go-sms-protocol"
 	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/consts"
 )
 
 // NewConnect creates a new PduConnect PDU.
@@ -53,6 +54,11 @@ func now() (string, uint32) {
 	return s, uint32(i)
 }
 
+func init() {
+	sms.RegisterDecoder(consts.ProtocolCMPP, consts.CMPPVersion2_0, DecodeCMPP20)
+	sms.RegisterDecoder(consts.ProtocolCMPP, consts.CMPPVersion2_1, DecodeCMPP20)
+}
+
 // DecodeCMPP20 decodes the given byte slice into a corresponding CMPP 2.0 PDU.
 func DecodeCMPP20(data []byte) (sms.PDU, error) {
 	header, err := cmpp.PeekHeader(data)
@@ -82,6 +88,14 @@ func DecodeCMPP20(data []byte) (sms.PDU, error) {
 		pdu = new(PduTerminate)
 	case cmpp.CommandTerminateResp:
 		pdu = new(PduTerminateResp)
+	case cmpp.CommandCancel:
+		pdu = new(PduCancel)
+	case cmpp.CommandCancelResp:
+		pdu = new(PduCancelResp)
+	case cmpp.CommandFwd:
+		pdu = new(PduFwd)
+	case cmpp.CommandFwdResp:
+		pdu = new(PduFwdResp)
 	}
 
 	if pdu == nil {
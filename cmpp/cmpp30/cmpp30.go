@@ -3,8 +3,13 @@ package cmpp30
 import (
 	sms "github.com/hujm2023/go-sms-protocol"
 	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/consts"
 )
 
+func init() {
+	sms.RegisterDecoder(consts.ProtocolCMPP, consts.CMPPVersion3_0, DecodeCMPP30)
+}
+
 // DecodeCMPP30 decodes the given byte slice into a corresponding CMPP 3.0 PDU.
 func DecodeCMPP30(data []byte) (sms.PDU, error) {
 	header, err := cmpp.PeekHeader(data)
@@ -42,6 +47,42 @@ func DecodeCMPP30(data []byte) (sms.PDU, error) {
 		pdu = new(Cancel)
 	case cmpp.CommandCancelResp:
 		pdu = new(CancelResp)
+	case cmpp.CommandFwd:
+		pdu = new(Fwd)
+	case cmpp.CommandFwdResp:
+		pdu = new(FwdResp)
+	case cmpp.CommandMtRoute:
+		pdu = new(MtRoute)
+	case cmpp.CommandMtRouteResp:
+		pdu = new(MtRouteResp)
+	case cmpp.CommandMoRoute:
+		pdu = new(MoRoute)
+	case cmpp.CommandMoRouteResp:
+		pdu = new(MoRouteResp)
+	case cmpp.CommandGetMtRoute:
+		pdu = new(GetMtRoute)
+	case cmpp.CommandGetMtRouteResp:
+		pdu = new(GetMtRouteResp)
+	case cmpp.CommandMtRouteUpdate:
+		pdu = new(MtRouteUpdate)
+	case cmpp.CommandMtRouteUpdateResp:
+		pdu = new(MtRouteUpdateResp)
+	case cmpp.CommandMoRouteUpdate:
+		pdu = new(MoRouteUpdate)
+	case cmpp.CommandMoRouteUpdateResp:
+		pdu = new(MoRouteUpdateResp)
+	case cmpp.CommandPushMtRouteUpdate:
+		pdu = new(PushMtRouteUpdate)
+	case cmpp.CommandPushMtRouteUpdateResp:
+		pdu = new(PushMtRouteUpdateResp)
+	case cmpp.CommandPushMoRouteUpdate:
+		pdu = new(PushMoRouteUpdate)
+	case cmpp.CommandPushMoRouteUpdateResp:
+		pdu = new(PushMoRouteUpdateResp)
+	case cmpp.CommandGetMoRoute:
+		pdu = new(GetMoRoute)
+	case cmpp.CommandGetMoRouteResp:
+		pdu = new(GetMoRouteResp)
 	}
 
 	if pdu == nil {
@@ -0,0 +1,10 @@
+package cmpp30
+
+import "github.com/hujm2023/go-sms-protocol/cmpp"
+
+const (
+	// MaxCancelLength is the maximum length of a Cancel PDU (20 bytes).
+	MaxCancelLength = cmpp.HeaderLength + 8
+	// MaxCancelRespLength is the maximum length of a CancelResp PDU (16 bytes).
+	MaxCancelRespLength = cmpp.HeaderLength + 4
+)
@@ -15,6 +15,18 @@ type Cancel struct {
 	MsgID uint64
 }
 
+// NewCancel creates a new Cancel PDU for the MsgID in the form returned by cmpp.MsgID2String.
+func NewCancel(msgID string, seqID uint32) (*Cancel, error) {
+	id, err := cmpp.ParseMsgID(msgID)
+	if err != nil {
+		return nil, err
+	}
+	return &Cancel{
+		Header: cmpp.NewHeader(MaxCancelLength, cmpp.CommandCancel, seqID),
+		MsgID:  id,
+	}, nil
+}
+
 // IDecode decodes the byte slice into a Cancel PDU.
 func (c *Cancel) IDecode(data []byte) error {
 	if len(data) < cmpp.MinCMPPPduLength {
@@ -1 +1,35 @@
 package cmpp30
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+)
+
+func TestNewCancel(t *testing.T) {
+	msgID := cmpp.CombineMsgID(8, 30, 10, 56, 0, 300, 32768)
+	c, err := NewCancel(cmpp.MsgID2String(msgID), 7)
+	assert.Nil(t, err)
+	assert.Equal(t, msgID, c.MsgID)
+
+	data, err := c.IEncode()
+	assert.Nil(t, err)
+	assert.Len(t, data, MaxCancelLength)
+
+	pdu, err := DecodeCMPP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, c, pdu)
+
+	resp := c.GenEmptyResponse().(*CancelResp)
+	resp.SuccessID = 1
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 16, 0x80, 0, 0, 7, 0, 0, 0, 7, 0, 0, 0, 1}, data)
+	assert.Len(t, data, MaxCancelRespLength)
+
+	_, err = NewCancel("abc", 1)
+	assert.True(t, errors.Is(err, cmpp.ErrInvalidMsgID))
+}
@@ -108,6 +108,7 @@ type ConnectResp struct {
 	Status uint32
 
 	// AuthenticatorISMG is the ISMG authentication code (16 bytes), used to authenticate the ISMG. Calculated as md5(Status + req.AuthenticatorSource + password).
+	// It is a digest, so all 16 bytes are kept on decode.
 	AuthenticatorISMG string
 
 	// Version is the highest protocol version supported by the server (1 byte).
@@ -124,7 +125,7 @@ func (c *ConnectResp) IDecode(data []byte) error {
 
 	c.Header = cmpp.ReadHeader(buf)
 	c.Status = buf.ReadUint32()
-	c.AuthenticatorISMG = buf.ReadCStringN(16)
+	c.AuthenticatorISMG = buf.ReadCStringNWithoutTrim(16)
 	c.Version = buf.ReadUint8()
 	return buf.Error()
 }
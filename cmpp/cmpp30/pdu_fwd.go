@@ -0,0 +1,378 @@
+package cmpp30
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/packet"
+)
+
+// Fwd represents a CMPP 3.0 Fwd PDU.
+// It is used between ISMGs to forward MT/MO messages and their status reports.
+type Fwd struct {
+	cmpp.Header
+
+	// SourceID is the code of the source ISMG (6 bytes).
+	SourceID string
+
+	// DestinationID is the code of the destination ISMG (6 bytes).
+	DestinationID string
+
+	// NodesCount is the number of ISMGs the message has passed through (1 byte), increased by each forwarding ISMG.
+	NodesCount uint8
+
+	// MsgFwdType is the type of the forwarded message (1 byte):
+	// 0=MT, 1=MO, 2=MT status report, 3=MO status report. See cmpp.MsgFwdTypeMT etc.
+	MsgFwdType uint8
+
+	// MsgID is the message identifier (8 bytes).
+	MsgID uint64
+
+	// PkTotal is the total number of packets for the same MsgID (1 byte, starts from 1).
+	PkTotal uint8
+
+	// PkNumber is the sequence number for the same MsgID (1 byte, starts from 1).
+	PkNumber uint8
+
+	// RegisteredDelivery indicates if a status report is required (1 byte): 0=No, 1=Yes.
+	// For status reports (MsgFwdType 2 and 3) it is always 1.
+	RegisteredDelivery uint8
+
+	// MsgLevel is the message priority level (1 byte).
+	MsgLevel uint8
+
+	// ServiceID is the service type (10 bytes), a combination of digits, letters, and symbols.
+	ServiceID string
+
+	// FeeUserType indicates the billing user type (1 byte):
+	// 0=Destination terminal, 1=Source terminal, 2=SP, 3=Field invalid (refer to FeeTerminalID).
+	FeeUserType uint8
+
+	// FeeTerminalID is the billed user's number (32 bytes).
+	FeeTerminalID string
+
+	// FeeTerminalPseudo is the pseudo code of the billed user (32 bytes).
+	FeeTerminalPseudo string
+
+	// FeeTerminalUserType is the billed user's type (1 byte): 0=Global System for Mobile, 1=CDMA.
+	FeeTerminalUserType uint8
+
+	// TpPID is the GSM protocol type (1 byte). See GSM 03.40 section 9.2.3.9.
+	TpPID uint8
+
+	// TpUDHI is the GSM protocol type (1 byte).
+	// See GSM 03.40 section 9.2.3.23 (only 1 bit used, right-aligned).
+	TpUDHI uint8
+
+	// MsgFmt is the message format (1 byte):
+	// 0=ASCII, 3=SMS Write Card, 4=Binary, 8=UCS2, 15=GB Hanzi.
+	MsgFmt uint8
+
+	// MsgSrc is the message source (SP ID) (6 bytes).
+	MsgSrc string
+
+	// FeeType is the fee category (2 bytes).
+	FeeType string
+
+	// FeeCode is the fee code (6 bytes, in cents).
+	FeeCode string
+
+	// ValiDTime is the validity period (17 bytes, SMPP 3.3 format).
+	ValiDTime string
+
+	// AtTime is the scheduled delivery time (17 bytes, SMPP 3.3 format).
+	AtTime string
+
+	// SrcID is the source number (21 bytes): the SP's service code for MT, the MSISDN for MO.
+	SrcID string
+
+	// SrcPseudo is the pseudo code of the source user (32 bytes).
+	SrcPseudo string
+
+	// SrcUserType is the source user's type (1 byte): 0=Global System for Mobile, 1=CDMA.
+	SrcUserType uint8
+
+	// SrcType is the type of SrcID (1 byte): 0=SMC code, 1=SP code.
+	SrcType uint8
+
+	// DestUsrTL is the number of recipient users (1 byte, < 100).
+	DestUsrTL uint8
+
+	// DestID is the list of recipient numbers (32 * DestUsrTL bytes).
+	DestID []string
+
+	// DestPseudo is the list of pseudo codes of the recipients (32 * DestUsrTL bytes).
+	DestPseudo []string
+
+	// DestUserType is the recipients' type (1 byte): 0=Global System for Mobile, 1=CDMA.
+	DestUserType uint8
+
+	// MsgLength is the message length (1 byte).
+	MsgLength uint8
+
+	// MsgContent is the message content (MsgLength bytes).
+	// For status reports it has the same layout as the status report of a Deliver PDU.
+	MsgContent []byte
+
+	// LinkID is used for on-demand services (20 bytes).
+	LinkID string
+}
+
+// IDecode decodes the byte slice into a Fwd PDU.
+func (f *Fwd) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	f.Header = cmpp.ReadHeader(b)
+	f.SourceID = b.ReadCStringN(6)
+	f.DestinationID = b.ReadCStringN(6)
+	f.NodesCount = b.ReadUint8()
+	f.MsgFwdType = b.ReadUint8()
+	f.MsgID = b.ReadUint64()
+	f.PkTotal = b.ReadUint8()
+	f.PkNumber = b.ReadUint8()
+	f.RegisteredDelivery = b.ReadUint8()
+	f.MsgLevel = b.ReadUint8()
+	f.ServiceID = b.ReadCStringN(10)
+	f.FeeUserType = b.ReadUint8()
+	f.FeeTerminalID = b.ReadCStringN(32)
+	f.FeeTerminalPseudo = b.ReadCStringN(32)
+	f.FeeTerminalUserType = b.ReadUint8()
+	f.TpPID = b.ReadUint8()
+	f.TpUDHI = b.ReadUint8()
+	f.MsgFmt = b.ReadUint8()
+	f.MsgSrc = b.ReadCStringN(6)
+	f.FeeType = b.ReadCStringN(2)
+	f.FeeCode = b.ReadCStringN(6)
+	f.ValiDTime = b.ReadCStringN(17)
+	f.AtTime = b.ReadCStringN(17)
+	f.SrcID = b.ReadCStringN(21)
+	f.SrcPseudo = b.ReadCStringN(32)
+	f.SrcUserType = b.ReadUint8()
+	f.SrcType = b.ReadUint8()
+	f.DestUsrTL = b.ReadUint8()
+	f.DestID = make([]string, f.DestUsrTL)
+	for i := 0; i < int(f.DestUsrTL); i++ {
+		f.DestID[i] = b.ReadCStringN(32)
+	}
+	f.DestPseudo = make([]string, f.DestUsrTL)
+	for i := 0; i < int(f.DestUsrTL); i++ {
+		f.DestPseudo[i] = b.ReadCStringN(32)
+	}
+	f.DestUserType = b.ReadUint8()
+	f.MsgLength = b.ReadUint8()
+	f.MsgContent = b.ReadNBytes(int(f.MsgLength))
+	f.LinkID = b.ReadCStringN(20)
+
+	return b.Error()
+}
+
+// IEncode encodes the Fwd PDU into a byte slice.
+// DestPseudo is padded with empty codes up to DestUsrTL.
+func (f *Fwd) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(f.Header, b)
+	b.WriteFixedLenString(f.SourceID, 6)
+	b.WriteFixedLenString(f.DestinationID, 6)
+	b.WriteUint8(f.NodesCount)
+	b.WriteUint8(f.MsgFwdType)
+	b.WriteUint64(f.MsgID)
+	b.WriteUint8(f.PkTotal)
+	b.WriteUint8(f.PkNumber)
+	b.WriteUint8(f.RegisteredDelivery)
+	b.WriteUint8(f.MsgLevel)
+	b.WriteFixedLenString(f.ServiceID, 10)
+	b.WriteUint8(f.FeeUserType)
+	b.WriteFixedLenString(f.FeeTerminalID, 32)
+	b.WriteFixedLenString(f.FeeTerminalPseudo, 32)
+	b.WriteUint8(f.FeeTerminalUserType)
+	b.WriteUint8(f.TpPID)
+	b.WriteUint8(f.TpUDHI)
+	b.WriteUint8(f.MsgFmt)
+	b.WriteFixedLenString(f.MsgSrc, 6)
+	b.WriteFixedLenString(f.FeeType, 2)
+	b.WriteFixedLenString(f.FeeCode, 6)
+	b.WriteFixedLenString(f.ValiDTime, 17)
+	b.WriteFixedLenString(f.AtTime, 17)
+	b.WriteFixedLenString(f.SrcID, 21)
+	b.WriteFixedLenString(f.SrcPseudo, 32)
+	b.WriteUint8(f.SrcUserType)
+	b.WriteUint8(f.SrcType)
+	b.WriteUint8(f.DestUsrTL)
+	for _, id := range f.DestID {
+		b.WriteFixedLenString(id, 32)
+	}
+	for i := 0; i < int(f.DestUsrTL); i++ {
+		var pseudo string
+		if i < len(f.DestPseudo) {
+			pseudo = f.DestPseudo[i]
+		}
+		b.WriteFixedLenString(pseudo, 32)
+	}
+	b.WriteUint8(f.DestUserType)
+	b.WriteUint8(f.MsgLength)
+	b.WriteBytes(f.MsgContent)
+	b.WriteFixedLenString(f.LinkID, 20)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (f *Fwd) SetSequenceID(id uint32) {
+	f.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (f *Fwd) GetSequenceID() uint32 {
+	return f.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (f *Fwd) GetCommand() sms.ICommander {
+	return cmpp.CommandFwd
+}
+
+// GenEmptyResponse generates an empty response PDU for the Fwd, with MsgID, PkTotal and PkNumber copied.
+func (f *Fwd) GenEmptyResponse() sms.PDU {
+	return &FwdResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandFwdResp,
+			SequenceID: f.GetSequenceID(),
+		},
+		MsgID:    f.MsgID,
+		PkTotal:  f.PkTotal,
+		PkNumber: f.PkNumber,
+	}
+}
+
+// String returns a string representation of the Fwd PDU.
+func (f *Fwd) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", f.Header)
+	w.Write("SourceID", f.SourceID)
+	w.Write("DestinationID", f.DestinationID)
+	w.Write("NodesCount", f.NodesCount)
+	w.Write("MsgFwdType", f.MsgFwdType)
+	w.Write("MsgID", f.MsgID)
+	w.Write("PkTotal", f.PkTotal)
+	w.Write("PkNumber", f.PkNumber)
+	w.Write("RegisteredDelivery", f.RegisteredDelivery)
+	w.Write("MsgLevel", f.MsgLevel)
+	w.Write("ServiceID", f.ServiceID)
+	w.Write("FeeUserType", f.FeeUserType)
+	w.Write("FeeTerminalID", f.FeeTerminalID)
+	w.Write("FeeTerminalPseudo", f.FeeTerminalPseudo)
+	w.Write("FeeTerminalUserType", f.FeeTerminalUserType)
+	w.Write("TpPID", f.TpPID)
+	w.Write("TpUDHI", f.TpUDHI)
+	w.Write("MsgFmt", f.MsgFmt)
+	w.Write("MsgSrc", f.MsgSrc)
+	w.Write("FeeType", f.FeeType)
+	w.Write("FeeCode", f.FeeCode)
+	w.Write("ValiDTime", f.ValiDTime)
+	w.Write("AtTime", f.AtTime)
+	w.Write("SrcID", f.SrcID)
+	w.Write("SrcPseudo", f.SrcPseudo)
+	w.Write("SrcUserType", f.SrcUserType)
+	w.Write("SrcType", f.SrcType)
+	w.Write("DestUsrTL", f.DestUsrTL)
+	w.Write("DestID", f.DestID)
+	w.Write("DestPseudo", f.DestPseudo)
+	w.Write("DestUserType", f.DestUserType)
+	w.Write("MsgLength", f.MsgLength)
+	w.WriteWithBytes("MsgContent", f.MsgContent)
+	w.Write("LinkID", f.LinkID)
+
+	return w.String()
+}
+
+// FwdResp represents a CMPP 3.0 FwdResp PDU.
+// It is the response to a Fwd PDU.
+type FwdResp struct {
+	cmpp.Header
+
+	// MsgID is the message identifier of the Fwd (8 bytes).
+	MsgID uint64
+
+	// PkTotal is the PkTotal of the Fwd (1 byte).
+	PkTotal uint8
+
+	// PkNumber is the PkNumber of the Fwd (1 byte).
+	PkNumber uint8
+
+	// Result is the result of the forwarding (4 bytes): 0=Success, 1=Invalid message structure, 2=Invalid command ID,
+	// 3=Duplicate sequence ID, 4=Invalid message length, 5=Invalid fee code, 6=Message length exceeds maximum,
+	// 7=Invalid service ID, 8=Flow control error, 9=No route to the destination ISMG, 10+=Other errors.
+	Result uint32
+}
+
+// IDecode decodes the byte slice into a FwdResp PDU.
+func (f *FwdResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	f.Header = cmpp.ReadHeader(b)
+	f.MsgID = b.ReadUint64()
+	f.PkTotal = b.ReadUint8()
+	f.PkNumber = b.ReadUint8()
+	f.Result = b.ReadUint32()
+
+	return b.Error()
+}
+
+// IEncode encodes the FwdResp PDU into a byte slice.
+func (f *FwdResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(f.Header, b)
+	b.WriteUint64(f.MsgID)
+	b.WriteUint8(f.PkTotal)
+	b.WriteUint8(f.PkNumber)
+	b.WriteUint32(f.Result)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (f *FwdResp) SetSequenceID(id uint32) {
+	f.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (f *FwdResp) GetSequenceID() uint32 {
+	return f.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (f *FwdResp) GetCommand() sms.ICommander {
+	return cmpp.CommandFwdResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for FwdResp).
+func (f *FwdResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the FwdResp PDU.
+func (f *FwdResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", f.Header)
+	w.Write("MsgID", f.MsgID)
+	w.Write("PkTotal", f.PkTotal)
+	w.Write("PkNumber", f.PkNumber)
+	w.Write("Result", f.Result)
+
+	return w.String()
+}
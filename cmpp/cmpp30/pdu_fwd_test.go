@@ -0,0 +1,67 @@
+package cmpp30
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+)
+
+func TestFwd(t *testing.T) {
+	fwd := &Fwd{
+		Header:            cmpp.NewHeader(0, cmpp.CommandFwd, 0x17),
+		SourceID:          "010001",
+		DestinationID:     "020001",
+		NodesCount:        2,
+		MsgFwdType:        cmpp.MsgFwdTypeMO,
+		MsgID:             cmpp.CombineMsgID(8, 30, 10, 56, 0, 300, 32768),
+		PkTotal:           1,
+		PkNumber:          1,
+		ServiceID:         "test",
+		FeeTerminalPseudo: "pseudo",
+		MsgSrc:            "901234",
+		SrcID:             "13800138000",
+		SrcPseudo:         "abc",
+		DestUsrTL:         1,
+		DestID:            []string{"1065"},
+		DestPseudo:        []string{"def"},
+		MsgLength:         2,
+		MsgContent:        []byte("hi"),
+		LinkID:            "link",
+	}
+	data, err := fwd.IEncode()
+	assert.Nil(t, err)
+	assert.Len(t, data, 309)
+
+	pdu, err := DecodeCMPP30(data)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*Fwd)
+	if assert.True(t, ok) {
+		fwd.TotalLength = 309
+		assert.Equal(t, fwd, decoded)
+		assert.Contains(t, decoded.String(), "DestPseudo")
+	}
+
+	// missing pseudo codes are encoded as empty ones
+	fwd.DestPseudo = nil
+	data2, err := fwd.IEncode()
+	assert.Nil(t, err)
+	assert.Len(t, data2, 309)
+
+	resp := fwd.GenEmptyResponse().(*FwdResp)
+	resp.Result = 9
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		0, 0, 0, 26, 0x80, 0, 0, 0x09, 0, 0, 0, 0x17,
+		0x8f, 0x2b, 0x80, 0x00, 0x01, 0x2c, 0x80, 0x00,
+		1, 1,
+		0, 0, 0, 9,
+	}, data)
+
+	pdu, err = DecodeCMPP30(data)
+	assert.Nil(t, err)
+	resp.TotalLength = 26
+	assert.Equal(t, resp, pdu)
+}
@@ -0,0 +1,755 @@
+package cmpp30
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/packet"
+)
+
+// The route management PDUs are exchanged between an ISMG and the GNS (gateway name server),
+// which keeps the routing table of MSISDN ranges (MT routes) and SP codes (MO routes) to ISMGs.
+
+const (
+	// RouteUpdateTypeAdd adds a route.
+	RouteUpdateTypeAdd = 0
+	// RouteUpdateTypeDelete deletes a route.
+	RouteUpdateTypeDelete = 1
+	// RouteUpdateTypeModify modifies a route.
+	RouteUpdateTypeModify = 2
+)
+
+// MtRouteInfo is an MT route, which routes a range of MSISDNs to an ISMG.
+type MtRouteInfo struct {
+	// RouteID is the ID of the route (4 bytes).
+	RouteID uint32
+
+	// DestinationID is the code of the destination ISMG (6 bytes).
+	DestinationID string
+
+	// GatewayIP is the IP address of the destination ISMG (15 bytes).
+	GatewayIP string
+
+	// GatewayPort is the port of the destination ISMG (2 bytes).
+	GatewayPort uint16
+
+	// StartID is the first MSISDN prefix of the range (9 bytes).
+	StartID string
+
+	// EndID is the last MSISDN prefix of the range (9 bytes).
+	EndID string
+
+	// AreaCode is the code of the province of the range (4 bytes).
+	AreaCode string
+
+	// UserType is the type of the users (1 byte): 0=GoTone, 1=Easyown, 2=M-Zone.
+	UserType uint8
+}
+
+func (m *MtRouteInfo) read(b *packet.Reader) {
+	m.RouteID = b.ReadUint32()
+	m.DestinationID = b.ReadCStringN(6)
+	m.GatewayIP = b.ReadCStringN(15)
+	m.GatewayPort = b.ReadUint16()
+	m.StartID = b.ReadCStringN(9)
+	m.EndID = b.ReadCStringN(9)
+	m.AreaCode = b.ReadCStringN(4)
+	m.UserType = b.ReadUint8()
+}
+
+func (m *MtRouteInfo) write(b *packet.Writer) {
+	b.WriteUint32(m.RouteID)
+	b.WriteFixedLenString(m.DestinationID, 6)
+	b.WriteFixedLenString(m.GatewayIP, 15)
+	b.WriteUint16(m.GatewayPort)
+	b.WriteFixedLenString(m.StartID, 9)
+	b.WriteFixedLenString(m.EndID, 9)
+	b.WriteFixedLenString(m.AreaCode, 4)
+	b.WriteUint8(m.UserType)
+}
+
+func (m *MtRouteInfo) writeString(w *packet.PDUStringer) {
+	w.Write("RouteID", m.RouteID)
+	w.Write("DestinationID", m.DestinationID)
+	w.Write("GatewayIP", m.GatewayIP)
+	w.Write("GatewayPort", m.GatewayPort)
+	w.Write("StartID", m.StartID)
+	w.Write("EndID", m.EndID)
+	w.Write("AreaCode", m.AreaCode)
+	w.Write("UserType", m.UserType)
+}
+
+// MoRouteInfo is an MO route, which routes a range of SP codes to an ISMG.
+type MoRouteInfo struct {
+	// RouteID is the ID of the route (4 bytes).
+	RouteID uint32
+
+	// DestinationID is the code of the destination ISMG (6 bytes).
+	DestinationID string
+
+	// GatewayIP is the IP address of the destination ISMG (15 bytes).
+	GatewayIP string
+
+	// GatewayPort is the port of the destination ISMG (2 bytes).
+	GatewayPort uint16
+
+	// SPID is the enterprise code of the SP (6 bytes).
+	SPID string
+
+	// SPCode is the service code of the SP (21 bytes).
+	SPCode string
+
+	// SPAccessType is how the SP accesses the ISMG (1 byte): 0=Local, 1=Remote.
+	SPAccessType uint8
+
+	// StartCode is the first service code of the range (9 bytes).
+	StartCode string
+
+	// EndCode is the last service code of the range (9 bytes).
+	EndCode string
+}
+
+func (m *MoRouteInfo) read(b *packet.Reader) {
+	m.RouteID = b.ReadUint32()
+	m.DestinationID = b.ReadCStringN(6)
+	m.GatewayIP = b.ReadCStringN(15)
+	m.GatewayPort = b.ReadUint16()
+	m.SPID = b.ReadCStringN(6)
+	m.SPCode = b.ReadCStringN(21)
+	m.SPAccessType = b.ReadUint8()
+	m.StartCode = b.ReadCStringN(9)
+	m.EndCode = b.ReadCStringN(9)
+}
+
+func (m *MoRouteInfo) write(b *packet.Writer) {
+	b.WriteUint32(m.RouteID)
+	b.WriteFixedLenString(m.DestinationID, 6)
+	b.WriteFixedLenString(m.GatewayIP, 15)
+	b.WriteUint16(m.GatewayPort)
+	b.WriteFixedLenString(m.SPID, 6)
+	b.WriteFixedLenString(m.SPCode, 21)
+	b.WriteUint8(m.SPAccessType)
+	b.WriteFixedLenString(m.StartCode, 9)
+	b.WriteFixedLenString(m.EndCode, 9)
+}
+
+func (m *MoRouteInfo) writeString(w *packet.PDUStringer) {
+	w.Write("RouteID", m.RouteID)
+	w.Write("DestinationID", m.DestinationID)
+	w.Write("GatewayIP", m.GatewayIP)
+	w.Write("GatewayPort", m.GatewayPort)
+	w.Write("SPID", m.SPID)
+	w.Write("SPCode", m.SPCode)
+	w.Write("SPAccessType", m.SPAccessType)
+	w.Write("StartCode", m.StartCode)
+	w.Write("EndCode", m.EndCode)
+}
+
+// MtRoute represents a CMPP 3.0 MtRoute PDU.
+// It is used by an ISMG to ask the GNS for the MT route of an MSISDN.
+type MtRoute struct {
+	cmpp.Header
+
+	// SourceID is the code of the requesting ISMG (6 bytes).
+	SourceID string
+
+	// TerminalID is the MSISDN (21 bytes).
+	TerminalID string
+}
+
+// IDecode decodes the byte slice into a MtRoute PDU.
+func (m *MtRoute) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	m.Header = cmpp.ReadHeader(b)
+	m.SourceID = b.ReadCStringN(6)
+	m.TerminalID = b.ReadCStringN(21)
+
+	return b.Error()
+}
+
+// IEncode encodes the MtRoute PDU into a byte slice.
+func (m *MtRoute) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(m.Header, b)
+	b.WriteFixedLenString(m.SourceID, 6)
+	b.WriteFixedLenString(m.TerminalID, 21)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (m *MtRoute) SetSequenceID(id uint32) {
+	m.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (m *MtRoute) GetSequenceID() uint32 {
+	return m.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (m *MtRoute) GetCommand() sms.ICommander {
+	return cmpp.CommandMtRoute
+}
+
+// GenEmptyResponse generates an empty response PDU for the MtRoute.
+func (m *MtRoute) GenEmptyResponse() sms.PDU {
+	return &MtRouteResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandMtRouteResp,
+			SequenceID: m.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the MtRoute PDU.
+func (m *MtRoute) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", m.Header)
+	w.Write("SourceID", m.SourceID)
+	w.Write("TerminalID", m.TerminalID)
+
+	return w.String()
+}
+
+// MtRouteResp represents a CMPP 3.0 MtRouteResp PDU.
+// It is the response to a MtRoute PDU.
+type MtRouteResp struct {
+	cmpp.Header
+
+	MtRouteInfo
+
+	// Result is the result of the request (1 byte): 0=Success, 1=No matching route.
+	Result uint8
+
+	// TimeStamp is the last modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a MtRouteResp PDU.
+func (m *MtRouteResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	m.Header = cmpp.ReadHeader(b)
+	m.MtRouteInfo.read(b)
+	m.Result = b.ReadUint8()
+	m.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the MtRouteResp PDU into a byte slice.
+func (m *MtRouteResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(m.Header, b)
+	m.MtRouteInfo.write(b)
+	b.WriteUint8(m.Result)
+	b.WriteFixedLenString(m.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (m *MtRouteResp) SetSequenceID(id uint32) {
+	m.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (m *MtRouteResp) GetSequenceID() uint32 {
+	return m.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (m *MtRouteResp) GetCommand() sms.ICommander {
+	return cmpp.CommandMtRouteResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for MtRouteResp).
+func (m *MtRouteResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the MtRouteResp PDU.
+func (m *MtRouteResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", m.Header)
+	m.MtRouteInfo.writeString(w)
+	w.Write("Result", m.Result)
+	w.Write("TimeStamp", m.TimeStamp)
+
+	return w.String()
+}
+
+// MoRoute represents a CMPP 3.0 MoRoute PDU.
+// It is used by an ISMG to ask the GNS for the MO route of an SP code.
+type MoRoute struct {
+	cmpp.Header
+
+	// SourceID is the code of the requesting ISMG (6 bytes).
+	SourceID string
+
+	// SPCode is the service code of the SP (21 bytes).
+	SPCode string
+}
+
+// IDecode decodes the byte slice into a MoRoute PDU.
+func (m *MoRoute) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	m.Header = cmpp.ReadHeader(b)
+	m.SourceID = b.ReadCStringN(6)
+	m.SPCode = b.ReadCStringN(21)
+
+	return b.Error()
+}
+
+// IEncode encodes the MoRoute PDU into a byte slice.
+func (m *MoRoute) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(m.Header, b)
+	b.WriteFixedLenString(m.SourceID, 6)
+	b.WriteFixedLenString(m.SPCode, 21)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (m *MoRoute) SetSequenceID(id uint32) {
+	m.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (m *MoRoute) GetSequenceID() uint32 {
+	return m.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (m *MoRoute) GetCommand() sms.ICommander {
+	return cmpp.CommandMoRoute
+}
+
+// GenEmptyResponse generates an empty response PDU for the MoRoute.
+func (m *MoRoute) GenEmptyResponse() sms.PDU {
+	return &MoRouteResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandMoRouteResp,
+			SequenceID: m.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the MoRoute PDU.
+func (m *MoRoute) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", m.Header)
+	w.Write("SourceID", m.SourceID)
+	w.Write("SPCode", m.SPCode)
+
+	return w.String()
+}
+
+// MoRouteResp represents a CMPP 3.0 MoRouteResp PDU.
+// It is the response to a MoRoute PDU.
+type MoRouteResp struct {
+	cmpp.Header
+
+	MoRouteInfo
+
+	// Result is the result of the request (1 byte): 0=Success, 1=No matching route.
+	Result uint8
+
+	// TimeStamp is the last modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a MoRouteResp PDU.
+func (m *MoRouteResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	m.Header = cmpp.ReadHeader(b)
+	m.MoRouteInfo.read(b)
+	m.Result = b.ReadUint8()
+	m.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the MoRouteResp PDU into a byte slice.
+func (m *MoRouteResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(m.Header, b)
+	m.MoRouteInfo.write(b)
+	b.WriteUint8(m.Result)
+	b.WriteFixedLenString(m.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (m *MoRouteResp) SetSequenceID(id uint32) {
+	m.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (m *MoRouteResp) GetSequenceID() uint32 {
+	return m.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (m *MoRouteResp) GetCommand() sms.ICommander {
+	return cmpp.CommandMoRouteResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for MoRouteResp).
+func (m *MoRouteResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the MoRouteResp PDU.
+func (m *MoRouteResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", m.Header)
+	m.MoRouteInfo.writeString(w)
+	w.Write("Result", m.Result)
+	w.Write("TimeStamp", m.TimeStamp)
+
+	return w.String()
+}
+
+// GetMtRoute represents a CMPP 3.0 GetMtRoute PDU.
+// It is used by an ISMG to download the MT routing table from the GNS, one route per request:
+// LastRouteID is 0 in the first request and the RouteID of the previous response afterwards.
+type GetMtRoute struct {
+	cmpp.Header
+
+	// SourceID is the code of the requesting ISMG (6 bytes).
+	SourceID string
+
+	// LastRouteID is the ID of the last route received (4 bytes), 0 for the first request.
+	LastRouteID uint32
+}
+
+// IDecode decodes the byte slice into a GetMtRoute PDU.
+func (g *GetMtRoute) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	g.Header = cmpp.ReadHeader(b)
+	g.SourceID = b.ReadCStringN(6)
+	g.LastRouteID = b.ReadUint32()
+
+	return b.Error()
+}
+
+// IEncode encodes the GetMtRoute PDU into a byte slice.
+func (g *GetMtRoute) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(g.Header, b)
+	b.WriteFixedLenString(g.SourceID, 6)
+	b.WriteUint32(g.LastRouteID)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (g *GetMtRoute) SetSequenceID(id uint32) {
+	g.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (g *GetMtRoute) GetSequenceID() uint32 {
+	return g.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (g *GetMtRoute) GetCommand() sms.ICommander {
+	return cmpp.CommandGetMtRoute
+}
+
+// GenEmptyResponse generates an empty response PDU for the GetMtRoute.
+func (g *GetMtRoute) GenEmptyResponse() sms.PDU {
+	return &GetMtRouteResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandGetMtRouteResp,
+			SequenceID: g.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the GetMtRoute PDU.
+func (g *GetMtRoute) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", g.Header)
+	w.Write("SourceID", g.SourceID)
+	w.Write("LastRouteID", g.LastRouteID)
+
+	return w.String()
+}
+
+// GetMtRouteResp represents a CMPP 3.0 GetMtRouteResp PDU.
+// It is the response to a GetMtRoute PDU and carries the MT route after LastRouteID.
+type GetMtRouteResp struct {
+	cmpp.Header
+
+	// Result is the result of the request (1 byte): 0=A route follows, 1=No more routes.
+	Result uint8
+
+	MtRouteInfo
+
+	// TimeStamp is the last modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a GetMtRouteResp PDU.
+func (g *GetMtRouteResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	g.Header = cmpp.ReadHeader(b)
+	g.Result = b.ReadUint8()
+	g.MtRouteInfo.read(b)
+	g.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the GetMtRouteResp PDU into a byte slice.
+func (g *GetMtRouteResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(g.Header, b)
+	b.WriteUint8(g.Result)
+	g.MtRouteInfo.write(b)
+	b.WriteFixedLenString(g.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (g *GetMtRouteResp) SetSequenceID(id uint32) {
+	g.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (g *GetMtRouteResp) GetSequenceID() uint32 {
+	return g.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (g *GetMtRouteResp) GetCommand() sms.ICommander {
+	return cmpp.CommandGetMtRouteResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for GetMtRouteResp).
+func (g *GetMtRouteResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the GetMtRouteResp PDU.
+func (g *GetMtRouteResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", g.Header)
+	w.Write("Result", g.Result)
+	g.MtRouteInfo.writeString(w)
+	w.Write("TimeStamp", g.TimeStamp)
+
+	return w.String()
+}
+
+// GetMoRoute represents a CMPP 3.0 GetMoRoute PDU.
+// It is used by an ISMG to download the MO routing table from the GNS, one route per request:
+// LastRouteID is 0 in the first request and the RouteID of the previous response afterwards.
+type GetMoRoute struct {
+	cmpp.Header
+
+	// SourceID is the code of the requesting ISMG (6 bytes).
+	SourceID string
+
+	// LastRouteID is the ID of the last route received (4 bytes), 0 for the first request.
+	LastRouteID uint32
+}
+
+// IDecode decodes the byte slice into a GetMoRoute PDU.
+func (g *GetMoRoute) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	g.Header = cmpp.ReadHeader(b)
+	g.SourceID = b.ReadCStringN(6)
+	g.LastRouteID = b.ReadUint32()
+
+	return b.Error()
+}
+
+// IEncode encodes the GetMoRoute PDU into a byte slice.
+func (g *GetMoRoute) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(g.Header, b)
+	b.WriteFixedLenString(g.SourceID, 6)
+	b.WriteUint32(g.LastRouteID)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (g *GetMoRoute) SetSequenceID(id uint32) {
+	g.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (g *GetMoRoute) GetSequenceID() uint32 {
+	return g.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (g *GetMoRoute) GetCommand() sms.ICommander {
+	return cmpp.CommandGetMoRoute
+}
+
+// GenEmptyResponse generates an empty response PDU for the GetMoRoute.
+func (g *GetMoRoute) GenEmptyResponse() sms.PDU {
+	return &GetMoRouteResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandGetMoRouteResp,
+			SequenceID: g.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the GetMoRoute PDU.
+func (g *GetMoRoute) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", g.Header)
+	w.Write("SourceID", g.SourceID)
+	w.Write("LastRouteID", g.LastRouteID)
+
+	return w.String()
+}
+
+// GetMoRouteResp represents a CMPP 3.0 GetMoRouteResp PDU.
+// It is the response to a GetMoRoute PDU and carries the MO route after LastRouteID.
+type GetMoRouteResp struct {
+	cmpp.Header
+
+	// Result is the result of the request (1 byte): 0=A route follows, 1=No more routes.
+	Result uint8
+
+	MoRouteInfo
+
+	// TimeStamp is the last modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a GetMoRouteResp PDU.
+func (g *GetMoRouteResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	g.Header = cmpp.ReadHeader(b)
+	g.Result = b.ReadUint8()
+	g.MoRouteInfo.read(b)
+	g.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the GetMoRouteResp PDU into a byte slice.
+func (g *GetMoRouteResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(g.Header, b)
+	b.WriteUint8(g.Result)
+	g.MoRouteInfo.write(b)
+	b.WriteFixedLenString(g.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (g *GetMoRouteResp) SetSequenceID(id uint32) {
+	g.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (g *GetMoRouteResp) GetSequenceID() uint32 {
+	return g.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (g *GetMoRouteResp) GetCommand() sms.ICommander {
+	return cmpp.CommandGetMoRouteResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for GetMoRouteResp).
+func (g *GetMoRouteResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the GetMoRouteResp PDU.
+func (g *GetMoRouteResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", g.Header)
+	w.Write("Result", g.Result)
+	g.MoRouteInfo.writeString(w)
+	w.Write("TimeStamp", g.TimeStamp)
+
+	return w.String()
+}
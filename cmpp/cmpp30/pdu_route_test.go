@@ -0,0 +1,83 @@
+package cmpp30
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+)
+
+func TestRoutePDUs(t *testing.T) {
+	mt := MtRouteInfo{
+		RouteID:       12,
+		DestinationID: "020001",
+		GatewayIP:     "10.0.0.1",
+		GatewayPort:   7890,
+		StartID:       "1380013",
+		EndID:         "1380014",
+		AreaCode:      "010",
+		UserType:      1,
+	}
+	mo := MoRouteInfo{
+		RouteID:       13,
+		DestinationID: "020001",
+		GatewayIP:     "10.0.0.1",
+		GatewayPort:   7890,
+		SPID:          "901234",
+		SPCode:        "1065",
+		SPAccessType:  1,
+		StartCode:     "1065000",
+		EndCode:       "1065999",
+	}
+	const ts = "20240102030405"
+
+	tests := []struct {
+		pdu    sms.PDU
+		id     uint32
+		length int
+	}{
+		{&MtRoute{Header: cmpp.NewHeader(0, cmpp.CommandMtRoute, 0), SourceID: "010001", TerminalID: "13800138000"}, 0x00000010, 12 + 6 + 21},
+		{&MtRouteResp{Header: cmpp.NewHeader(0, cmpp.CommandMtRouteResp, 0), MtRouteInfo: mt, TimeStamp: ts}, 0x80000010, 12 + 50 + 1 + 14},
+		{&MoRoute{Header: cmpp.NewHeader(0, cmpp.CommandMoRoute, 0), SourceID: "010001", SPCode: "1065"}, 0x00000011, 12 + 6 + 21},
+		{&MoRouteResp{Header: cmpp.NewHeader(0, cmpp.CommandMoRouteResp, 0), MoRouteInfo: mo, Result: 1, TimeStamp: ts}, 0x80000011, 12 + 73 + 1 + 14},
+		{&GetMtRoute{Header: cmpp.NewHeader(0, cmpp.CommandGetMtRoute, 0), SourceID: "010001", LastRouteID: 12}, 0x00000012, 12 + 6 + 4},
+		{&GetMtRouteResp{Header: cmpp.NewHeader(0, cmpp.CommandGetMtRouteResp, 0), MtRouteInfo: mt, TimeStamp: ts}, 0x80000012, 12 + 1 + 50 + 14},
+		{&GetMoRoute{Header: cmpp.NewHeader(0, cmpp.CommandGetMoRoute, 0), SourceID: "010001", LastRouteID: 13}, 0x00000017, 12 + 6 + 4},
+		{&GetMoRouteResp{Header: cmpp.NewHeader(0, cmpp.CommandGetMoRouteResp, 0), Result: 1, MoRouteInfo: mo, TimeStamp: ts}, 0x80000017, 12 + 1 + 73 + 14},
+		{&MtRouteUpdate{Header: cmpp.NewHeader(0, cmpp.CommandMtRouteUpdate, 0), UpdateType: RouteUpdateTypeAdd, MtRouteInfo: mt}, 0x00000013, 12 + 1 + 50},
+		{&MtRouteUpdateResp{Header: cmpp.NewHeader(0, cmpp.CommandMtRouteUpdateResp, 0), RouteID: 12, TimeStamp: ts}, 0x80000013, 12 + 1 + 4 + 14},
+		{&MoRouteUpdate{Header: cmpp.NewHeader(0, cmpp.CommandMoRouteUpdate, 0), UpdateType: RouteUpdateTypeDelete, MoRouteInfo: mo}, 0x00000014, 12 + 1 + 73},
+		{&MoRouteUpdateResp{Header: cmpp.NewHeader(0, cmpp.CommandMoRouteUpdateResp, 0), Result: 1, RouteID: 13, TimeStamp: ts}, 0x80000014, 12 + 1 + 4 + 14},
+		{&PushMtRouteUpdate{Header: cmpp.NewHeader(0, cmpp.CommandPushMtRouteUpdate, 0), UpdateType: RouteUpdateTypeModify, MtRouteInfo: mt, TimeStamp: ts}, 0x00000015, 12 + 1 + 50 + 14},
+		{&PushMtRouteUpdateResp{Header: cmpp.NewHeader(0, cmpp.CommandPushMtRouteUpdateResp, 0)}, 0x80000015, 12 + 1},
+		{&PushMoRouteUpdate{Header: cmpp.NewHeader(0, cmpp.CommandPushMoRouteUpdate, 0), MoRouteInfo: mo, TimeStamp: ts}, 0x00000016, 12 + 1 + 73 + 14},
+		{&PushMoRouteUpdateResp{Header: cmpp.NewHeader(0, cmpp.CommandPushMoRouteUpdateResp, 0), Result: 1}, 0x80000016, 12 + 1},
+	}
+	for _, tt := range tests {
+		command := tt.pdu.GetCommand().(cmpp.CommandID)
+		t.Run(command.String(), func(t *testing.T) {
+			tt.pdu.SetSequenceID(7)
+			data, err := tt.pdu.IEncode()
+			assert.Nil(t, err)
+			assert.Len(t, data, tt.length)
+			assert.Equal(t, tt.id, binary.BigEndian.Uint32(data[4:8]))
+
+			decoded, err := DecodeCMPP30(data)
+			assert.Nil(t, err)
+			assert.IsType(t, tt.pdu, decoded)
+			assert.Equal(t, uint32(7), decoded.GetSequenceID())
+			again, err := decoded.IEncode()
+			assert.Nil(t, err)
+			assert.Equal(t, data, again)
+			assert.Contains(t, decoded.String(), command.String())
+
+			if resp := tt.pdu.GenEmptyResponse(); resp != nil {
+				assert.Equal(t, uint32(7), resp.GetSequenceID())
+				assert.Equal(t, cmpp.CommandID(command|0x80000000), resp.GetCommand())
+			}
+		})
+	}
+}
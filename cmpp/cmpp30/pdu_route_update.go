@@ -0,0 +1,607 @@
+package cmpp30
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/packet"
+)
+
+// MtRouteUpdate represents a CMPP 3.0 MtRouteUpdate PDU.
+// It is used by an ISMG to add, delete or modify an MT route in the GNS.
+type MtRouteUpdate struct {
+	cmpp.Header
+
+	// UpdateType is the type of the update (1 byte): 0=Add, 1=Delete, 2=Modify. See RouteUpdateTypeAdd etc.
+	UpdateType uint8
+
+	// MtRouteInfo is the route to update.
+	MtRouteInfo
+}
+
+// IDecode decodes the byte slice into a MtRouteUpdate PDU.
+func (r *MtRouteUpdate) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.UpdateType = b.ReadUint8()
+	r.MtRouteInfo.read(b)
+
+	return b.Error()
+}
+
+// IEncode encodes the MtRouteUpdate PDU into a byte slice.
+func (r *MtRouteUpdate) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.UpdateType)
+	r.MtRouteInfo.write(b)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *MtRouteUpdate) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *MtRouteUpdate) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *MtRouteUpdate) GetCommand() sms.ICommander {
+	return cmpp.CommandMtRouteUpdate
+}
+
+// GenEmptyResponse generates an empty response PDU for the MtRouteUpdate.
+func (r *MtRouteUpdate) GenEmptyResponse() sms.PDU {
+	return &MtRouteUpdateResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandMtRouteUpdateResp,
+			SequenceID: r.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the MtRouteUpdate PDU.
+func (r *MtRouteUpdate) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("UpdateType", r.UpdateType)
+	r.MtRouteInfo.writeString(w)
+
+	return w.String()
+}
+
+// MtRouteUpdateResp represents a CMPP 3.0 MtRouteUpdateResp PDU.
+// It is the response to a MtRouteUpdate PDU.
+type MtRouteUpdateResp struct {
+	cmpp.Header
+
+	// Result is the result of the update (1 byte): 0=Success, 1=Failure.
+	Result uint8
+
+	// RouteID is the ID of the updated route (4 bytes), assigned by the GNS when a route is added.
+	RouteID uint32
+
+	// TimeStamp is the modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a MtRouteUpdateResp PDU.
+func (r *MtRouteUpdateResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.Result = b.ReadUint8()
+	r.RouteID = b.ReadUint32()
+	r.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the MtRouteUpdateResp PDU into a byte slice.
+func (r *MtRouteUpdateResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.Result)
+	b.WriteUint32(r.RouteID)
+	b.WriteFixedLenString(r.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *MtRouteUpdateResp) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *MtRouteUpdateResp) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *MtRouteUpdateResp) GetCommand() sms.ICommander {
+	return cmpp.CommandMtRouteUpdateResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for MtRouteUpdateResp).
+func (r *MtRouteUpdateResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the MtRouteUpdateResp PDU.
+func (r *MtRouteUpdateResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("Result", r.Result)
+	w.Write("RouteID", r.RouteID)
+	w.Write("TimeStamp", r.TimeStamp)
+
+	return w.String()
+}
+
+// MoRouteUpdate represents a CMPP 3.0 MoRouteUpdate PDU.
+// It is used by an ISMG to add, delete or modify an MO route in the GNS.
+type MoRouteUpdate struct {
+	cmpp.Header
+
+	// UpdateType is the type of the update (1 byte): 0=Add, 1=Delete, 2=Modify. See RouteUpdateTypeAdd etc.
+	UpdateType uint8
+
+	// MoRouteInfo is the route to update.
+	MoRouteInfo
+}
+
+// IDecode decodes the byte slice into a MoRouteUpdate PDU.
+func (r *MoRouteUpdate) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.UpdateType = b.ReadUint8()
+	r.MoRouteInfo.read(b)
+
+	return b.Error()
+}
+
+// IEncode encodes the MoRouteUpdate PDU into a byte slice.
+func (r *MoRouteUpdate) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.UpdateType)
+	r.MoRouteInfo.write(b)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *MoRouteUpdate) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *MoRouteUpdate) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *MoRouteUpdate) GetCommand() sms.ICommander {
+	return cmpp.CommandMoRouteUpdate
+}
+
+// GenEmptyResponse generates an empty response PDU for the MoRouteUpdate.
+func (r *MoRouteUpdate) GenEmptyResponse() sms.PDU {
+	return &MoRouteUpdateResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandMoRouteUpdateResp,
+			SequenceID: r.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the MoRouteUpdate PDU.
+func (r *MoRouteUpdate) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("UpdateType", r.UpdateType)
+	r.MoRouteInfo.writeString(w)
+
+	return w.String()
+}
+
+// MoRouteUpdateResp represents a CMPP 3.0 MoRouteUpdateResp PDU.
+// It is the response to a MoRouteUpdate PDU.
+type MoRouteUpdateResp struct {
+	cmpp.Header
+
+	// Result is the result of the update (1 byte): 0=Success, 1=Failure.
+	Result uint8
+
+	// RouteID is the ID of the updated route (4 bytes), assigned by the GNS when a route is added.
+	RouteID uint32
+
+	// TimeStamp is the modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a MoRouteUpdateResp PDU.
+func (r *MoRouteUpdateResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.Result = b.ReadUint8()
+	r.RouteID = b.ReadUint32()
+	r.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the MoRouteUpdateResp PDU into a byte slice.
+func (r *MoRouteUpdateResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.Result)
+	b.WriteUint32(r.RouteID)
+	b.WriteFixedLenString(r.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *MoRouteUpdateResp) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *MoRouteUpdateResp) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *MoRouteUpdateResp) GetCommand() sms.ICommander {
+	return cmpp.CommandMoRouteUpdateResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for MoRouteUpdateResp).
+func (r *MoRouteUpdateResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the MoRouteUpdateResp PDU.
+func (r *MoRouteUpdateResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("Result", r.Result)
+	w.Write("RouteID", r.RouteID)
+	w.Write("TimeStamp", r.TimeStamp)
+
+	return w.String()
+}
+
+// PushMtRouteUpdate represents a CMPP 3.0 PushMtRouteUpdate PDU.
+// It is used by the GNS to push an MT route update to the ISMGs.
+type PushMtRouteUpdate struct {
+	cmpp.Header
+
+	// UpdateType is the type of the update (1 byte): 0=Add, 1=Delete, 2=Modify. See RouteUpdateTypeAdd etc.
+	UpdateType uint8
+
+	// MtRouteInfo is the route to update.
+	MtRouteInfo
+
+	// TimeStamp is the modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a PushMtRouteUpdate PDU.
+func (r *PushMtRouteUpdate) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.UpdateType = b.ReadUint8()
+	r.MtRouteInfo.read(b)
+	r.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the PushMtRouteUpdate PDU into a byte slice.
+func (r *PushMtRouteUpdate) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.UpdateType)
+	r.MtRouteInfo.write(b)
+	b.WriteFixedLenString(r.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *PushMtRouteUpdate) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *PushMtRouteUpdate) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *PushMtRouteUpdate) GetCommand() sms.ICommander {
+	return cmpp.CommandPushMtRouteUpdate
+}
+
+// GenEmptyResponse generates an empty response PDU for the PushMtRouteUpdate.
+func (r *PushMtRouteUpdate) GenEmptyResponse() sms.PDU {
+	return &PushMtRouteUpdateResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandPushMtRouteUpdateResp,
+			SequenceID: r.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the PushMtRouteUpdate PDU.
+func (r *PushMtRouteUpdate) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("UpdateType", r.UpdateType)
+	r.MtRouteInfo.writeString(w)
+	w.Write("TimeStamp", r.TimeStamp)
+
+	return w.String()
+}
+
+// PushMtRouteUpdateResp represents a CMPP 3.0 PushMtRouteUpdateResp PDU.
+// It is the response to a PushMtRouteUpdate PDU.
+type PushMtRouteUpdateResp struct {
+	cmpp.Header
+
+	// Result is the result of the update (1 byte): 0=Success, 1=Failure.
+	Result uint8
+}
+
+// IDecode decodes the byte slice into a PushMtRouteUpdateResp PDU.
+func (r *PushMtRouteUpdateResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.Result = b.ReadUint8()
+
+	return b.Error()
+}
+
+// IEncode encodes the PushMtRouteUpdateResp PDU into a byte slice.
+func (r *PushMtRouteUpdateResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.Result)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *PushMtRouteUpdateResp) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *PushMtRouteUpdateResp) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *PushMtRouteUpdateResp) GetCommand() sms.ICommander {
+	return cmpp.CommandPushMtRouteUpdateResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for PushMtRouteUpdateResp).
+func (r *PushMtRouteUpdateResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the PushMtRouteUpdateResp PDU.
+func (r *PushMtRouteUpdateResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("Result", r.Result)
+
+	return w.String()
+}
+
+// PushMoRouteUpdate represents a CMPP 3.0 PushMoRouteUpdate PDU.
+// It is used by the GNS to push an MO route update to the ISMGs.
+type PushMoRouteUpdate struct {
+	cmpp.Header
+
+	// UpdateType is the type of the update (1 byte): 0=Add, 1=Delete, 2=Modify. See RouteUpdateTypeAdd etc.
+	UpdateType uint8
+
+	// MoRouteInfo is the route to update.
+	MoRouteInfo
+
+	// TimeStamp is the modification time of the route (14 bytes, YYYYMMDDHHMMSS).
+	TimeStamp string
+}
+
+// IDecode decodes the byte slice into a PushMoRouteUpdate PDU.
+func (r *PushMoRouteUpdate) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.UpdateType = b.ReadUint8()
+	r.MoRouteInfo.read(b)
+	r.TimeStamp = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+// IEncode encodes the PushMoRouteUpdate PDU into a byte slice.
+func (r *PushMoRouteUpdate) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.UpdateType)
+	r.MoRouteInfo.write(b)
+	b.WriteFixedLenString(r.TimeStamp, 14)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *PushMoRouteUpdate) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *PushMoRouteUpdate) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *PushMoRouteUpdate) GetCommand() sms.ICommander {
+	return cmpp.CommandPushMoRouteUpdate
+}
+
+// GenEmptyResponse generates an empty response PDU for the PushMoRouteUpdate.
+func (r *PushMoRouteUpdate) GenEmptyResponse() sms.PDU {
+	return &PushMoRouteUpdateResp{
+		Header: cmpp.Header{
+			CommandID:  cmpp.CommandPushMoRouteUpdateResp,
+			SequenceID: r.GetSequenceID(),
+		},
+	}
+}
+
+// String returns a string representation of the PushMoRouteUpdate PDU.
+func (r *PushMoRouteUpdate) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("UpdateType", r.UpdateType)
+	r.MoRouteInfo.writeString(w)
+	w.Write("TimeStamp", r.TimeStamp)
+
+	return w.String()
+}
+
+// PushMoRouteUpdateResp represents a CMPP 3.0 PushMoRouteUpdateResp PDU.
+// It is the response to a PushMoRouteUpdate PDU.
+type PushMoRouteUpdateResp struct {
+	cmpp.Header
+
+	// Result is the result of the update (1 byte): 0=Success, 1=Failure.
+	Result uint8
+}
+
+// IDecode decodes the byte slice into a PushMoRouteUpdateResp PDU.
+func (r *PushMoRouteUpdateResp) IDecode(data []byte) error {
+	if len(data) < cmpp.MinCMPPPduLength {
+		return cmpp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	r.Header = cmpp.ReadHeader(b)
+	r.Result = b.ReadUint8()
+
+	return b.Error()
+}
+
+// IEncode encodes the PushMoRouteUpdateResp PDU into a byte slice.
+func (r *PushMoRouteUpdateResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	cmpp.WriteHeaderNoLength(r.Header, b)
+	b.WriteUint8(r.Result)
+
+	return b.BytesWithLength()
+}
+
+// SetSequenceID sets the sequence ID of the PDU.
+func (r *PushMoRouteUpdateResp) SetSequenceID(id uint32) {
+	r.Header.SequenceID = id
+}
+
+// GetSequenceID returns the sequence ID of the PDU.
+func (r *PushMoRouteUpdateResp) GetSequenceID() uint32 {
+	return r.Header.SequenceID
+}
+
+// GetCommand returns the command ID of the PDU.
+func (r *PushMoRouteUpdateResp) GetCommand() sms.ICommander {
+	return cmpp.CommandPushMoRouteUpdateResp
+}
+
+// GenEmptyResponse generates an empty response PDU (nil for PushMoRouteUpdateResp).
+func (r *PushMoRouteUpdateResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// String returns a string representation of the PushMoRouteUpdateResp PDU.
+func (r *PushMoRouteUpdateResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", r.Header)
+	w.Write("Result", r.Result)
+
+	return w.String()
+}
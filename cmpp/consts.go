@@ -1,6 +1,7 @@
 package cmpp
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
@@ -21,29 +22,56 @@ const (
 // CommandID represents the command identifier for CMPP PDUs.
 type CommandID uint32
 
+// commandNames maps each CommandID to its name in the spec.
+// CMPP_DELIVER is named CMPP_DELIVERY for compatibility.
+var commandNames = map[CommandID]string{
+	CommandConnect:               "CMPP_CONNECT",
+	CommandTerminate:             "CMPP_TERMINATE",
+	CommandSubmit:                "CMPP_SUBMIT",
+	CommandDeliver:               "CMPP_DELIVERY",
+	CommandQuery:                 "CMPP_QUERY",
+	CommandCancel:                "CMPP_CANCEL",
+	CommandActiveTest:            "CMPP_ACTIVE_TEST",
+	CommandFwd:                   "CMPP_FWD",
+	CommandMtRoute:               "CMPP_MT_ROUTE",
+	CommandMoRoute:               "CMPP_MO_ROUTE",
+	CommandGetMtRoute:            "CMPP_GET_MT_ROUTE",
+	CommandMtRouteUpdate:         "CMPP_MT_ROUTE_UPDATE",
+	CommandMoRouteUpdate:         "CMPP_MO_ROUTE_UPDATE",
+	CommandPushMtRouteUpdate:     "CMPP_PUSH_MT_ROUTE_UPDATE",
+	CommandPushMoRouteUpdate:     "CMPP_PUSH_MO_ROUTE_UPDATE",
+	CommandGetMoRoute:            "CMPP_GET_MO_ROUTE",
+	CommandConnectResp:           "CMPP_CONNECT_RESP",
+	CommandTerminateResp:         "CMPP_TERMINATE_RESP",
+	CommandSubmitResp:            "CMPP_SUBMIT_RESP",
+	CommandDeliverResp:           "CMPP_DELIVERY_RESP",
+	CommandQueryResp:             "CMPP_QUERY_RESP",
+	CommandCancelResp:            "CMPP_CANCEL_RESP",
+	CommandActiveTestResp:        "CMPP_ACTIVE_TEST_RESP",
+	CommandFwdResp:               "CMPP_FWD_RESP",
+	CommandMtRouteResp:           "CMPP_MT_ROUTE_RESP",
+	CommandMoRouteResp:           "CMPP_MO_ROUTE_RESP",
+	CommandGetMtRouteResp:        "CMPP_GET_MT_ROUTE_RESP",
+	CommandMtRouteUpdateResp:     "CMPP_MT_ROUTE_UPDATE_RESP",
+	CommandMoRouteUpdateResp:     "CMPP_MO_ROUTE_UPDATE_RESP",
+	CommandPushMtRouteUpdateResp: "CMPP_PUSH_MT_ROUTE_UPDATE_RESP",
+	CommandPushMoRouteUpdateResp: "CMPP_PUSH_MO_ROUTE_UPDATE_RESP",
+	CommandGetMoRouteResp:        "CMPP_GET_MO_ROUTE_RESP",
+}
+
+// commandIDs maps each name in commandNames back to its CommandID.
+var commandIDs = func() map[string]CommandID {
+	m := make(map[string]CommandID, len(commandNames))
+	for id, name := range commandNames {
+		m[name] = id
+	}
+	return m
+}()
+
 // String returns the string representation of the CommandID.
 func (c CommandID) String() string {
-	switch c {
-	case CommandConnect:
-		return "CMPP_CONNECT"
-	case CommandConnectResp:
-		return "CMPP_CONNECT_RESP"
-	case CommandActiveTest:
-		return "CMPP_ACTIVE_TEST"
-	case CommandActiveTestResp:
-		return "CMPP_ACTIVE_TEST_RESP"
-	case CommandSubmit:
-		return "CMPP_SUBMIT"
-	case CommandSubmitResp:
-		return "CMPP_SUBMIT_RESP"
-	case CommandDeliver:
-		return "CMPP_DELIVERY"
-	case CommandDeliverResp:
-		return "CMPP_DELIVERY_RESP"
-	case CommandTerminate:
-		return "CMPP_TERMINATE"
-	case CommandTerminateResp:
-		return "CMPP_TERMINATE_RESP"
+	if name, ok := commandNames[c]; ok {
+		return name
 	}
 	return fmt.Sprintf("unknown(%d)", uint32(c))
 }
@@ -60,30 +88,15 @@ func (c CommandID) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON implements the json.Unmarshaler interface for CommandID.
 func (c *CommandID) UnmarshalJSON(b []byte) error {
-	switch string(b) {
-	case `"CMPP_CONNECT"`:
-		*c = CommandConnect
-	case `"CMPP_CONNECT_RESP"`:
-		*c = CommandConnectResp
-	case `"CMPP_ACTIVE_TEST"`:
-		*c = CommandActiveTest
-	case `"CMPP_ACTIVE_TEST_RESP"`:
-		*c = CommandActiveTestResp
-	case `"CMPP_SUBMIT"`:
-		*c = CommandSubmit
-	case `"CMPP_SUBMIT_RESP"`:
-		*c = CommandSubmitResp
-	case `"CMPP_DELIVERY"`:
-		*c = CommandDeliver
-	case `"CMPP_DELIVERY_RESP"`:
-		*c = CommandDeliverResp
-	case `"CMPP_TERMINATE"`:
-		*c = CommandTerminate
-	case `"CMPP_TERMINATE_RESP"`:
-		*c = CommandTerminateResp
-	default:
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
 		return fmt.Errorf("invalid command id: %s", string(b))
 	}
+	id, ok := commandIDs[name]
+	if !ok {
+		return fmt.Errorf("invalid command id: %s", string(b))
+	}
+	*c = id
 	return nil
 }
 
@@ -108,22 +121,33 @@ const (
 	CommandActiveTest // 激活测试
 	// CommandFwd represents the CMPP_FWD request (message forwarding).
 	CommandFwd // 消息前转
+)
+
+// CMPP 3.0 route management Command IDs for requests, which are exchanged between an ISMG and the GNS.
+const (
 	// CommandMtRoute represents the CMPP_MT_ROUTE request.
-	CommandMtRoute // MT 路由请求
+	CommandMtRoute CommandID = 0x00000010 // MT 路由请求
 	// CommandMoRoute represents the CMPP_MO_ROUTE request.
-	CommandMoRoute // MO 路由请求
-	// CommandGetRoute represents the CMPP_GET_ROUTE request.
-	CommandGetRoute // 获取路由请求
+	CommandMoRoute CommandID = 0x00000011 // MO 路由请求
+	// CommandGetMtRoute represents the CMPP_GET_MT_ROUTE request.
+	CommandGetMtRoute CommandID = 0x00000012 // 获取 MT 路由请求
 	// CommandMtRouteUpdate represents the CMPP_MT_ROUTE_UPDATE request.
-	CommandMtRouteUpdate // MT 路由更新
+	CommandMtRouteUpdate CommandID = 0x00000013 // MT 路由更新
 	// CommandMoRouteUpdate represents the CMPP_MO_ROUTE_UPDATE request.
-	CommandMoRouteUpdate // MO 路由更新
+	CommandMoRouteUpdate CommandID = 0x00000014 // MO 路由更新
 	// CommandPushMtRouteUpdate represents the CMPP_PUSH_MT_ROUTE_UPDATE request.
-	CommandPushMtRouteUpdate // MT 路由更新
+	CommandPushMtRouteUpdate CommandID = 0x00000015 // MT 路由更新
 	// CommandPushMoRouteUpdate represents the CMPP_PUSH_MO_ROUTE_UPDATE request.
-	CommandPushMoRouteUpdate // MO 路由更新
+	CommandPushMoRouteUpdate CommandID = 0x00000016 // MO 路由更新
+	// CommandGetMoRoute represents the CMPP_GET_MO_ROUTE request.
+	CommandGetMoRoute CommandID = 0x00000017 // 获取 MO 路由请求
 )
 
+// CommandGetRoute represents the CMPP_GET_MT_ROUTE request.
+//
+// Deprecated: use CommandGetMtRoute.
+const CommandGetRoute = CommandGetMtRoute
+
 // CMPP Command IDs for responses.
 const (
 	// CommandResponseNone is a placeholder for no response command.
@@ -145,18 +169,41 @@ const (
 	CommandActiveTestResp // 激活测试应答
 	// CommandFwdResp represents the CMPP_FWD_RESP response.
 	CommandFwdResp // 消息前转应答
+)
+
+// CMPP 3.0 route management Command IDs for responses.
+const (
 	// CommandMtRouteResp represents the CMPP_MT_ROUTE_RESP response.
-	CommandMtRouteResp // MT 路由请求应答
+	CommandMtRouteResp CommandID = 0x80000010 // MT 路由请求应答
 	// CommandMoRouteResp represents the CMPP_MO_ROUTE_RESP response.
-	CommandMoRouteResp // MO 路由请求应答
-	// CommandGetRouteResp represents the CMPP_GET_ROUTE_RESP response.
-	CommandGetRouteResp // 获取路由请求应答
+	CommandMoRouteResp CommandID = 0x80000011 // MO 路由请求应答
+	// CommandGetMtRouteResp represents the CMPP_GET_MT_ROUTE_RESP response.
+	CommandGetMtRouteResp CommandID = 0x80000012 // 获取 MT 路由请求应答
 	// CommandMtRouteUpdateResp represents the CMPP_MT_ROUTE_UPDATE_RESP response.
-	CommandMtRouteUpdateResp // MT 路由更新应答
+	CommandMtRouteUpdateResp CommandID = 0x80000013 // MT 路由更新应答
 	// CommandMoRouteUpdateResp represents the CMPP_MO_ROUTE_UPDATE_RESP response.
-	CommandMoRouteUpdateResp // MO 路由更新应答
+	CommandMoRouteUpdateResp CommandID = 0x80000014 // MO 路由更新应答
 	// CommandPushMtRouteUpdateResp represents the CMPP_PUSH_MT_ROUTE_UPDATE_RESP response.
-	CommandPushMtRouteUpdateResp // MT 路由更新应答
+	CommandPushMtRouteUpdateResp CommandID = 0x80000015 // MT 路由更新应答
 	// CommandPushMoRouteUpdateResp represents the CMPP_PUSH_MO_ROUTE_UPDATE_RESP response.
-	CommandPushMoRouteUpdateResp // MO 路由更新应答
+	CommandPushMoRouteUpdateResp CommandID = 0x80000016 // MO 路由更新应答
+	// CommandGetMoRouteResp represents the CMPP_GET_MO_ROUTE_RESP response.
+	CommandGetMoRouteResp CommandID = 0x80000017 // 获取 MO 路由请求应答
+)
+
+// CommandGetRouteResp represents the CMPP_GET_MT_ROUTE_RESP response.
+//
+// Deprecated: use CommandGetMtRouteResp.
+const CommandGetRouteResp = CommandGetMtRouteResp
+
+// Msg_Fwd_Type of CMPP_FWD.
+const (
+	// MsgFwdTypeMT is a forwarded MT message.
+	MsgFwdTypeMT = 0
+	// MsgFwdTypeMO is a forwarded MO message.
+	MsgFwdTypeMO = 1
+	// MsgFwdTypeMTReport is a forwarded status report of an MT message.
+	MsgFwdTypeMTReport = 2
+	// MsgFwdTypeMOReport is a forwarded status report of an MO message.
+	MsgFwdTypeMOReport = 3
 )
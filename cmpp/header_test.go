@@ -2,6 +2,7 @@ package cmpp
 
 import (
 	"bytes"
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -42,3 +43,18 @@ func TestTryReadHeader(t *testing.T) {
 		assert.Equal(t, true, bytes.Equal(tmp, data))
 	})
 }
+
+func TestCommandID_JSON(t *testing.T) {
+	for _, id := range []CommandID{CommandConnect, CommandDeliverResp, CommandGetMtRoute, CommandGetMoRoute, CommandPushMoRouteUpdateResp, CommandFwd} {
+		b, err := json.Marshal(id)
+		assert.Nil(t, err)
+		var decoded CommandID
+		assert.Nil(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, id, decoded)
+	}
+	assert.Equal(t, "CMPP_MT_ROUTE_UPDATE", CommandMtRouteUpdate.String())
+	assert.Equal(t, "unknown(4660)", CommandID(0x1234).String())
+
+	var c CommandID
+	assert.NotNil(t, json.Unmarshal([]byte(`"CMPP_UNKNOWN"`), &c))
+}
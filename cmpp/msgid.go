@@ -1,6 +1,7 @@
 package cmpp
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -22,6 +23,9 @@ Left-pad with zeros if necessary, right-aligned.
 
 const msgIDFormat = "%02d%02d%02d%02d%02d%07d%05d"
 
+// ErrInvalidMsgID indicates that a MsgID string is not in the form returned by MsgID2String.
+var ErrInvalidMsgID = errors.New("invalid msg id")
+
 // CombineMsgID generates a 64-bit MsgID based on the provided time components, gateway ID, and sequence ID.
 func CombineMsgID(month, day, hour, minute, second, gateID, sequenceID uint64) uint64 {
 	var msgID uint64
@@ -65,3 +69,13 @@ func MsgIDString2Uint64(s string) uint64 {
 	}
 	return CombineMsgID(month, day, hour, minute, second, gateID, sequenceID)
 }
+
+// ParseMsgID converts a MsgID string returned by MsgID2String back to its 64-bit integer form.
+// Unlike MsgIDString2Uint64, it reports malformed strings and out of range fields with ErrInvalidMsgID.
+func ParseMsgID(s string) (uint64, error) {
+	u := MsgIDString2Uint64(s)
+	if u == 0 || MsgID2String(u) != s {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMsgID, s)
+	}
+	return u, nil
+}
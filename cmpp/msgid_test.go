@@ -31,3 +31,15 @@ func TestXX(t *testing.T) {
 func TestSplitMsgID(t *testing.T) {
 	t.Log(MsgIDString2Uint64("0901000115001693265292"))
 }
+
+func TestParseMsgID(t *testing.T) {
+	msgID := CombineMsgID(8, 30, 10, 56, 0, 300, 32768)
+	u, err := ParseMsgID(MsgID2String(msgID))
+	assert.Nil(t, err)
+	assert.Equal(t, msgID, u)
+
+	for _, s := range []string{"", "abc", "1630105600000030032768", "0830105600000030099999"} {
+		_, err = ParseMsgID(s)
+		assert.ErrorIs(t, err, ErrInvalidMsgID, s)
+	}
+}
@@ -0,0 +1,76 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+)
+
+func TestCMPPCodec_Decode(t *testing.T) {
+	connect, err := cmpp20.NewConnect("900001", "pwd", 1).IEncode()
+	assert.Nil(t, err)
+	activeTest := cmpp20.NewActiveTestPacket(2)
+
+	c := NewCMPPCodec()
+
+	r := newMemReader(connect, activeTest)
+	data, err := c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, connect, data)
+	data, err = c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, activeTest, data)
+
+	for _, n := range []int{0, 3, cmpp.HeaderLength, len(connect) - 1} {
+		_, err = c.Decode(newMemReader(connect[:n]))
+		assert.Equal(t, ErrPacketNotComplete, err, n)
+	}
+
+	// a length of 0 or 3 used to slice out of bounds
+	for _, l := range []uint32{0, 3, cmpp.HeaderLength - 1} {
+		_, err = c.Decode(newMemReader(lengthPrefixed(l, 8)))
+		assert.True(t, errors.Is(err, ErrFrameTooSmall), l)
+	}
+	_, err = c.Decode(newMemReader(lengthPrefixed(DefaultMaxFrameLength+1, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+
+	// custom limits
+	_, err = NewCMPPCodec(WithMinFrameLength(len(connect) + 1)).Decode(newMemReader(connect))
+	assert.True(t, errors.Is(err, ErrFrameTooSmall))
+	_, err = NewCMPPCodec(WithMaxFrameLength(len(connect) - 1)).Decode(newMemReader(connect))
+	var fe *FrameLengthError
+	if assert.True(t, errors.As(err, &fe)) {
+		assert.Equal(t, ErrFrameTooLarge, fe.Err)
+		assert.Equal(t, uint32(len(connect)), fe.Length)
+		assert.Equal(t, len(connect)-1, fe.Limit)
+	}
+}
+
+func TestCMPPCodec_DecodeBlocked(t *testing.T) {
+	connect, err := cmpp20.NewConnect("900001", "pwd", 1).IEncode()
+	assert.Nil(t, err)
+
+	c := NewCMPPCodec()
+
+	r := newMemReader(connect, connect)
+	for i := 0; i < 2; i++ {
+		data, err := c.DecodeBlocked(r)
+		assert.Nil(t, err)
+		assert.Equal(t, connect, data)
+	}
+
+	_, err = c.DecodeBlocked(newMemReader(connect[:len(connect)-1]))
+	assert.NotNil(t, err)
+
+	for _, l := range []uint32{0, 3} {
+		_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(l, 8)))
+		assert.True(t, errors.Is(err, ErrFrameTooSmall), l)
+	}
+	// must not allocate 4GB
+	_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(0xFFFFFFFF, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+}
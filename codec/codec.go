@@ -2,11 +2,39 @@ package codec
 
 import (
 	"errors"
+	"fmt"
 	"io"
 )
 
-// ErrPacketNotComplete indicates that the reader has not received a full packet yet.
-var ErrPacketNotComplete = errors.New("packet not completed")
+var (
+	// ErrPacketNotComplete indicates that the reader has not received a full packet yet.
+	ErrPacketNotComplete = errors.New("packet not completed")
+
+	// ErrFrameTooSmall indicates that the length field of a frame is smaller than the configured minimum,
+	// which is the protocol header length by default.
+	// The stream cannot be resynchronized, so the connection should be closed.
+	ErrFrameTooSmall = errors.New("frame too small")
+
+	// ErrFrameTooLarge indicates that the length field of a frame exceeds the configured maximum.
+	// The connection should be closed.
+	ErrFrameTooLarge = errors.New("frame too large")
+)
+
+// FrameLengthError is returned when the length field of a frame is out of the configured limits.
+// Err is ErrFrameTooSmall or ErrFrameTooLarge, so it can be checked with errors.Is.
+type FrameLengthError struct {
+	Err    error
+	Length uint32
+	Limit  int
+}
+
+func (e *FrameLengthError) Error() string {
+	return fmt.Sprintf("%s: length %d, limit %d", e.Err.Error(), e.Length, e.Limit)
+}
+
+func (e *FrameLengthError) Unwrap() error {
+	return e.Err
+}
 
 // Codec defines the interface for handling protocol-specific packet encoding and decoding,
 // particularly addressing the TCP sticky packet problem.
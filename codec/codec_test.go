@@ -0,0 +1,55 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// memReader is an in-memory ConnReader. Size returns the number of unread bytes.
+type memReader struct {
+	buf []byte
+}
+
+func newMemReader(chunks ...[]byte) *memReader {
+	return &memReader{buf: bytes.Join(chunks, nil)}
+}
+
+func (r *memReader) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *memReader) Peek(n int) ([]byte, error) {
+	if n > len(r.buf) {
+		return r.buf, io.EOF
+	}
+	return r.buf[:n], nil
+}
+
+func (r *memReader) Discard(n int) (int, error) {
+	if n > len(r.buf) {
+		n = len(r.buf)
+		r.buf = nil
+		return n, io.EOF
+	}
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *memReader) Size() int {
+	return len(r.buf)
+}
+
+// lengthPrefixed returns a frame whose length field is totalLen, padded or cut to size bytes.
+func lengthPrefixed(totalLen uint32, size int) []byte {
+	b := make([]byte, size)
+	if size >= 4 {
+		binary.BigEndian.PutUint32(b, totalLen)
+	}
+	return b
+}
@@ -0,0 +1,257 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp50"
+)
+
+// ErrUnknownProtocol indicates that the first packet of a connection is not a CMPP connect,
+// SMPP bind, SGIP bind or SMGP login.
+var ErrUnknownProtocol = errors.New("unknown protocol")
+
+const (
+	// cmppConnectLength is the length of CMPP_CONNECT, which is the same in CMPP 2.0 and 3.0.
+	// Version is the byte before the 4-byte Timestamp.
+	cmppConnectLength = cmpp20.MaxConnectLength
+
+	// smgpLoginLength is the length of SMGP Login: Header + ClientID(8) + AuthenticatorClient(16) + LoginMode(1) + TimeStamp(4) + Version(1).
+	smgpLoginLength = smgp.HeaderLength + 8 + 16 + 1 + 4 + 1
+
+	// sgipBindLength is the length of SGIP Bind. LoginType is the first byte of the body.
+	sgipBindLength = sgip.MaxBindLength
+)
+
+// Detect recognises the protocol and version from the first packet of a connection,
+// which must be a CMPP_CONNECT, an SMPP bind_transmitter/bind_receiver/bind_transceiver,
+// an SGIP Bind or an SMGP Login. data must hold the complete packet.
+//
+// All of CMPP_CONNECT, SGIP Bind and SMGP Login use the command ID 0x00000001, so they are told apart
+// by their fixed lengths and the version or login type fields. SMPP bind_receiver uses 0x00000001 too, and may have
+// the same length, so SMPP binds are validated field by field first.
+func Detect(data []byte) (consts.Protocol, consts.Version, error) {
+	if len(data) < cmpp.HeaderLength {
+		return consts.ProtocolUnknown, consts.UnknownVersion(0), ErrUnknownProtocol
+	}
+
+	if h, err := smpp.PeekHeader(data); err == nil && int(h.Length) == len(data) && h.Status == smpp.ESME_ROK {
+		switch h.ID {
+		case smpp.BIND_RECEIVER, smpp.BIND_TRANSMITTER, smpp.BIND_TRANSCEIVER:
+			if v, ok := smppBindVersion(data[smpp.MinSMPPPacketLen:]); ok {
+				return consts.ProtocolSMPP, v, nil
+			}
+		}
+	}
+
+	if h, err := cmpp.PeekHeader(data); err == nil && h.CommandID == cmpp.CommandConnect && len(data) == cmppConnectLength {
+		switch v := consts.CMPPVersion(data[cmppConnectLength-5]); v {
+		case consts.CMPPVersion2_0, consts.CMPPVersion2_1, consts.CMPPVersion3_0:
+			return consts.ProtocolCMPP, v, nil
+		}
+	}
+
+	if h, err := smgp.PeekHeader(data); err == nil && h.CommandID == smgp.CommandLogin && len(data) == smgpLoginLength {
+		// LoginMode must be SEND_MODE, RECEIVE_MODE or TRANSMIT_MODE
+		if mode := data[smgp.HeaderLength+8+16]; mode <= smgp.TRANSMIT_MODE {
+			return consts.ProtocolSMGP, consts.SMGPVersion(data[smgpLoginLength-1]), nil
+		}
+	}
+
+	if h, err := sgip.PeekHeader(data); err == nil && h.CommandID == sgip.SGIP_BIND && len(data) == sgipBindLength {
+		switch sgip.LoginType(data[sgip.HeaderLength]) {
+		case sgip.SP_SMG, sgip.SMG_SP, sgip.SMG_SMG:
+			return consts.ProtocolSGIP, consts.SGIPVersion1_2, nil
+		}
+	}
+
+	return consts.ProtocolUnknown, consts.UnknownVersion(0), ErrUnknownProtocol
+}
+
+// smppBindVersion checks the body of an SMPP bind and returns its interface_version.
+// system_id, password and system_type are C-Octet Strings of at most 16, 9 and 13 bytes,
+// followed by interface_version, addr_ton, addr_npi and address_range (at most 41 bytes).
+func smppBindVersion(body []byte) (consts.Version, bool) {
+	for _, max := range []int{16, 9, 13} {
+		idx := bytes.IndexByte(body, 0)
+		if idx < 0 || idx >= max {
+			return nil, false
+		}
+		body = body[idx+1:]
+	}
+	if len(body) < 4 {
+		return nil, false
+	}
+	version := body[0]
+	if idx := bytes.IndexByte(body[3:], 0); idx < 0 || idx >= 41 || idx+4 != len(body) {
+		return nil, false
+	}
+	return consts.SMPPVersion(version), true
+}
+
+// DetectCodec is a Codec for listeners that accept several protocols on one port.
+// It recognises the protocol from the first packet with Detect, then locks onto the matching Codec
+// and decode function for the rest of the connection.
+//
+// A DetectCodec holds the state of one connection, so create one per connection.
+type DetectCodec struct {
+	opts  []Option
+	frame frameDecoder
+
+	mu       sync.RWMutex
+	codec    Codec
+	decode   protocol.DecodeFunc
+	protocol consts.Protocol
+	version  consts.Version
+}
+
+// NewDetectCodec creates and returns a new DetectCodec instance.
+// opts are passed to the Codec of the detected protocol.
+func NewDetectCodec(opts ...Option) *DetectCodec {
+	return &DetectCodec{
+		opts:     opts,
+		frame:    newFrameDecoder(cmpp.HeaderLength, DefaultMaxFrameLength, opts...),
+		protocol: consts.ProtocolUnknown,
+		version:  consts.UnknownVersion(0),
+	}
+}
+
+// Decode reads a complete packet from the ConnReader without blocking.
+// The first packet is used to detect the protocol; ErrUnknownProtocol is returned if it is not recognised.
+// If the data is incomplete, it will return ErrPacketNotComplete.
+func (dc *DetectCodec) Decode(c ConnReader) ([]byte, error) {
+	if codec := dc.lockedCodec(); codec != nil {
+		return codec.Decode(c)
+	}
+
+	totalLenBytes, _ := c.Peek(totalLengthBytes)
+	if len(totalLenBytes) < totalLengthBytes {
+		return nil, ErrPacketNotComplete
+	}
+	totalLen := binary.BigEndian.Uint32(totalLenBytes)
+	if err := dc.frame.checkLength(totalLen); err != nil {
+		return nil, err
+	}
+	if c.Size() < int(totalLen) {
+		return nil, ErrPacketNotComplete
+	}
+	buf, _ := c.Peek(int(totalLen))
+	if len(buf) < int(totalLen) {
+		return nil, ErrPacketNotComplete
+	}
+
+	codec, err := dc.detect(buf)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Decode(c)
+}
+
+// DecodeBlocked reads a complete packet from the ConnReader in a blocking manner.
+// The first packet is used to detect the protocol; ErrUnknownProtocol is returned if it is not recognised.
+func (dc *DetectCodec) DecodeBlocked(c ConnReader) ([]byte, error) {
+	if codec := dc.lockedCodec(); codec != nil {
+		return codec.DecodeBlocked(c)
+	}
+
+	// the first packet may not fit in the buffer of the ConnReader, so it is read instead of peeked
+	buf, err := dc.frame.decodeBlocked(c)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dc.detect(buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// DecodePDU decodes a packet returned by Decode or DecodeBlocked with the decode function
+// of the detected protocol, e.g. cmpp30.DecodeCMPP30.
+func (dc *DetectCodec) DecodePDU(data []byte) (protocol.PDU, error) {
+	dc.mu.RLock()
+	decode := dc.decode
+	dc.mu.RUnlock()
+	if decode == nil {
+		return nil, ErrUnknownProtocol
+	}
+	return decode(data)
+}
+
+// Protocol returns the detected protocol, or consts.ProtocolUnknown before the first packet.
+func (dc *DetectCodec) Protocol() consts.Protocol {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	return dc.protocol
+}
+
+// Version returns the detected version, or consts.UnknownVersion(0) before the first packet.
+// For CMPP and SMGP it is the Version of the connect/login, for SMPP the interface_version of the bind.
+func (dc *DetectCodec) Version() consts.Version {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	return dc.version
+}
+
+func (dc *DetectCodec) lockedCodec() Codec {
+	dc.mu.RLock()
+	defer dc.mu.RUnlock()
+	return dc.codec
+}
+
+// detect recognises the protocol of the first packet and locks onto the matching Codec.
+func (dc *DetectCodec) detect(data []byte) (Codec, error) {
+	p, v, err := Detect(data)
+	if err != nil {
+		// frames shorter than a header get here if WithMinFrameLength allows them
+		head := data
+		if len(head) > cmpp.HeaderLength {
+			head = head[:cmpp.HeaderLength]
+		}
+		return nil, fmt.Errorf("%w: % x", err, head)
+	}
+
+	var codec Codec
+	var decode protocol.DecodeFunc
+	switch p {
+	case consts.ProtocolCMPP:
+		codec = NewCMPPCodec(dc.opts...)
+		decode = cmpp20.DecodeCMPP20
+		if v == consts.CMPPVersion3_0 {
+			decode = cmpp30.DecodeCMPP30
+		}
+	case consts.ProtocolSMPP:
+		codec, decode = NewSMPPCodec(dc.opts...), smpp34.DecodeSMPP34
+		if v == consts.SMPPVersion5_0 {
+			decode = smpp50.DecodeSMPP50
+		}
+		if dc.frame.strictTLVs {
+			decode = smpp34.DecodeSMPP34Strict
+			if v == consts.SMPPVersion5_0 {
+				decode = smpp50.DecodeSMPP50Strict
+			}
+		}
+	case consts.ProtocolSGIP:
+		codec, decode = NewSGIPCodec(dc.opts...), sgip12.DecodeSGIP12
+	case consts.ProtocolSMGP:
+		codec, decode = NewSMGPCodec(dc.opts...), smgp30.DecodeSMGP30
+	}
+
+	dc.mu.Lock()
+	dc.codec, dc.decode, dc.protocol, dc.version = codec, decode, p, v
+	dc.mu.Unlock()
+	return codec, nil
+}
@@ -0,0 +1,195 @@
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp50"
+)
+
+type detectCase struct {
+	name     string
+	first    protocol.PDU
+	second   []byte
+	protocol consts.Protocol
+	version  consts.Version
+}
+
+func detectCases() []detectCase {
+	cmpp30Connect := &cmpp30.Connect{
+		Header:              cmpp.NewHeader(0, cmpp.CommandConnect, 1),
+		SourceAddr:          "900001",
+		AuthenticatorSource: "0123456789abcdef",
+		Version:             cmpp.Version30,
+	}
+	smgpLogin := smgp30.NewLogin("sp", "pwd", 1)
+	smgpLogin.LoginMode = smgp.RECEIVE_MODE
+
+	bind := func(id smpp.CMDId, version uint8) protocol.PDU {
+		return &smpp34.Bind{
+			Header:           smpp.Header{ID: id, Sequence: 1},
+			SystemID:         "esme",
+			Password:         "pwd",
+			InterfaceVersion: version,
+			AddressRange:     "1065",
+		}
+	}
+
+	return []detectCase{
+		{"cmpp20", cmpp20.NewConnect("900001", "pwd", 1), cmpp20.NewActiveTestPacket(2), consts.ProtocolCMPP, consts.CMPPVersion2_0},
+		{"cmpp30", cmpp30Connect, cmpp20.NewActiveTestPacket(2), consts.ProtocolCMPP, consts.CMPPVersion3_0},
+		{"smpp34 transceiver", bind(smpp.BIND_TRANSCEIVER, 0x34), smpp34.NewEnquireLinkReqBytes(2), consts.ProtocolSMPP, consts.SMPPVersion3_4},
+		{"smpp34 receiver", bind(smpp.BIND_RECEIVER, 0x34), smpp34.NewEnquireLinkReqBytes(2), consts.ProtocolSMPP, consts.SMPPVersion3_4},
+		{"smpp50 transmitter", bind(smpp.BIND_TRANSMITTER, 0x50), mustEncode(&smpp50.CancelBroadcastSm{Header: smpp.Header{ID: smpp.CANCEL_BROADCAST_SM, Sequence: 2}, MessageID: "b1"}), consts.ProtocolSMPP, consts.SMPPVersion5_0},
+		{"sgip12", sgip12.NewBind("sp", "pwd", 3000012345, 1), mustEncode(&sgip12.Unbind{Header: sgip.NewHeader(0, sgip.SGIP_UNBIND, 3000012345, 2)}), consts.ProtocolSGIP, consts.SGIPVersion1_2},
+		{"smgp30", smgpLogin, smgp30.NewActiveTestPacket(2), consts.ProtocolSMGP, consts.SMGPVersion3_0},
+		// bind_receiver with the length of CMPP_CONNECT, the CMPP Version byte is '0' (0x30) of address_range
+		{"smpp34 receiver as long as cmpp connect", &smpp34.Bind{
+			Header: smpp.Header{ID: smpp.BIND_RECEIVER, Sequence: 1}, SystemID: "esme", Password: "pwd", InterfaceVersion: 0x34, AddressRange: "123450789",
+		}, smpp34.NewEnquireLinkReqBytes(2), consts.ProtocolSMPP, consts.SMPPVersion3_4},
+		// bind_receiver with the length of SMGP Login, the SMGP LoginMode byte is 0x02 of address_range
+		{"smpp34 receiver as long as smgp login", &smpp34.Bind{
+			Header: smpp.Header{ID: smpp.BIND_RECEIVER, Sequence: 1}, SystemID: "esme", Password: "pwd", InterfaceVersion: 0x34, AddressRange: "1234567\x0289ab",
+		}, smpp34.NewEnquireLinkReqBytes(2), consts.ProtocolSMPP, consts.SMPPVersion3_4},
+	}
+}
+
+func mustEncode(p protocol.PDU) []byte {
+	data, err := p.IEncode()
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestDetect(t *testing.T) {
+	assert.Len(t, mustEncode(detectCases()[7].first), cmppConnectLength)
+	assert.Len(t, mustEncode(detectCases()[8].first), smgpLoginLength)
+	for _, tt := range detectCases() {
+		p, v, err := Detect(mustEncode(tt.first))
+		assert.Nil(t, err, tt.name)
+		assert.Equal(t, tt.protocol, p, tt.name)
+		assert.Equal(t, tt.version, v, tt.name)
+	}
+
+	for _, data := range [][]byte{
+		nil,
+		cmpp20.NewActiveTestPacket(1),
+		smpp34.NewEnquireLinkReqBytes(1),
+		// a bind with a too long system_id
+		mustEncode(&smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSMITTER}, SystemID: "0123456789abcdefg"}),
+	} {
+		p, _, err := Detect(data)
+		assert.Equal(t, ErrUnknownProtocol, err)
+		assert.Equal(t, consts.ProtocolUnknown, p)
+	}
+}
+
+func TestDetectCodec_Decode(t *testing.T) {
+	for _, tt := range detectCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			first := mustEncode(tt.first)
+			c := NewDetectCodec()
+
+			_, err := c.Decode(newMemReader(first[:len(first)-1]))
+			assert.Equal(t, ErrPacketNotComplete, err)
+			assert.Equal(t, consts.ProtocolUnknown, c.Protocol())
+
+			r := newMemReader(first, tt.second)
+			data, err := c.Decode(r)
+			assert.Nil(t, err)
+			assert.Equal(t, first, data)
+			assert.Equal(t, tt.protocol, c.Protocol())
+			assert.Equal(t, tt.version, c.Version())
+
+			pdu, err := c.DecodePDU(data)
+			if assert.Nil(t, err) {
+				assert.Equal(t, tt.first.GetCommand().String(), pdu.GetCommand().String())
+			}
+
+			data, err = c.Decode(r)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.second, data)
+			_, err = c.DecodePDU(data)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestDetectCodec_DecodeBlocked(t *testing.T) {
+	for _, tt := range detectCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			first := mustEncode(tt.first)
+			c := NewDetectCodec()
+
+			r := bufio.NewReader(bytes.NewReader(append(append([]byte{}, first...), tt.second...)))
+			data, err := c.DecodeBlocked(r)
+			assert.Nil(t, err)
+			assert.Equal(t, first, data)
+			assert.Equal(t, tt.protocol, c.Protocol())
+			assert.Equal(t, tt.version, c.Version())
+
+			data, err = c.DecodeBlocked(r)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.second, data)
+		})
+	}
+}
+
+func TestDetectCodec_Unknown(t *testing.T) {
+	c := NewDetectCodec()
+	_, err := c.Decode(newMemReader(cmpp20.NewActiveTestPacket(1)))
+	assert.True(t, errors.Is(err, ErrUnknownProtocol))
+	_, err = c.DecodePDU(cmpp20.NewActiveTestPacket(1))
+	assert.True(t, errors.Is(err, ErrUnknownProtocol))
+
+	_, err = NewDetectCodec().DecodeBlocked(bufio.NewReader(bytes.NewReader(lengthPrefixed(0xFFFFFFFF, 16))))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+
+	// shorter than a header
+	_, err = NewDetectCodec(WithMinFrameLength(4)).Decode(newMemReader(lengthPrefixed(6, 6)))
+	assert.True(t, errors.Is(err, ErrUnknownProtocol))
+
+	// larger than the buffer of the bufio.Reader
+	_, err = NewDetectCodec(WithMaxFrameLength(8192)).DecodeBlocked(bufio.NewReader(bytes.NewReader(lengthPrefixed(5000, 5000))))
+	assert.True(t, errors.Is(err, ErrUnknownProtocol))
+}
+
+func TestDetectCodec_StrictTLVs(t *testing.T) {
+	submit := &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 2}, DestinationAddr: "123"}
+	// sar_msg_ref_num must be 2 bytes
+	submit.TLVs.SetTLV(smpp.TLV{Tag: smpp.SAR_MSG_REF_NUM, Length: 1, ValueBytes: []byte{7}})
+	first := mustEncode(&smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}, SystemID: "esme", InterfaceVersion: 0x34})
+	second := mustEncode(submit)
+
+	for _, strict := range []bool{false, true} {
+		var opts []Option
+		if strict {
+			opts = append(opts, WithStrictTLVs())
+		}
+		c := NewDetectCodec(opts...)
+		r := newMemReader(append(append([]byte{}, first...), second...))
+		_, err := c.Decode(r)
+		assert.Nil(t, err)
+		data, err := c.Decode(r)
+		assert.Nil(t, err)
+
+		_, err = c.DecodePDU(data)
+		assert.Equal(t, strict, errors.Is(err, smpp.ErrInvalidTLVLength))
+	}
+}
@@ -0,0 +1,94 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// totalLengthBytes is the size of the total length field at the beginning of every frame.
+const totalLengthBytes = 4
+
+// frameDecoder reads frames starting with a 4-byte big-endian total length,
+// which includes the length field itself.
+type frameDecoder struct {
+	frameOptions
+}
+
+// newFrameDecoder creates a frameDecoder whose limits default to headerLength and maxLength.
+func newFrameDecoder(headerLength, maxLength int, opts ...Option) frameDecoder {
+	o := newFrameOptions(opts...)
+	if o.minFrameLength == 0 {
+		o.minFrameLength = headerLength
+	}
+	if o.minFrameLength < totalLengthBytes {
+		o.minFrameLength = totalLengthBytes
+	}
+	if o.maxFrameLength == 0 {
+		o.maxFrameLength = maxLength
+	}
+	return frameDecoder{frameOptions: o}
+}
+
+// checkLength validates the total length read from the wire.
+func (f frameDecoder) checkLength(totalLen uint32) error {
+	if totalLen < uint32(f.minFrameLength) {
+		return &FrameLengthError{Err: ErrFrameTooSmall, Length: totalLen, Limit: f.minFrameLength}
+	}
+	if totalLen > uint32(f.maxFrameLength) {
+		return &FrameLengthError{Err: ErrFrameTooLarge, Length: totalLen, Limit: f.maxFrameLength}
+	}
+	return nil
+}
+
+func (f frameDecoder) decode(c ConnReader) ([]byte, error) {
+	totalLenBytes, _ := c.Peek(totalLengthBytes)
+	if len(totalLenBytes) < totalLengthBytes {
+		return nil, ErrPacketNotComplete
+	}
+
+	totalLen := binary.BigEndian.Uint32(totalLenBytes)
+	if err := f.checkLength(totalLen); err != nil {
+		return nil, err
+	}
+	if c.Size() < int(totalLen) {
+		return nil, ErrPacketNotComplete
+	}
+
+	buf, _ := c.Peek(int(totalLen))
+	if len(buf) < int(totalLen) {
+		return nil, ErrPacketNotComplete
+	}
+
+	n, err := c.Discard(int(totalLen))
+	if err != nil {
+		return nil, fmt.Errorf("discard total packet error: %w", err)
+	}
+	if n != len(buf) {
+		return nil, fmt.Errorf("not discard enough data")
+	}
+
+	return buf, nil
+}
+
+func (f frameDecoder) decodeBlocked(c ConnReader) ([]byte, error) {
+	totalLenBytes := make([]byte, totalLengthBytes)
+	_, err := io.ReadFull(c, totalLenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	totalLen := binary.BigEndian.Uint32(totalLenBytes)
+	if err := f.checkLength(totalLen); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, totalLen)
+	copy(buf, totalLenBytes)
+	_, err = io.ReadFull(c, buf[totalLengthBytes:])
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,70 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+func isExpectedError(err error) bool {
+	for _, target := range []error{ErrPacketNotComplete, ErrFrameTooSmall, ErrFrameTooLarge, ErrUnknownProtocol, io.EOF, io.ErrUnexpectedEOF} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func fuzzCodecs() map[string]Codec {
+	return map[string]Codec{
+		"cmpp":   NewCMPPCodec(),
+		"smpp":   NewSMPPCodec(),
+		"sgip":   NewSGIPCodec(),
+		"smgp":   NewSMGPCodec(),
+		"small":  NewCMPPCodec(WithMinFrameLength(1), WithMaxFrameLength(64)),
+		"detect": NewDetectCodec(),
+	}
+}
+
+// FuzzDecode feeds arbitrary bytes to every codec. No input may panic,
+// and every frame returned must match its length field and the limits of the codec.
+func FuzzDecode(f *testing.F) {
+	connect, _ := cmpp20.NewConnect("900001", "pwd", 1).IEncode()
+	bind, _ := sgip12.NewBind("sp", "pwd", 1, 1).IEncode()
+	login, _ := smgp30.NewLogin("sp", "pwd", 1).IEncode()
+	f.Add(connect)
+	f.Add(bind)
+	f.Add(login)
+	f.Add(smpp34.NewEnquireLinkReqBytes(1))
+	f.Add(append(smpp34.NewEnquireLinkReqBytes(1), smpp34.NewUnBindBytes(2)...))
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 0})
+	f.Add([]byte{0, 0, 0, 3, 1, 2, 3})
+	f.Add([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for name, c := range fuzzCodecs() {
+			for _, decode := range []func(ConnReader) ([]byte, error){c.Decode, c.DecodeBlocked} {
+				r := newMemReader(data)
+				for r.Size() > 0 {
+					frame, err := decode(r)
+					if err != nil {
+						if !isExpectedError(err) {
+							t.Fatalf("%s: unexpected error %v", name, err)
+						}
+						break
+					}
+					if len(frame) < 4 || int(binary.BigEndian.Uint32(frame)) != len(frame) {
+						t.Fatalf("%s: invalid frame %v", name, frame)
+					}
+				}
+			}
+		}
+	})
+}
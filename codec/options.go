@@ -0,0 +1,54 @@
+package codec
+
+// DefaultMaxFrameLength is the default maximum length of a frame accepted by a codec.
+// SMPPCodec defaults to smpp.MAX_PDU_SIZE instead, as a message_payload TLV may carry up to 64KB.
+const DefaultMaxFrameLength = 4096
+
+// Option configures a codec.
+type Option func(*frameOptions)
+
+// frameOptions holds the limits of the frame length, zero means the default of the codec,
+// and the decode settings of DetectCodec.
+type frameOptions struct {
+	minFrameLength int
+	maxFrameLength int
+	strictTLVs     bool
+}
+
+func newFrameOptions(opts ...Option) frameOptions {
+	o := frameOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMinFrameLength sets the minimum length of a frame, including the header.
+// Frames whose length field is smaller than n are rejected with ErrFrameTooSmall.
+// Defaults to the header length of the protocol. n is never lower than the 4-byte length field itself.
+func WithMinFrameLength(n int) Option {
+	return func(o *frameOptions) {
+		if n > 0 {
+			o.minFrameLength = n
+		}
+	}
+}
+
+// WithMaxFrameLength sets the maximum length of a frame, including the header.
+// Frames whose length field is larger than n are rejected with ErrFrameTooLarge.
+// Defaults to DefaultMaxFrameLength, or smpp.MAX_PDU_SIZE for SMPP. n <= 0 is ignored.
+func WithMaxFrameLength(n int) Option {
+	return func(o *frameOptions) {
+		if n > 0 {
+			o.maxFrameLength = n
+		}
+	}
+}
+
+// WithStrictTLVs makes DetectCodec.DecodePDU decode SMPP with smpp34.DecodeSMPP34Strict or
+// smpp50.DecodeSMPP50Strict, so a known TLV with an invalid length fails the decode.
+func WithStrictTLVs() Option {
+	return func(o *frameOptions) {
+		o.strictTLVs = true
+	}
+}
@@ -0,0 +1,30 @@
+package codec
+
+import (
+	"github.com/hujm2023/go-sms-protocol/sgip"
+)
+
+// SGIPCodec provides methods for decoding SGIP PDUs.
+// It handles sticky packets for the SGIP protocol.
+type SGIPCodec struct {
+	frameDecoder
+}
+
+// NewSGIPCodec creates and returns a new SGIPCodec instance.
+// By default frames shorter than sgip.HeaderLength or longer than DefaultMaxFrameLength are rejected.
+func NewSGIPCodec(opts ...Option) *SGIPCodec {
+	return &SGIPCodec{frameDecoder: newFrameDecoder(sgip.HeaderLength, DefaultMaxFrameLength, opts...)}
+}
+
+// Decode implements sticky packet handling for the SGIP protocol,
+// reading a complete SGIP packet from the ConnReader.
+// If the data is incomplete, it will return ErrPacketNotComplete.
+func (sc *SGIPCodec) Decode(c ConnReader) ([]byte, error) {
+	return sc.decode(c)
+}
+
+// DecodeBlocked reads a complete SGIP packet from the ConnReader in a blocking manner.
+// It reads the message length first, then reads the remaining bytes.
+func (sc *SGIPCodec) DecodeBlocked(c ConnReader) ([]byte, error) {
+	return sc.decodeBlocked(c)
+}
@@ -0,0 +1,65 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+)
+
+func TestSGIPCodec_Decode(t *testing.T) {
+	bind, err := sgip12.NewBind("sp", "pwd", 3000012345, 1).IEncode()
+	assert.Nil(t, err)
+	unbind, err := (&sgip12.Unbind{Header: sgip.NewHeader(0, sgip.SGIP_UNBIND, 3000012345, 2)}).IEncode()
+	assert.Nil(t, err)
+
+	c := NewSGIPCodec()
+
+	// sticky packets
+	r := newMemReader(bind, unbind)
+	data, err := c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, bind, data)
+	data, err = c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, unbind, data)
+
+	// incomplete
+	for _, n := range []int{0, 3, sgip.HeaderLength, len(bind) - 1} {
+		_, err = c.Decode(newMemReader(bind[:n]))
+		assert.Equal(t, ErrPacketNotComplete, err, n)
+	}
+
+	_, err = c.Decode(newMemReader(lengthPrefixed(sgip.HeaderLength-1, sgip.HeaderLength)))
+	assert.True(t, errors.Is(err, ErrFrameTooSmall))
+	_, err = c.Decode(newMemReader(lengthPrefixed(DefaultMaxFrameLength+1, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+
+	_, err = NewSGIPCodec(WithMaxFrameLength(len(bind) - 1)).Decode(newMemReader(bind))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+}
+
+func TestSGIPCodec_DecodeBlocked(t *testing.T) {
+	bind, err := sgip12.NewBind("sp", "pwd", 3000012345, 1).IEncode()
+	assert.Nil(t, err)
+
+	c := NewSGIPCodec()
+
+	r := newMemReader(bind, bind)
+	for i := 0; i < 2; i++ {
+		data, err := c.DecodeBlocked(r)
+		assert.Nil(t, err)
+		assert.Equal(t, bind, data)
+	}
+
+	_, err = c.DecodeBlocked(newMemReader(bind[:len(bind)-1]))
+	assert.NotNil(t, err)
+
+	_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(0, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooSmall))
+	_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(0xFFFFFFFF, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+}
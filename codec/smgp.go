@@ -0,0 +1,30 @@
+package codec
+
+import (
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+// SMGPCodec provides methods for decoding SMGP PDUs.
+// It handles sticky packets for the SMGP protocol.
+type SMGPCodec struct {
+	frameDecoder
+}
+
+// NewSMGPCodec creates and returns a new SMGPCodec instance.
+// By default frames shorter than smgp.HeaderLength or longer than DefaultMaxFrameLength are rejected.
+func NewSMGPCodec(opts ...Option) *SMGPCodec {
+	return &SMGPCodec{frameDecoder: newFrameDecoder(smgp.HeaderLength, DefaultMaxFrameLength, opts...)}
+}
+
+// Decode implements sticky packet handling for the SMGP protocol,
+// reading a complete SMGP packet from the ConnReader.
+// If the data is incomplete, it will return ErrPacketNotComplete.
+func (mc *SMGPCodec) Decode(c ConnReader) ([]byte, error) {
+	return mc.decode(c)
+}
+
+// DecodeBlocked reads a complete SMGP packet from the ConnReader in a blocking manner.
+// It reads the packet length first, then reads the remaining bytes.
+func (mc *SMGPCodec) DecodeBlocked(c ConnReader) ([]byte, error) {
+	return mc.decodeBlocked(c)
+}
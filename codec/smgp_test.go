@@ -0,0 +1,64 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+)
+
+func TestSMGPCodec_Decode(t *testing.T) {
+	login, err := smgp30.NewLogin("sp", "pwd", 1).IEncode()
+	assert.Nil(t, err)
+	activeTest := smgp30.NewActiveTestPacket(2)
+
+	c := NewSMGPCodec()
+
+	// sticky packets
+	r := newMemReader(login, activeTest)
+	data, err := c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, login, data)
+	data, err = c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, activeTest, data)
+
+	// incomplete
+	for _, n := range []int{0, 3, smgp.HeaderLength, len(login) - 1} {
+		_, err = c.Decode(newMemReader(login[:n]))
+		assert.Equal(t, ErrPacketNotComplete, err, n)
+	}
+
+	_, err = c.Decode(newMemReader(lengthPrefixed(smgp.HeaderLength-1, smgp.HeaderLength)))
+	assert.True(t, errors.Is(err, ErrFrameTooSmall))
+	_, err = c.Decode(newMemReader(lengthPrefixed(DefaultMaxFrameLength+1, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+
+	_, err = NewSMGPCodec(WithMaxFrameLength(len(login) - 1)).Decode(newMemReader(login))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+}
+
+func TestSMGPCodec_DecodeBlocked(t *testing.T) {
+	login, err := smgp30.NewLogin("sp", "pwd", 1).IEncode()
+	assert.Nil(t, err)
+
+	c := NewSMGPCodec()
+
+	r := newMemReader(login, login)
+	for i := 0; i < 2; i++ {
+		data, err := c.DecodeBlocked(r)
+		assert.Nil(t, err)
+		assert.Equal(t, login, data)
+	}
+
+	_, err = c.DecodeBlocked(newMemReader(login[:len(login)-1]))
+	assert.NotNil(t, err)
+
+	_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(3, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooSmall))
+	_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(0xFFFFFFFF, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+}
@@ -0,0 +1,65 @@
+package codec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+func TestSMPPCodec_Decode(t *testing.T) {
+	enquireLink := smpp34.NewEnquireLinkReqBytes(1)
+	unbind := smpp34.NewUnBindBytes(2)
+
+	c := NewSMPPCodec()
+
+	r := newMemReader(enquireLink, unbind)
+	data, err := c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, enquireLink, data)
+	data, err = c.Decode(r)
+	assert.Nil(t, err)
+	assert.Equal(t, unbind, data)
+
+	for _, n := range []int{0, 3, len(enquireLink) - 1} {
+		_, err = c.Decode(newMemReader(enquireLink[:n]))
+		assert.Equal(t, ErrPacketNotComplete, err, n)
+	}
+
+	for _, l := range []uint32{0, 3, smpp.MinSMPPPacketLen - 1} {
+		_, err = c.Decode(newMemReader(lengthPrefixed(l, 8)))
+		assert.True(t, errors.Is(err, ErrFrameTooSmall), l)
+	}
+	_, err = c.Decode(newMemReader(lengthPrefixed(smpp.MAX_PDU_SIZE+1, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+
+	data, err = NewSMPPCodec(WithMaxFrameLength(smpp.MAX_PDU_SIZE + 1)).Decode(newMemReader(lengthPrefixed(smpp.MAX_PDU_SIZE+1, smpp.MAX_PDU_SIZE+1)))
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.MAX_PDU_SIZE+1, len(data))
+}
+
+func TestSMPPCodec_DecodeBlocked(t *testing.T) {
+	enquireLink := smpp34.NewEnquireLinkReqBytes(1)
+
+	c := NewSMPPCodec()
+
+	r := newMemReader(enquireLink, enquireLink)
+	for i := 0; i < 2; i++ {
+		data, err := c.DecodeBlocked(r)
+		assert.Nil(t, err)
+		assert.Equal(t, enquireLink, data)
+	}
+
+	_, err := c.DecodeBlocked(newMemReader(enquireLink[:len(enquireLink)-1]))
+	assert.NotNil(t, err)
+
+	for _, l := range []uint32{0, 3} {
+		_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(l, 8)))
+		assert.True(t, errors.Is(err, ErrFrameTooSmall), l)
+	}
+	_, err = c.DecodeBlocked(newMemReader(lengthPrefixed(0xFFFFFFFF, 8)))
+	assert.True(t, errors.Is(err, ErrFrameTooLarge))
+}
@@ -14,6 +14,18 @@ func DecodeSMPPContentSimple(ctx context.Context, dataCoding uint8, msgContent [
 	return DecodeSMPPCContentBytes(ctx, newContent, int(dataCoding))
 }
 
+// SMPPMessage is implemented by the SMPP PDUs carrying a message, e.g. smpp34.SubmitSm, smpp34.DeliverSm and smpp34.DataSm.
+type SMPPMessage interface {
+	// MessageContent returns short_message, or the message_payload TLV if short_message is empty.
+	MessageContent() []byte
+	GetDataCoding() uint8
+}
+
+// DecodeSMPPMessageSimple decodes the content of an SMPP PDU, which is taken from short_message or message_payload.
+func DecodeSMPPMessageSimple(ctx context.Context, msg SMPPMessage) (content []byte, err error) {
+	return DecodeSMPPContentSimple(ctx, msg.GetDataCoding(), msg.MessageContent())
+}
+
 func DecodeSGIPContentSimple(ctx context.Context, dataCoding uint8, msgContent []byte) (content []byte, err error) {
 	_, _, _, newContent, _ := ParseLongSmsContentBytes(msgContent)
 	return DecodeCMPPCContentBytes(ctx, newContent, dataCoding)
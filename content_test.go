@@ -68,6 +68,22 @@ func (s *ContentTestSuite) TestDecodeSMPPContentSimple() {
 	s.Equal("test", string(content))
 }
 
+type smppMessage struct {
+	dataCoding uint8
+	content    []byte
+}
+
+func (m smppMessage) MessageContent() []byte { return m.content }
+
+func (m smppMessage) GetDataCoding() uint8 { return m.dataCoding }
+
+// TestDecodeSMPPMessageSimple tests the DecodeSMPPMessageSimple function.
+func (s *ContentTestSuite) TestDecodeSMPPMessageSimple() {
+	content, err := DecodeSMPPMessageSimple(context.Background(), smppMessage{dataCoding: uint8(datacoding.SMPP_CODING_ASCII), content: []byte("payload")})
+	s.NoError(err)
+	s.Equal("payload", string(content))
+}
+
 // TestDecodeSGIPContentSimple tests the DecodeSGIPContentSimple function.
 func (s *ContentTestSuite) TestDecodeSGIPContentSimple() {
 	content, err := DecodeSGIPContentSimple(context.Background(), uint8(datacoding.CMPP_CODING_GBK), append([]byte{0x05, 0x00, 0x03, 0x01, 0x02, 0x01}, []byte{0xc4, 0xe3, 0xba, 0xc3}...))
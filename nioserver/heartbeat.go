@@ -0,0 +1,88 @@
+package nioserver
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// defaultMaxMissed is ActiveTestConfig.MaxMissed when it is not set.
+const defaultMaxMissed = 3
+
+// ActiveTestConfig configures the periodic active test of a BaseServer.
+type ActiveTestConfig struct {
+	// Interval is the idle time, measured from the last PDU received, after which an active test is sent.
+	// It is also the time to wait for a response before the next one. It is required.
+	Interval time.Duration
+
+	// MaxMissed is the number of active tests sent without receiving any PDU after which the connection
+	// is closed. Defaults to 3.
+	MaxMissed int
+
+	// Builder encodes an active test request with the given sequence ID, e.g. smpp34.NewEnquireLinkReqBytes,
+	// cmpp20.NewActiveTestPacket or smgp30.NewActiveTestPacket. It is required.
+	Builder func(seqID uint32) []byte
+}
+
+// WithActiveTest enables the periodic active test: an active test is sent on a connection which has not
+// received any PDU for ActiveTestConfig.Interval, and the connection is closed after ActiveTestConfig.MaxMissed
+// active tests without a PDU received. Any PDU received resets the missed count, see IActiveTest.
+// The responses to the active tests are not passed to HandleFunc.
+// With WithSession, active tests are only sent on bound sessions.
+func WithActiveTest[T any](cfg ActiveTestConfig) ServerOption[T] {
+	return func(s *BaseServer[T]) {
+		s.activeTest = &cfg
+	}
+}
+
+// onReceive records the time a PDU is received and resets the missed active test count.
+func (m *muxConn[T]) onReceive() {
+	atomic.StoreInt64(&m.lastRead, time.Now().UnixNano())
+	m.OnReceiveActiveTest()
+}
+
+// startActiveTest starts the active test timer of a new connection.
+func (s *BaseServer[T]) startActiveTest(ctx context.Context, m *muxConn[T]) {
+	atomic.StoreInt64(&m.lastRead, time.Now().UnixNano())
+	// the timer is only started after it is stored, checkActiveTest resets it
+	m.activeTestTimer = time.AfterFunc(time.Hour, func() {
+		s.checkActiveTest(ctx, m)
+	})
+	m.activeTestTimer.Stop()
+	m.activeTestTimer.Reset(s.activeTest.Interval)
+}
+
+// stopActiveTest stops the active test timer of a closed connection.
+func (s *BaseServer[T]) stopActiveTest(m *muxConn[T]) {
+	if m.activeTestTimer != nil {
+		m.activeTestTimer.Stop()
+	}
+}
+
+// checkActiveTest runs when the active test timer fires, it sends an active test if the connection is idle,
+// or closes the connection if too many active tests are missed.
+func (s *BaseServer[T]) checkActiveTest(ctx context.Context, m *muxConn[T]) {
+	if !m.conn.IsActive() {
+		return
+	}
+	interval := s.activeTest.Interval
+	idle := time.Since(time.Unix(0, atomic.LoadInt64(&m.lastRead)))
+	if idle < interval {
+		m.activeTestTimer.Reset(interval - idle)
+		return
+	}
+
+	if m.NoActiveTestCount() >= s.activeTest.MaxMissed {
+		s.logger.CtxWarnf(ctx, "[ActiveTest] %s missed %d active tests, closing", m.RemoteAddr(), m.NoActiveTestCount())
+		_ = m.Close()
+		return
+	}
+	if s.session == nil || m.sessionState().IsBound() {
+		seq := m.NextSequenceID()
+		// the response is dropped instead of being passed to HandleFunc
+		m.requests.addActiveTest(seq, s.activeTest.MaxMissed)
+		atomic.AddUint32(&m.noActiveTest, 1)
+		m.AsyncWrite(ctx, s.activeTest.Builder(seq))
+	}
+	m.activeTestTimer.Reset(interval)
+}
@@ -0,0 +1,91 @@
+package nioserver
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+func TestWithActiveTest(t *testing.T) {
+	var handled int32 // active test responses passed to HandleFunc
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			if _, ok := p.(*smpp34.EnquireLinkResp); ok {
+				atomic.AddInt32(&handled, 1)
+			}
+			if resp := p.GenEmptyResponse(); resp != nil {
+				return resp.IEncode()
+			}
+			return nil, nil
+		}),
+		WithActiveTest[string](ActiveTestConfig{
+			Interval:  100 * time.Millisecond,
+			MaxMissed: 2,
+			Builder:   smpp34.NewEnquireLinkReqBytes,
+		}),
+	)
+
+	// the peer answers the active tests
+	esme := dialServer(t, addr)
+	for i := 0; i < 4; i++ {
+		p := esme.read(t)
+		if !assert.IsType(t, &smpp34.EnquireLink{}, p) {
+			return
+		}
+		esme.send(t, &smpp34.EnquireLinkResp{Header: smpp.Header{ID: smpp.ENQUIRE_LINK_RESP, Sequence: p.GetSequenceID()}})
+	}
+	// an unknown response is still passed to HandleFunc
+	esme.send(t, &smpp34.EnquireLinkResp{Header: smpp.Header{ID: smpp.ENQUIRE_LINK_RESP, Sequence: 1000}})
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handled))
+
+	// the peer keeps sending, no active test is needed
+	esme = dialServer(t, addr)
+	for i := uint32(1); i <= 6; i++ {
+		esme.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: i}})
+		assert.IsType(t, &smpp34.SubmitSmResp{}, esme.read(t))
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// the peer does not answer, closed after 2 missed active tests
+	esme = dialServer(t, addr)
+	start := time.Now()
+	assert.IsType(t, &smpp34.EnquireLink{}, esme.read(t))
+	assert.IsType(t, &smpp34.EnquireLink{}, esme.read(t))
+	assert.Nil(t, esme.read(t))
+	assert.True(t, time.Since(start) >= 300*time.Millisecond)
+}
+
+func TestWithActiveTest_Session(t *testing.T) {
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			return p.GenEmptyResponse().IEncode()
+		}),
+		WithSession[string](SessionConfig{Policy: SMPPSessionPolicy{}}),
+		WithActiveTest[string](ActiveTestConfig{Interval: 50 * time.Millisecond, Builder: smpp34.NewEnquireLinkReqBytes}),
+	)
+
+	// no active test before bind, the first PDU received is the bind response
+	esme := dialServer(t, addr)
+	time.Sleep(100 * time.Millisecond)
+	esme.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}})
+	assert.IsType(t, &smpp34.BindResp{}, esme.read(t))
+	assert.IsType(t, &smpp34.EnquireLink{}, esme.read(t))
+}
+
+func TestNewBaseServer_ActiveTest(t *testing.T) {
+	handle := WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) { return nil, nil })
+	unpack := WithUnpackFunc[string](unpackSMPP)
+
+	_, err := NewBaseServer[string]("tcp", "127.0.0.1:0", unpack, handle, WithActiveTest[string](ActiveTestConfig{Builder: smpp34.NewEnquireLinkReqBytes}))
+	assert.NotNil(t, err)
+	_, err = NewBaseServer[string]("tcp", "127.0.0.1:0", unpack, handle, WithActiveTest[string](ActiveTestConfig{Interval: time.Second}))
+	assert.NotNil(t, err)
+}
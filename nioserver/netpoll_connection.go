@@ -4,9 +4,12 @@ import (
 	"context"
 	"io"
 	"sync/atomic"
+	"time"
 
 	"github.com/cloudwego/netpoll"
 	"github.com/cloudwego/netpoll/mux"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
 )
 
 // IActiveTest defines the interface for connection active testing.
@@ -38,6 +41,23 @@ type ISMSConn[T any] interface {
 	SetBizData(data T)
 }
 
+// ISMSRequester is implemented by the connections of BaseServer in addition to ISMSConn.
+// It is a separate interface so that other implementations of ISMSConn keep compiling; type-assert for it:
+//
+//	if r, ok := conn.(ISMSRequester); ok {
+//		resp, err := r.Request(ctx, pdu)
+//	}
+type ISMSRequester interface {
+	// IsActive reports whether the connection is open.
+	IsActive() bool
+
+	// Request sends p with the next sequence ID and waits for its response, see RequestConfig.
+	// It returns ErrConnClosed if the connection is closed before the response is received.
+	Request(ctx context.Context, p protocol.PDU) (resp protocol.PDU, err error)
+	// InFlight returns the number of Requests waiting for a response.
+	InFlight() int
+}
+
 // connkey is a private type for context key to avoid collisions.
 type connkey struct{}
 
@@ -47,22 +67,32 @@ var ctxkey connkey
 // muxConn implements ISMSConn based on netpoll and mux.
 // It manages connection state, write queue, sequence ID, and business data.
 type muxConn[T any] struct {
-	conn          netpoll.Connection
-	wqueue        *mux.ShardQueue // Sharded queue for write operations
-	sequenceIDGen uint32          // Sequence ID generator
-	noActiveTest  uint32          // Counter for missed active test responses
-	remoteAddr    string          // Cached remote address string
-	bizData       atomic.Value    // Stores business data of type T atomically
+	lastRead        int64 // UnixNano of the last PDU received, first for 64-bit atomic alignment
+	conn            netpoll.Connection
+	wqueue          *mux.ShardQueue  // Sharded queue for write operations
+	sequenceIDGen   uint32           // Sequence ID generator
+	noActiveTest    uint32           // Counter for missed active test responses
+	remoteAddr      string           // Cached remote address string
+	bizData         atomic.Value     // Stores business data of type T atomically
+	outbind         uint32           // SMPP outbind state, see WithSMPPOutbind
+	session         uint32           // SessionState, see WithSession
+	binding         uint32           // 1 while a bind request is being handled
+	bindDone        atomic.Value     // chan struct{} closed once the last bind received is handled, see bindBarrier
+	hasSession      bool             // Whether the session layer is enabled
+	bindTimer       *time.Timer      // Closes the connection if not bound in time, nil if disabled
+	requests        *pendingRequests // Requests waiting for a response, see Request
+	activeTestTimer *time.Timer      // Sends active tests on an idle connection, nil if disabled
 }
 
 // newSvrMuxConn creates a new server-side muxConn instance.
-func newSvrMuxConn[T any](conn netpoll.Connection) *muxConn[T] {
+func newSvrMuxConn[T any](conn netpoll.Connection, request RequestConfig) *muxConn[T] {
 	mc := &muxConn[T]{}
 	mc.conn = conn
 	mc.remoteAddr = conn.RemoteAddr().String()
 	mc.wqueue = mux.NewShardQueue(mux.ShardSize, conn)
 	mc.sequenceIDGen = 0
 	mc.noActiveTest = 0
+	mc.requests = newPendingRequests(request)
 	// Initialize bizData with the zero value of T to prevent panic on Load.
 	var zero T
 	mc.bizData.Store(zero)
@@ -109,7 +139,7 @@ func (m *muxConn[T]) SetBizData(data T) {
 	m.bizData.Store(data)
 }
 
-// NoActiveTestCount atomically increments and returns the count of consecutive missed active test responses.
+// NoActiveTestCount atomically returns the count of consecutive missed active test responses.
 func (m *muxConn[T]) NoActiveTestCount() int {
 	return int(atomic.LoadUint32(&m.noActiveTest))
 }
@@ -120,6 +150,11 @@ func (m *muxConn[T]) OnReceiveActiveTest() {
 	atomic.StoreUint32(&m.noActiveTest, 0)
 }
 
+// IsActive reports whether the underlying connection is open.
+func (m *muxConn[T]) IsActive() bool {
+	return m.conn.IsActive()
+}
+
 func (m *muxConn[T]) Close() error {
 	// _ = m.wqueue.Close() // wqueue will be closed by BaseServer.OnCloseConn
 	return m.conn.Close()
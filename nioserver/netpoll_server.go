@@ -2,9 +2,12 @@ package nioserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -15,7 +18,18 @@ import (
 	protocol "github.com/hujm2023/go-sms-protocol"
 )
 
+// ErrServerClosed is returned by BaseServer.Start after BaseServer.Shutdown is called.
+var ErrServerClosed = errors.New("server closed")
+
+// Bits of BaseServer.state.
+const (
+	stateStarted int32 = 1 << iota // Start is called
+	stateClosing                   // Shutdown is called
+)
+
 // UnpackFunc defines the function signature for unpacking data from the reader into a PDU.
+// Any error returned closes the connection, e.g. codec.ErrFrameTooSmall or codec.ErrFrameTooLarge
+// for a malformed length field, after which the stream cannot be resynchronized.
 type UnpackFunc func(ctx context.Context, r netpoll.Reader) (protocol.PDU, error)
 
 // HandleFunc defines the function signature for handling business logic for a PDU.
@@ -79,22 +93,43 @@ func WithOnCloseFunc[T any](f OnCloseFunc) ServerOption[T] {
 	}
 }
 
+// WithUnbindBuilder sets the builder of the unbind PDU which BaseServer.Shutdown sends to each bound session,
+// e.g. smpp34.NewUnBindBytes, cmpp20.NewTerminatePacket or smgp30.NewExitPacket.
+// Without it, Shutdown closes the connections without unbinding.
+func WithUnbindBuilder[T any](f func(seqID uint32) []byte) ServerOption[T] {
+	return func(s *BaseServer[T]) {
+		s.unbind = f
+	}
+}
+
 // BaseServer is a generic TCP server implementation based on netpoll.
 // It handles connection management, data unpacking, business logic dispatching, and graceful shutdown.
 type BaseServer[T any] struct {
 	network, address string           // Network type and address to listen on
 	options          []netpoll.Option // Netpoll configuration options
 
-	unpackBlock        UnpackFunc     // Data unpack function
-	handle             HandleFunc     // Business handler function
-	refreshCtxWhenRead RefreshCtxFunc // Context refresh function before read
-	closeFunc          OnCloseFunc    // Connection close callback
+	unpackBlock        UnpackFunc                // Data unpack function
+	handle             HandleFunc                // Business handler function
+	refreshCtxWhenRead RefreshCtxFunc            // Context refresh function before read
+	closeFunc          OnCloseFunc               // Connection close callback
+	outbind            *OutbindConfig            // SMPP outbind handling, nil if disabled
+	session            *SessionConfig            // Session layer, nil if disabled
+	activeTest         *ActiveTestConfig         // Periodic active test, nil if disabled
+	request            RequestConfig             // Window and timeout of ISMSRequester.Request
+	registry           *Registry[T]              // Connections unregistered on close, nil if disabled
+	unbind             func(seqID uint32) []byte // Unbind PDU builder used by Shutdown, nil if disabled
 
 	listener  netpoll.Listener  // Network listener
 	eventLoop netpoll.EventLoop // Netpoll event loop
 
 	workerpool gopool.Pool     // Goroutine pool for business logic
 	logger     hlog.FullLogger // Logger instance
+
+	conns    sync.Map      // Live connections, *muxConn[T] -> struct{}
+	handling int64         // Number of PDUs being handled
+	state    int32         // stateStarted and stateClosing bits, both set by CAS
+	served   chan struct{} // Closed when the event loop exits
+	serveErr error         // Error of the event loop, valid after served is closed
 }
 
 // NewBaseServer creates and initializes a new BaseServer instance.
@@ -104,6 +139,7 @@ func NewBaseServer[T any](network, address string, opts ...ServerOption[T]) (*Ba
 		network: network,
 		address: address,
 		logger:  hlog.DefaultLogger(),
+		served:  make(chan struct{}),
 	}
 	for _, opt := range opts {
 		opt(server)
@@ -114,6 +150,32 @@ func NewBaseServer[T any](network, address string, opts ...ServerOption[T]) (*Ba
 	if server.handle == nil {
 		return nil, fmt.Errorf("handle func is nil")
 	}
+	if server.session != nil {
+		if server.session.Policy == nil {
+			return nil, fmt.Errorf("session policy is nil")
+		}
+		server.handle = server.handleSession(server.handle)
+	}
+	if server.request.Window <= 0 {
+		server.request.Window = defaultRequestWindow
+	}
+	if server.request.Timeout <= 0 {
+		server.request.Timeout = defaultRequestTimeout
+	}
+	if server.activeTest != nil {
+		if server.activeTest.Interval <= 0 {
+			return nil, fmt.Errorf("active test interval must be positive")
+		}
+		if server.activeTest.Builder == nil {
+			return nil, fmt.Errorf("active test builder is nil")
+		}
+		if server.activeTest.MaxMissed <= 0 {
+			server.activeTest.MaxMissed = defaultMaxMissed
+		}
+	}
+	if server.outbind != nil {
+		server.handle = server.handleOutbind(server.handle)
+	}
 
 	onPrepare := func(conn netpoll.Connection) context.Context {
 		return server.OnOpenConn(conn)
@@ -164,33 +226,174 @@ func NewBaseServer[T any](network, address string, opts ...ServerOption[T]) (*Ba
 
 // Serve starts the server's event loop and begins accepting connections.
 // It blocks until a termination signal (SIGINT, SIGTERM) is received or an error occurs.
-// 'wait' specifies the timeout duration for graceful shutdown.
+// 'wait' specifies the timeout duration for graceful shutdown, see Shutdown.
 func (s *BaseServer[T]) Serve(wait time.Duration) {
-	errChan := make(chan error)
-	go func() {
-		errChan <- s.eventLoop.Serve(s.listener)
-	}()
+	if err := s.Start(); err != nil {
+		panic(err)
+	}
 
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
 
-	ctx, cancel := context.WithTimeout(context.Background(), wait)
-	defer cancel()
 	select {
 	case <-sig:
 		s.logger.Noticef("received signal, exiting...")
-		if err := s.eventLoop.Shutdown(ctx); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), wait)
+		defer cancel()
+		if err := s.Shutdown(ctx); err != nil {
 			s.logger.Errorf("shutdown error: %v", err)
 			return
 		}
-	case err := <-errChan:
-		if err != nil {
-			panic(err)
+	case <-s.served:
+		if s.serveErr != nil {
+			panic(s.serveErr)
 		}
 	}
 	s.logger.Notice("!!!exited done.")
 }
 
+// Start starts the server's event loop in the background and returns immediately.
+// An error of the event loop is logged and returned by Shutdown.
+func (s *BaseServer[T]) Start() error {
+	for {
+		state := atomic.LoadInt32(&s.state)
+		if state&stateClosing != 0 {
+			return ErrServerClosed
+		}
+		if state&stateStarted != 0 {
+			return fmt.Errorf("server already started")
+		}
+		if atomic.CompareAndSwapInt32(&s.state, state, state|stateStarted) {
+			break
+		}
+	}
+	go func() {
+		defer close(s.served)
+		if err := s.eventLoop.Serve(s.listener); err != nil {
+			s.logger.Errorf("serve error: %v", err)
+			s.serveErr = err
+		}
+	}()
+	return nil
+}
+
+// Shutdown gracefully shuts down the server:
+//  1. new connections are closed once accepted;
+//  2. each bound session is sent the unbind PDU of WithUnbindBuilder and its response is waited for,
+//     with WithSession only the bound sessions, otherwise all the connections;
+//  3. the PDUs being handled by HandleFunc are waited for, new requests are not passed to HandleFunc any more:
+//     with WithSession they are rejected as in SessionUnbinding, otherwise they are dropped;
+//  4. the pending writes are flushed, all the connections and the listener are closed.
+//
+// If ctx is done before, the remaining steps are skipped, the connections are still closed and ctx.Err() is returned.
+func (s *BaseServer[T]) Shutdown(ctx context.Context) error {
+	var state int32
+	for {
+		state = atomic.LoadInt32(&s.state)
+		if state&stateClosing != 0 {
+			return ErrServerClosed
+		}
+		if atomic.CompareAndSwapInt32(&s.state, state, state|stateClosing) {
+			break
+		}
+	}
+
+	if s.unbind != nil {
+		s.unbindAll(ctx)
+	}
+	err := s.waitHandling(ctx)
+
+	s.closeAll(ctx)
+	if state&stateStarted == 0 {
+		_ = s.listener.Close()
+		return err
+	}
+	if e := s.eventLoop.Shutdown(ctx); err == nil {
+		err = e
+	}
+	select {
+	case <-s.served:
+		if err == nil {
+			err = s.serveErr
+		}
+	case <-ctx.Done():
+		if err == nil {
+			err = ctx.Err()
+		}
+	}
+	return err
+}
+
+// unbindAll sends the unbind PDU to each bound session and waits for the responses.
+func (s *BaseServer[T]) unbindAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	s.conns.Range(func(key, _ any) bool {
+		m := key.(*muxConn[T])
+		if s.session != nil {
+			state := m.sessionState()
+			if !state.IsBound() || !s.setSessionState(ctx, m, state, SessionUnbinding) {
+				return true
+			}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, m.requests.timeout)
+			defer cancel()
+			seq := m.NextSequenceID()
+			if _, err := m.writeAndWait(ctx, seq, s.unbind(seq)); err != nil {
+				s.logger.CtxWarnf(ctx, "[Shutdown] unbind %s error: %v", m.RemoteAddr(), err)
+			}
+		}()
+		return true
+	})
+	wg.Wait()
+}
+
+// closeAll closes all the connections, each one once its pending writes are flushed or ctx is done.
+func (s *BaseServer[T]) closeAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	s.conns.Range(func(key, _ any) bool {
+		m := key.(*muxConn[T])
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			flushed := make(chan struct{})
+			go func() {
+				_ = m.wqueue.Close()
+				close(flushed)
+			}()
+			select {
+			case <-flushed:
+			case <-ctx.Done():
+			}
+			_ = m.Close()
+		}()
+		return true
+	})
+	wg.Wait()
+}
+
+// closing reports whether Shutdown is called.
+func (s *BaseServer[T]) closing() bool {
+	return atomic.LoadInt32(&s.state)&stateClosing != 0
+}
+
+// waitHandling waits until no PDU is being handled.
+func (s *BaseServer[T]) waitHandling(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for atomic.LoadInt64(&s.handling) > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 // DispatchRequest is the netpoll request dispatch callback.
 // It reads data, unpacks it using UnpackFunc, and submits the PDU to the worker pool for handling by HandleFunc.
 func (s *BaseServer[T]) DispatchRequest(ctx context.Context, conn netpoll.Connection) error {
@@ -216,10 +419,36 @@ func (s *BaseServer[T]) DispatchRequest(ctx context.Context, conn netpoll.Connec
 	if err != nil {
 		return err
 	}
+	if m, ok := mc.(*muxConn[T]); ok {
+		m.onReceive()
+		// the response of a Request goes to its waiter
+		if isResponse(rPDU) && m.requests.deliver(rPDU) {
+			return nil
+		}
+	}
+
+	// counted before the check, so waitHandling of a concurrent Shutdown does not miss it
+	atomic.AddInt64(&s.handling, 1)
+	if !isResponse(rPDU) && s.closing() {
+		atomic.AddInt64(&s.handling, -1)
+		s.rejectClosing(ctx, mc, rPDU)
+		return nil
+	}
+
+	// PDUs received after a bind wait for it, see bindBarrier
+	var wait, done func()
+	if m, ok := mc.(*muxConn[T]); ok && m.hasSession {
+		wait, done = s.bindBarrier(m, rPDU)
+	}
 
 	// 这里必须使用异步goroutine去处理，不能阻塞整个eventloop
 	// 在这个goroutine中，不应该在对conn进行读写
 	s.workerpool.Go(func() {
+		defer atomic.AddInt64(&s.handling, -1)
+		if wait != nil {
+			wait()
+			defer done()
+		}
 		// 处理这个PDU
 		respData, err := s.handle(ctx, rPDU)
 		// 有数据要返回，先写，再判断是否要关闭
@@ -239,14 +468,42 @@ func (s *BaseServer[T]) DispatchRequest(ctx context.Context, conn netpoll.Connec
 	return nil
 }
 
+// rejectClosing answers a request received after Shutdown is called instead of passing it to HandleFunc.
+// With WithSession it is rejected as in SessionUnbinding, otherwise it is dropped.
+func (s *BaseServer[T]) rejectClosing(ctx context.Context, mc ISMSConn[T], p protocol.PDU) {
+	if s.session == nil {
+		s.logger.CtxWarnf(ctx, "[Shutdown] %s from %s dropped, server is shutting down", p.GetCommand(), mc.RemoteAddr())
+		return
+	}
+	resp, err := s.rejectSession(ctx, s.session.Policy, SessionUnbinding, p)
+	if len(resp) > 0 {
+		mc.AsyncWrite(ctx, resp)
+	}
+	if err != nil {
+		_ = mc.Close()
+	}
+}
+
 // OnOpenConn is the netpoll connection established callback (set via WithOnPrepare).
 // It creates a muxConn instance and populates the context with it.
 func (s *BaseServer[T]) OnOpenConn(conn netpoll.Connection) context.Context {
 	s.logger.Noticef("[OnOpenConn] %s connected", conn.RemoteAddr().String())
 	// 创建泛型 muxConn
-	mc := newSvrMuxConn[T](conn)
+	mc := newSvrMuxConn[T](conn, s.request)
 	// 使用泛型 fillCtx
 	ctx := fillCtx[T](context.Background(), mc)
+	if s.closing() {
+		s.logger.Noticef("[OnOpenConn] %s closed, server is shutting down", conn.RemoteAddr().String())
+		_ = conn.Close()
+		return ctx
+	}
+	s.conns.Store(mc, struct{}{})
+	if s.session != nil {
+		s.openSession(ctx, mc)
+	}
+	if s.activeTest != nil {
+		s.startActiveTest(ctx, mc)
+	}
 	return ctx
 }
 
@@ -266,8 +523,20 @@ func (s *BaseServer[T]) OnCloseConn(ctx context.Context, conn netpoll.Connection
 		return
 	}
 
+	if s.registry != nil {
+		s.registry.Unregister(mc)
+	}
+
 	// 需要类型断言来访问内部的 wqueue
 	if muxConnInst, ok := mc.(*muxConn[T]); ok {
+		s.conns.Delete(muxConnInst)
+		if s.session != nil {
+			s.closeSession(ctx, muxConnInst)
+		}
+		if s.activeTest != nil {
+			s.stopActiveTest(muxConnInst)
+		}
+		muxConnInst.requests.close()
 		_ = muxConnInst.wqueue.Close()
 	}
 }
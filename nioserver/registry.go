@@ -0,0 +1,217 @@
+package nioserver
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrTooManyConns is returned by Registry.Register when the account already has RegistryConfig.MaxConnsPerAccount connections.
+var ErrTooManyConns = errors.New("too many connections of the account")
+
+// SelectPolicy decides which connection of an account Registry.Pick returns.
+type SelectPolicy int
+
+const (
+	SelectRoundRobin    SelectPolicy = iota // the connections in turn
+	SelectLeastInFlight                     // the connection with the fewest requests waiting for a response, see ISMSRequester.InFlight
+)
+
+// RegistryConfig configures a Registry.
+type RegistryConfig struct {
+	// MaxConnsPerAccount is the max number of connections of an account, zero means no limit.
+	MaxConnsPerAccount int
+
+	// Select is the policy of Pick, defaults to SelectRoundRobin.
+	Select SelectPolicy
+}
+
+// accountConns is the connections of an account.
+type accountConns[T any] struct {
+	conns []ISMSConn[T]
+	next  uint32 // round-robin counter
+}
+
+// Registry is a concurrent registry of the live connections keyed by account, e.g. to find the connection
+// to push an MO or a status report to. A connection is registered by HandleFunc after the bind is accepted,
+// and unregistered when it is closed if the Registry is passed to WithRegistry.
+type Registry[T any] struct {
+	cfg RegistryConfig
+
+	mu       sync.RWMutex
+	accounts map[string]*accountConns[T]
+	conns    map[ISMSConn[T]]string // connection -> account
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry[T any](cfg RegistryConfig) *Registry[T] {
+	return &Registry[T]{
+		cfg:      cfg,
+		accounts: make(map[string]*accountConns[T]),
+		conns:    make(map[ISMSConn[T]]string),
+	}
+}
+
+// WithRegistry unregisters a connection from r when it is closed. Connections are not registered
+// automatically: HandleFunc calls r.Register with the account of the bind once it is accepted,
+// the conn is returned by GetCtxConn.
+func WithRegistry[T any](r *Registry[T]) ServerOption[T] {
+	return func(s *BaseServer[T]) {
+		s.registry = r
+	}
+}
+
+// Register adds conn to account. It returns ErrTooManyConns if the account is full, and ErrConnClosed
+// if conn is an ISMSRequester which is already closed, e.g. the peer disconnected while its bind was handled.
+// Registering a connection which is already registered moves it to account.
+func (r *Registry[T]) Register(account string, conn ISMSConn[T]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// checked under r.mu: a conn closed after the check is unregistered by WithRegistry once Register returns
+	if rc, ok := conn.(ISMSRequester); ok && !rc.IsActive() {
+		return ErrConnClosed
+	}
+
+	old, registered := r.conns[conn]
+	if registered && old == account {
+		return nil
+	}
+	ac, ok := r.accounts[account]
+	if ok && r.cfg.MaxConnsPerAccount > 0 && len(ac.conns) >= r.cfg.MaxConnsPerAccount {
+		return ErrTooManyConns
+	}
+	if registered {
+		r.remove(old, conn)
+	}
+	if !ok {
+		ac = &accountConns[T]{}
+		r.accounts[account] = ac
+	}
+	ac.conns = append(ac.conns, conn)
+	r.conns[conn] = account
+	return nil
+}
+
+// Unregister removes conn, it reports false if conn is not registered.
+func (r *Registry[T]) Unregister(conn ISMSConn[T]) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	account, ok := r.conns[conn]
+	if !ok {
+		return false
+	}
+	r.remove(account, conn)
+	return true
+}
+
+// remove deletes conn from account, r.mu must be held.
+func (r *Registry[T]) remove(account string, conn ISMSConn[T]) {
+	delete(r.conns, conn)
+	ac := r.accounts[account]
+	if ac == nil {
+		return
+	}
+	// copy on remove, slices returned by Get are not changed
+	conns := make([]ISMSConn[T], 0, len(ac.conns))
+	for _, c := range ac.conns {
+		if c != conn {
+			conns = append(conns, c)
+		}
+	}
+	if len(conns) == 0 {
+		delete(r.accounts, account)
+		return
+	}
+	ac.conns = conns
+}
+
+// Account returns the account conn is registered to.
+func (r *Registry[T]) Account(conn ISMSConn[T]) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	account, ok := r.conns[conn]
+	return account, ok
+}
+
+// Get returns the connections of account, the slice must not be modified.
+func (r *Registry[T]) Get(account string) []ISMSConn[T] {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ac, ok := r.accounts[account]
+	if !ok {
+		return nil
+	}
+	return ac.conns
+}
+
+// Count returns the number of connections of account.
+func (r *Registry[T]) Count(account string) int {
+	return len(r.Get(account))
+}
+
+// Len returns the number of registered connections.
+func (r *Registry[T]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.conns)
+}
+
+// Pick selects a connection of account by RegistryConfig.Select, ok is false if the account has no connection.
+func (r *Registry[T]) Pick(account string) (conn ISMSConn[T], ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ac, ok := r.accounts[account]
+	if !ok || len(ac.conns) == 0 {
+		return nil, false
+	}
+
+	if r.cfg.Select == SelectLeastInFlight {
+		conn = ac.conns[0]
+		least := inFlight(conn)
+		for _, c := range ac.conns[1:] {
+			if n := inFlight(c); n < least {
+				conn, least = c, n
+			}
+		}
+		return conn, true
+	}
+	n := atomic.AddUint32(&ac.next, 1)
+	return ac.conns[(n-1)%uint32(len(ac.conns))], true
+}
+
+// inFlight returns the InFlight of conn, or 0 if conn is not an ISMSRequester.
+func inFlight[T any](conn ISMSConn[T]) int {
+	if rc, ok := conn.(ISMSRequester); ok {
+		return rc.InFlight()
+	}
+	return 0
+}
+
+// Range calls f for each registered connection until f returns false.
+// f runs on a snapshot, it may Register, Unregister or Close.
+func (r *Registry[T]) Range(f func(account string, conn ISMSConn[T]) bool) {
+	r.mu.RLock()
+	snapshot := make(map[string][]ISMSConn[T], len(r.accounts))
+	for account, ac := range r.accounts {
+		snapshot[account] = ac.conns
+	}
+	r.mu.RUnlock()
+
+	for account, conns := range snapshot {
+		for _, conn := range conns {
+			if !f(account, conn) {
+				return
+			}
+		}
+	}
+}
+
+// CloseAll closes all the registered connections, they are unregistered by WithRegistry once closed.
+func (r *Registry[T]) CloseAll() {
+	r.Range(func(_ string, conn ISMSConn[T]) bool {
+		_ = conn.Close()
+		return true
+	})
+}
@@ -0,0 +1,159 @@
+package nioserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// fakeConn is an ISMSConn and ISMSRequester with a fixed InFlight.
+type fakeConn struct {
+	ISMSConn[string]
+	inFlight int
+	closed   bool
+}
+
+func (c *fakeConn) InFlight() int { return c.inFlight }
+
+func (c *fakeConn) IsActive() bool { return !c.closed }
+
+func (c *fakeConn) Request(context.Context, protocol.PDU) (protocol.PDU, error) {
+	return nil, ErrConnClosed
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry[string](RegistryConfig{MaxConnsPerAccount: 2})
+	c1, c2, c3 := &fakeConn{}, &fakeConn{}, &fakeConn{}
+
+	assert.Nil(t, r.Register("sp1", c1))
+	assert.Nil(t, r.Register("sp1", c1))
+	assert.Nil(t, r.Register("sp1", c2))
+	assert.ErrorIs(t, r.Register("sp1", c3), ErrTooManyConns)
+	assert.Nil(t, r.Register("sp2", c3))
+	assert.Equal(t, 2, r.Count("sp1"))
+	assert.Equal(t, 3, r.Len())
+	account, ok := r.Account(c3)
+	assert.True(t, ok)
+	assert.Equal(t, "sp2", account)
+
+	// round robin
+	picked := map[ISMSConn[string]]int{}
+	for i := 0; i < 4; i++ {
+		conn, ok := r.Pick("sp1")
+		assert.True(t, ok)
+		picked[conn]++
+	}
+	assert.Equal(t, map[ISMSConn[string]]int{c1: 2, c2: 2}, picked)
+	_, ok = r.Pick("sp3")
+	assert.False(t, ok)
+
+	// c3 stays in sp2 if sp1 is full, moving c3 frees sp2
+	assert.ErrorIs(t, r.Register("sp1", c3), ErrTooManyConns)
+	assert.Equal(t, 1, r.Count("sp2"))
+	assert.True(t, r.Unregister(c2))
+	assert.False(t, r.Unregister(c2))
+	assert.Nil(t, r.Register("sp1", c3))
+	assert.Equal(t, 0, r.Count("sp2"))
+	assert.Equal(t, []ISMSConn[string]{c1, c3}, r.Get("sp1"))
+
+	n := 0
+	r.Range(func(account string, conn ISMSConn[string]) bool {
+		assert.Equal(t, "sp1", account)
+		n++
+		return false
+	})
+	assert.Equal(t, 1, n)
+
+	r.CloseAll()
+	assert.True(t, c1.closed)
+	assert.False(t, c2.closed)
+	assert.True(t, c3.closed)
+}
+
+func TestRegistry_Closed(t *testing.T) {
+	r := NewRegistry[string](RegistryConfig{MaxConnsPerAccount: 1})
+	c := &fakeConn{}
+	_ = c.Close()
+	assert.ErrorIs(t, r.Register("sp", c), ErrConnClosed)
+	assert.Equal(t, 0, r.Len())
+	assert.Nil(t, r.Register("sp", &fakeConn{}))
+}
+
+func TestRegistry_LeastInFlight(t *testing.T) {
+	r := NewRegistry[string](RegistryConfig{Select: SelectLeastInFlight})
+	c1, c2, c3 := &fakeConn{inFlight: 3}, &fakeConn{inFlight: 1}, &fakeConn{inFlight: 2}
+	for _, c := range []*fakeConn{c1, c2, c3} {
+		assert.Nil(t, r.Register("sp", c))
+	}
+	conn, ok := r.Pick("sp")
+	assert.True(t, ok)
+	assert.Equal(t, c2, conn)
+
+	c2.inFlight = 5
+	conn, _ = r.Pick("sp")
+	assert.Equal(t, c3, conn)
+
+	// an ISMSConn without InFlight counts as 0
+	c4 := &struct{ ISMSConn[string] }{}
+	assert.Nil(t, r.Register("sp", c4))
+	conn, _ = r.Pick("sp")
+	assert.Equal(t, c4, conn)
+}
+
+func TestWithRegistry(t *testing.T) {
+	r := NewRegistry[string](RegistryConfig{MaxConnsPerAccount: 1})
+	registered := make(chan error, 1)
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			bind, ok := p.(*smpp34.Bind)
+			if !ok {
+				return nil, nil
+			}
+			resp := p.GenEmptyResponse().(*smpp34.BindResp)
+			conn, _ := GetCtxConn[string](ctx)
+			if bind.SystemID == "gone" {
+				// the peer disconnects while its bind is handled
+				_ = conn.Close()
+				registered <- r.Register(bind.SystemID, conn)
+			}
+			if err := r.Register(bind.SystemID, conn); err != nil {
+				resp.Header.Status = smpp.ESME_RBINDFAIL
+			}
+			return resp.IEncode()
+		}),
+		WithRegistry[string](r),
+	)
+
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}, SystemID: "sp"})
+	if resp := esme.read(t); assert.IsType(t, &smpp34.BindResp{}, resp) {
+		assert.Equal(t, smpp.ESME_ROK, resp.(*smpp34.BindResp).Header.Status)
+	}
+	assert.Equal(t, 1, r.Count("sp"))
+
+	esme2 := dialServer(t, addr)
+	esme2.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}, SystemID: "sp"})
+	if resp := esme2.read(t); assert.IsType(t, &smpp34.BindResp{}, resp) {
+		assert.Equal(t, smpp.ESME_RBINDFAIL, resp.(*smpp34.BindResp).Header.Status)
+	}
+
+	_ = esme.Close()
+	assert.Eventually(t, func() bool { return r.Len() == 0 }, time.Second, 10*time.Millisecond)
+
+	esme3 := dialServer(t, addr)
+	esme3.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}, SystemID: "gone"})
+	assert.ErrorIs(t, <-registered, ErrConnClosed)
+	assert.Nil(t, esme3.read(t))
+	assert.Equal(t, 0, r.Len())
+}
@@ -0,0 +1,189 @@
+package nioserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+)
+
+const (
+	defaultRequestWindow  = 16
+	defaultRequestTimeout = 10 * time.Second
+)
+
+// ErrConnClosed is returned by ISMSRequester.Request when the connection is closed before the response is received.
+var ErrConnClosed = errors.New("connection closed")
+
+// RequestConfig configures ISMSRequester.Request.
+type RequestConfig struct {
+	// Window is the max number of requests waiting for a response on a connection, Request blocks
+	// until there is room. Defaults to 16.
+	Window int
+
+	// Timeout is the max time to wait for a response, the ctx of Request may shorten it. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// WithRequestConfig sets the window and the timeout of ISMSRequester.Request.
+func WithRequestConfig[T any](cfg RequestConfig) ServerOption[T] {
+	return func(s *BaseServer[T]) {
+		s.request = cfg
+	}
+}
+
+// pendingRequests tracks the requests of a connection waiting for a response, keyed by sequence ID.
+type pendingRequests struct {
+	window  chan struct{}
+	timeout time.Duration
+
+	mu          sync.Mutex
+	waiters     map[uint32]chan protocol.PDU
+	activeTests []uint32      // sequence IDs of the last active tests sent, see WithActiveTest
+	done        chan struct{} // closed when the connection is closed
+	closed      bool
+}
+
+func newPendingRequests(cfg RequestConfig) *pendingRequests {
+	return &pendingRequests{
+		window:  make(chan struct{}, cfg.Window),
+		timeout: cfg.Timeout,
+		waiters: make(map[uint32]chan protocol.PDU),
+		done:    make(chan struct{}),
+	}
+}
+
+// add registers a waiter for the response of sequence ID seq.
+func (r *pendingRequests) add(seq uint32) (chan protocol.PDU, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil, ErrConnClosed
+	}
+	if _, ok := r.waiters[seq]; ok {
+		return nil, fmt.Errorf("sequence id %d is already in flight", seq)
+	}
+	ch := make(chan protocol.PDU, 1)
+	r.waiters[seq] = ch
+	return ch, nil
+}
+
+func (r *pendingRequests) remove(seq uint32) {
+	r.mu.Lock()
+	delete(r.waiters, seq)
+	r.mu.Unlock()
+}
+
+// addActiveTest records the sequence ID of an active test sent, only the last max ones are kept.
+func (r *pendingRequests) addActiveTest(seq uint32, max int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.activeTests) >= max {
+		r.activeTests = append(r.activeTests[:0], r.activeTests[len(r.activeTests)-max+1:]...)
+	}
+	r.activeTests = append(r.activeTests, seq)
+}
+
+// deliver passes a response to its waiter, or drops the response of an active test.
+// It reports false if no request is waiting for it.
+func (r *pendingRequests) deliver(resp protocol.PDU) bool {
+	seq := resp.GetSequenceID()
+	r.mu.Lock()
+	ch, ok := r.waiters[seq]
+	if ok {
+		delete(r.waiters, seq)
+	} else {
+		for i, s := range r.activeTests {
+			if s == seq {
+				r.activeTests = append(r.activeTests[:i], r.activeTests[i+1:]...)
+				r.mu.Unlock()
+				return true
+			}
+		}
+	}
+	r.mu.Unlock()
+	if ok {
+		ch <- resp
+	}
+	return ok
+}
+
+// close fails all the waiters with ErrConnClosed.
+func (r *pendingRequests) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	r.waiters = make(map[uint32]chan protocol.PDU)
+	close(r.done)
+}
+
+// inFlight returns the number of requests waiting for a response.
+func (r *pendingRequests) inFlight() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.waiters)
+}
+
+// Request sends p with the next sequence ID and waits for its response. The response is returned
+// here instead of being passed to HandleFunc, a response received after Request returns is passed to HandleFunc.
+func (m *muxConn[T]) Request(ctx context.Context, p protocol.PDU) (protocol.PDU, error) {
+	r := m.requests
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	select {
+	case r.window <- struct{}{}:
+		defer func() { <-r.window }()
+	case <-r.done:
+		return nil, ErrConnClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	seq := m.NextSequenceID()
+	p.SetSequenceID(seq)
+	data, err := p.IEncode()
+	if err != nil {
+		return nil, fmt.Errorf("encode %s error: %w", p.GetCommand(), err)
+	}
+	return m.writeAndWait(ctx, seq, data)
+}
+
+// writeAndWait writes the encoded request data with sequence ID seq and waits for its response.
+func (m *muxConn[T]) writeAndWait(ctx context.Context, seq uint32, data []byte) (protocol.PDU, error) {
+	r := m.requests
+	ch, err := r.add(seq)
+	if err != nil {
+		return nil, err
+	}
+	defer r.remove(seq)
+	m.AsyncWrite(ctx, data)
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-r.done:
+		select {
+		case resp := <-ch: // received just before the close
+			return resp, nil
+		default:
+			return nil, ErrConnClosed
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// InFlight returns the number of Requests waiting for a response.
+func (m *muxConn[T]) InFlight() int {
+	return m.requests.inFlight()
+}
@@ -0,0 +1,93 @@
+package nioserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+type requestResult struct {
+	resp protocol.PDU
+	err  error
+}
+
+// newRequestServer starts a server which sends a deliver_sm with Request for every submit_sm received.
+func newRequestServer(t *testing.T, handled chan protocol.PDU, results chan requestResult, opts ...ServerOption[string]) string {
+	handle := WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+		handled <- p
+		conn, _ := GetCtxConn[string](ctx)
+		go func() {
+			resp, err := conn.(ISMSRequester).Request(context.Background(), &smpp34.DeliverSm{Header: smpp.Header{ID: smpp.DELIVER_SM}})
+			results <- requestResult{resp: resp, err: err}
+		}()
+		return nil, nil
+	})
+	return newTestServer(t, append([]ServerOption[string]{handle}, opts...)...)
+}
+
+func TestMuxConn_Request(t *testing.T) {
+	handled := make(chan protocol.PDU, 8)
+	results := make(chan requestResult, 8)
+	addr := newRequestServer(t, handled, results, WithRequestConfig[string](RequestConfig{Window: 1, Timeout: 200 * time.Millisecond}))
+
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 1}})
+	esme.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 2}})
+
+	deliver := esme.read(t)
+	if !assert.IsType(t, &smpp34.DeliverSm{}, deliver) {
+		return
+	}
+	assert.NotZero(t, deliver.GetSequenceID())
+	esme.send(t, &smpp34.DeliverSmResp{Header: smpp.Header{ID: smpp.DELIVER_SM_RESP, Sequence: deliver.GetSequenceID()}})
+	result := <-results
+	assert.Nil(t, result.err)
+	if assert.IsType(t, &smpp34.DeliverSmResp{}, result.resp) {
+		assert.Equal(t, deliver.GetSequenceID(), result.resp.GetSequenceID())
+	}
+
+	// the second request waits for the window and is not answered
+	deliver2 := esme.read(t)
+	if assert.IsType(t, &smpp34.DeliverSm{}, deliver2) {
+		assert.NotEqual(t, deliver.GetSequenceID(), deliver2.GetSequenceID())
+	}
+	result = <-results
+	assert.ErrorIs(t, result.err, context.DeadlineExceeded)
+
+	// a response after the timeout goes to HandleFunc
+	esme.send(t, &smpp34.DeliverSmResp{Header: smpp.Header{ID: smpp.DELIVER_SM_RESP, Sequence: deliver2.GetSequenceID()}})
+	assert.IsType(t, &smpp34.SubmitSm{}, <-handled)
+	assert.IsType(t, &smpp34.SubmitSm{}, <-handled)
+	select {
+	case p := <-handled:
+		assert.IsType(t, &smpp34.DeliverSmResp{}, p)
+	case <-time.After(time.Second):
+		t.Fatal("late response not handled")
+	}
+	<-results // the request of the late response
+	assert.Len(t, handled, 0)
+}
+
+func TestMuxConn_Request_Closed(t *testing.T) {
+	handled := make(chan protocol.PDU, 8)
+	results := make(chan requestResult, 8)
+	addr := newRequestServer(t, handled, results)
+
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 1}})
+	assert.IsType(t, &smpp34.DeliverSm{}, esme.read(t))
+	_ = esme.Close()
+
+	select {
+	case result := <-results:
+		assert.ErrorIs(t, result.err, ErrConnClosed)
+	case <-time.After(time.Second):
+		t.Fatal("request not failed")
+	}
+}
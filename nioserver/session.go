@@ -0,0 +1,226 @@
+package nioserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+)
+
+// ErrInvalidSessionState is returned by the session layer when a PDU is not allowed in the current session state
+// and the protocol has no error reply for it, so the connection is closed.
+var ErrInvalidSessionState = errors.New("invalid session state")
+
+// SessionState is the state of a connection, see WithSession.
+type SessionState uint32
+
+const (
+	SessionOpen      SessionState = iota // connected, not bound yet
+	SessionBoundTx                       // bound, the peer is a transmitter
+	SessionBoundRx                       // bound, the peer is a receiver
+	SessionBoundTrx                      // bound, the peer is a transceiver
+	SessionUnbinding                     // unbind/terminate/exit sent or received
+	SessionClosed                        // connection closed
+)
+
+func (s SessionState) String() string {
+	switch s {
+	case SessionOpen:
+		return "open"
+	case SessionBoundTx:
+		return "bound-tx"
+	case SessionBoundRx:
+		return "bound-rx"
+	case SessionBoundTrx:
+		return "bound-trx"
+	case SessionUnbinding:
+		return "unbinding"
+	case SessionClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("unknown(%d)", uint32(s))
+}
+
+// IsBound reports whether s is one of the bound states.
+func (s SessionState) IsBound() bool {
+	return s == SessionBoundTx || s == SessionBoundRx || s == SessionBoundTrx
+}
+
+// SessionPolicy describes the session rules of a protocol.
+// SMPPSessionPolicy, CMPPSessionPolicy, SMGPSessionPolicy and SGIPSessionPolicy are provided.
+type SessionPolicy interface {
+	// Bind returns the state requested by a bind request, ok is false if p is not a bind request.
+	// A bind request which cannot be accepted, e.g. with an unknown mode, returns SessionOpen,
+	// it is answered with Reject(SessionOpen, p) and not passed to HandleFunc.
+	Bind(p protocol.PDU) (state SessionState, ok bool)
+
+	// BindAccepted reports whether the encoded bind response returned by HandleFunc accepts the bind.
+	BindAccepted(resp []byte) bool
+
+	// IsUnbind reports whether p is a request to end the session, e.g. unbind, terminate or exit.
+	IsUnbind(p protocol.PDU) bool
+
+	// Allowed reports whether p, which is neither a bind nor an unbind request, may be received in state s.
+	Allowed(s SessionState, p protocol.PDU) bool
+
+	// Reject returns the error reply to p, which is not allowed in state s.
+	// If closeConn is true, the connection is closed after the reply is written.
+	Reject(s SessionState, p protocol.PDU) (resp []byte, closeConn bool)
+}
+
+// SessionConfig configures the session layer of a BaseServer.
+type SessionConfig struct {
+	// Policy is the session rules of the protocol served, it is required.
+	Policy SessionPolicy
+
+	// BindTimeout closes a connection which is not bound within this duration. Zero disables it.
+	BindTimeout time.Duration
+
+	// OnStateChange, if not nil, is called after the session state of a connection changes.
+	OnStateChange func(ctx context.Context, from, to SessionState)
+}
+
+// WithSession enables the session layer: every connection has a SessionState, and a PDU which is not allowed
+// in the current state is answered with the protocol error from SessionConfig.Policy instead of being passed to HandleFunc.
+// Bind and unbind requests are still passed to HandleFunc, the state changes when the bind response accepts the bind.
+func WithSession[T any](cfg SessionConfig) ServerOption[T] {
+	return func(s *BaseServer[T]) {
+		s.session = &cfg
+	}
+}
+
+// GetSessionState returns the session state of the connection in ctx.
+// ok is false if the server has no session layer or ctx has no connection.
+func GetSessionState[T any](ctx context.Context) (state SessionState, ok bool) {
+	mc, ok := GetCtxConn[T](ctx)
+	if !ok {
+		return 0, false
+	}
+	m, ok := mc.(*muxConn[T])
+	if !ok || !m.hasSession {
+		return 0, false
+	}
+	return m.sessionState(), true
+}
+
+// sessionState returns the current session state.
+func (m *muxConn[T]) sessionState() SessionState {
+	return SessionState(atomic.LoadUint32(&m.session))
+}
+
+// setSessionState changes the state from `from` to `to`, it reports false if the state is not `from`.
+func (s *BaseServer[T]) setSessionState(ctx context.Context, m *muxConn[T], from, to SessionState) bool {
+	if !atomic.CompareAndSwapUint32(&m.session, uint32(from), uint32(to)) {
+		return false
+	}
+	if from == SessionOpen && m.bindTimer != nil {
+		m.bindTimer.Stop()
+	}
+	if s.session.OnStateChange != nil {
+		s.session.OnStateChange(ctx, from, to)
+	}
+	return true
+}
+
+// openSession starts the bind timer of a new connection.
+func (s *BaseServer[T]) openSession(ctx context.Context, m *muxConn[T]) {
+	m.hasSession = true
+	if s.session.BindTimeout <= 0 {
+		return
+	}
+	m.bindTimer = time.AfterFunc(s.session.BindTimeout, func() {
+		if m.sessionState() != SessionOpen {
+			return
+		}
+		s.logger.CtxWarnf(ctx, "[Session] %s not bound in %s, closing", m.RemoteAddr(), s.session.BindTimeout)
+		_ = m.Close()
+	})
+}
+
+// closeSession moves the connection into SessionClosed.
+func (s *BaseServer[T]) closeSession(ctx context.Context, m *muxConn[T]) {
+	if m.bindTimer != nil {
+		m.bindTimer.Stop()
+	}
+	for {
+		from := m.sessionState()
+		if from == SessionClosed || s.setSessionState(ctx, m, from, SessionClosed) {
+			return
+		}
+	}
+}
+
+// handleSession wraps next with the session state checks.
+func (s *BaseServer[T]) handleSession(next HandleFunc) HandleFunc {
+	policy := s.session.Policy
+	return func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+		mc, ok := GetCtxConn[T](ctx)
+		if !ok {
+			return next(ctx, p)
+		}
+		m, ok := mc.(*muxConn[T])
+		if !ok {
+			return next(ctx, p)
+		}
+
+		state := m.sessionState()
+		if bound, ok := policy.Bind(p); ok {
+			// only one bind at a time, a concurrent bind is rejected as if already bound
+			if state != SessionOpen || !bound.IsBound() || !atomic.CompareAndSwapUint32(&m.binding, 0, 1) {
+				return s.rejectSession(ctx, policy, state, p)
+			}
+			defer atomic.StoreUint32(&m.binding, 0)
+
+			resp, err := next(ctx, p)
+			if err == nil && policy.BindAccepted(resp) {
+				s.setSessionState(ctx, m, SessionOpen, bound)
+			}
+			return resp, err
+		}
+
+		if policy.IsUnbind(p) {
+			if !state.IsBound() {
+				return s.rejectSession(ctx, policy, state, p)
+			}
+			s.setSessionState(ctx, m, state, SessionUnbinding)
+			return next(ctx, p)
+		}
+
+		if !policy.Allowed(state, p) {
+			return s.rejectSession(ctx, policy, state, p)
+		}
+		return next(ctx, p)
+	}
+}
+
+// bindBarrier keeps a PDU from being handled before a bind received earlier on the same connection,
+// otherwise a submit pipelined right after a bind could be checked before the bind is accepted.
+// It must be called in the order the PDUs are received. wait blocks until the earlier bind is handled,
+// done is called once p is handled.
+func (s *BaseServer[T]) bindBarrier(m *muxConn[T], p protocol.PDU) (wait, done func()) {
+	prev, _ := m.bindDone.Load().(chan struct{})
+	wait = func() {
+		if prev != nil {
+			<-prev
+		}
+	}
+	done = func() {}
+	if _, ok := s.session.Policy.Bind(p); ok {
+		ch := make(chan struct{})
+		m.bindDone.Store(ch)
+		done = func() { close(ch) }
+	}
+	return wait, done
+}
+
+func (s *BaseServer[T]) rejectSession(ctx context.Context, policy SessionPolicy, state SessionState, p protocol.PDU) ([]byte, error) {
+	s.logger.CtxWarnf(ctx, "[Session] %s not allowed in state %s", p.GetCommand(), state)
+	resp, closeConn := policy.Reject(state, p)
+	if closeConn {
+		return resp, fmt.Errorf("%w: %s in %s", ErrInvalidSessionState, p.GetCommand(), state)
+	}
+	return resp, nil
+}
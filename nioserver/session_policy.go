@@ -0,0 +1,272 @@
+package nioserver
+
+import (
+	"encoding/binary"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// isResponse reports whether p is a response, all the protocols set the highest bit of the command ID of a response.
+func isResponse(p protocol.PDU) bool {
+	return p.GetCommand().ToUint32()&0x80000000 != 0
+}
+
+// encodeEmptyResponse encodes the empty response of p, nil if p has none.
+func encodeEmptyResponse(p protocol.PDU) []byte {
+	resp := p.GenEmptyResponse()
+	if resp == nil {
+		return nil
+	}
+	data, err := resp.IEncode()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// SMPPSessionPolicy is the SessionPolicy of SMPP 3.4 and 5.0.
+// enquire_link and generic_nack are allowed in every state, submits are allowed for transmitters and transceivers,
+// deliver_sm_resp and data_sm_resp for receivers and transceivers. A request which is not allowed is answered
+// with ESME_RINVBNDSTS, a second bind with ESME_RALYBND.
+type SMPPSessionPolicy struct{}
+
+var (
+	// smppTxCommands are the requests of an ESME bound as a transmitter or transceiver.
+	smppTxCommands = map[smpp.CMDId]bool{
+		smpp.SUBMIT_SM:           true,
+		smpp.SUBMIT_MULTI:        true,
+		smpp.DATA_SM:             true,
+		smpp.QUERY_SM:            true,
+		smpp.CANCEL_SM:           true,
+		smpp.REPLACE_SM:          true,
+		smpp.BROADCAST_SM:        true,
+		smpp.QUERY_BROADCAST_SM:  true,
+		smpp.CANCEL_BROADCAST_SM: true,
+	}
+
+	// smppRxCommands are the responses of an ESME bound as a receiver or transceiver.
+	smppRxCommands = map[smpp.CMDId]bool{
+		smpp.DELIVER_SM_RESP: true,
+		smpp.DATA_SM_RESP:    true,
+	}
+)
+
+// smppCommand returns the command ID of p. smpp34.Bind serves all the three binds and its GetCommand
+// is always BIND_TRANSCEIVER, the real one is in the header.
+func smppCommand(p protocol.PDU) smpp.CMDId {
+	if bind, ok := p.(*smpp34.Bind); ok {
+		return bind.Header.ID
+	}
+	return smpp.CMDId(p.GetCommand().ToUint32())
+}
+
+func (SMPPSessionPolicy) Bind(p protocol.PDU) (SessionState, bool) {
+	switch smppCommand(p) {
+	case smpp.BIND_TRANSMITTER:
+		return SessionBoundTx, true
+	case smpp.BIND_RECEIVER:
+		return SessionBoundRx, true
+	case smpp.BIND_TRANSCEIVER:
+		return SessionBoundTrx, true
+	}
+	return 0, false
+}
+
+func (SMPPSessionPolicy) BindAccepted(resp []byte) bool {
+	return len(resp) >= 16 && smpp.CMDStatus(binary.BigEndian.Uint32(resp[8:12])) == smpp.ESME_ROK
+}
+
+func (SMPPSessionPolicy) IsUnbind(p protocol.PDU) bool {
+	return smppCommand(p) == smpp.UNBIND
+}
+
+func (SMPPSessionPolicy) Allowed(s SessionState, p protocol.PDU) bool {
+	cmd := smppCommand(p)
+	if cmd == smpp.ENQUIRE_LINK || cmd == smpp.ENQUIRE_LINK_RESP || cmd == smpp.GENERIC_NACK {
+		return s != SessionClosed
+	}
+	switch s {
+	case SessionBoundTx:
+		return !smppRxCommands[cmd]
+	case SessionBoundRx:
+		return !smppTxCommands[cmd]
+	case SessionBoundTrx:
+		return true
+	case SessionUnbinding:
+		return isResponse(p)
+	}
+	return false
+}
+
+func (SMPPSessionPolicy) Reject(s SessionState, p protocol.PDU) ([]byte, bool) {
+	resp := encodeEmptyResponse(p)
+	if len(resp) < 16 {
+		return nil, false
+	}
+	status := smpp.ESME_RINVBNDSTS
+	if _, ok := (SMPPSessionPolicy{}).Bind(p); ok {
+		binary.BigEndian.PutUint32(resp[4:8], uint32(smppCommand(p))|0x80000000)
+		if s != SessionOpen {
+			status = smpp.ESME_RALYBND
+		}
+	}
+	binary.BigEndian.PutUint32(resp[8:12], uint32(status))
+	return resp, false
+}
+
+// CMPPSessionPolicy is the SessionPolicy of CMPP 2.0 and 3.0, a connected session is SessionBoundTrx.
+// A second CMPP_CONNECT is answered with a CMPP_CONNECT_RESP of status 5 (cmpp20.ConnectRespStatusTooConns). CMPP has no
+// error for a PDU received before CMPP_CONNECT, such a PDU closes the connection.
+type CMPPSessionPolicy struct{}
+
+func (CMPPSessionPolicy) Bind(p protocol.PDU) (SessionState, bool) {
+	if cmpp.CommandID(p.GetCommand().ToUint32()) == cmpp.CommandConnect {
+		return SessionBoundTrx, true
+	}
+	return 0, false
+}
+
+func (CMPPSessionPolicy) BindAccepted(resp []byte) bool {
+	switch len(resp) {
+	case cmpp.HeaderLength + 1 + 16 + 1: // CMPP 2.0, 1 byte status
+		return resp[cmpp.HeaderLength] == 0
+	case cmpp.HeaderLength + 4 + 16 + 1: // CMPP 3.0, 4 bytes status
+		return binary.BigEndian.Uint32(resp[cmpp.HeaderLength:]) == 0
+	}
+	return false
+}
+
+func (CMPPSessionPolicy) IsUnbind(p protocol.PDU) bool {
+	return cmpp.CommandID(p.GetCommand().ToUint32()) == cmpp.CommandTerminate
+}
+
+func (CMPPSessionPolicy) Allowed(s SessionState, p protocol.PDU) bool {
+	switch cmd := cmpp.CommandID(p.GetCommand().ToUint32()); {
+	case cmd == cmpp.CommandActiveTest || cmd == cmpp.CommandActiveTestResp:
+		return s != SessionClosed
+	case s == SessionUnbinding:
+		return isResponse(p)
+	}
+	return s.IsBound()
+}
+
+func (CMPPSessionPolicy) Reject(s SessionState, p protocol.PDU) ([]byte, bool) {
+	if s.IsBound() {
+		switch resp := p.GenEmptyResponse().(type) {
+		case *cmpp20.PduConnectResp:
+			resp.Status = cmpp20.ConnectRespStatusTooConns
+			data, _ := resp.IEncode()
+			return data, false
+		case *cmpp30.ConnectResp:
+			resp.Status = uint32(cmpp20.ConnectRespStatusTooConns)
+			data, _ := resp.IEncode()
+			return data, false
+		}
+	}
+	return nil, !isResponse(p)
+}
+
+// SMGPSessionPolicy is the SessionPolicy of SMGP 3.0, the LoginMode of a Login maps to
+// SessionBoundTx (SEND_MODE), SessionBoundRx (RECEIVE_MODE) or SessionBoundTrx (TRANSMIT_MODE).
+// A second Login is answered with a Login_Resp of status 5 (other error). SMGP has no status for an unknown
+// LoginMode, such a Login is answered with status 1 (message structure error). SMGP has no error for a PDU
+// received before Login, such a PDU closes the connection.
+type SMGPSessionPolicy struct{}
+
+func (SMGPSessionPolicy) Bind(p protocol.PDU) (SessionState, bool) {
+	login, ok := p.(*smgp30.Login)
+	if !ok {
+		return 0, false
+	}
+	switch login.LoginMode {
+	case smgp.SEND_MODE:
+		return SessionBoundTx, true
+	case smgp.RECEIVE_MODE:
+		return SessionBoundRx, true
+	case smgp.TRANSMIT_MODE:
+		return SessionBoundTrx, true
+	}
+	return SessionOpen, true
+}
+
+func (SMGPSessionPolicy) BindAccepted(resp []byte) bool {
+	return len(resp) >= smgp.HeaderLength+4 && binary.BigEndian.Uint32(resp[smgp.HeaderLength:]) == 0
+}
+
+func (SMGPSessionPolicy) IsUnbind(p protocol.PDU) bool {
+	return smgp.CommandID(p.GetCommand().ToUint32()) == smgp.CommandExit
+}
+
+func (SMGPSessionPolicy) Allowed(s SessionState, p protocol.PDU) bool {
+	switch cmd := smgp.CommandID(p.GetCommand().ToUint32()); {
+	case cmd == smgp.CommandActiveTest || cmd == smgp.CommandActiveTestResp:
+		return s != SessionClosed
+	case s == SessionUnbinding:
+		return isResponse(p)
+	}
+	return s.IsBound()
+}
+
+func (SMGPSessionPolicy) Reject(s SessionState, p protocol.PDU) ([]byte, bool) {
+	if login, ok := p.(*smgp30.Login); ok && s == SessionOpen && login.LoginMode > smgp.TRANSMIT_MODE {
+		resp := login.GenEmptyResponse().(*smgp30.LoginResp)
+		resp.Status = smgp30.LoginRespStatusMsgStructErr
+		data, _ := resp.IEncode()
+		return data, false
+	}
+	if resp, ok := p.GenEmptyResponse().(*smgp30.LoginResp); ok && s.IsBound() {
+		resp.Status = smgp30.LoginRespStatusOtherErr
+		data, _ := resp.IEncode()
+		return data, false
+	}
+	return nil, !isResponse(p)
+}
+
+// SGIPSessionPolicy is the SessionPolicy of SGIP 1.2. An SGIP connection only carries the commands of the
+// client, so every Bind leads to SessionBoundTx. A command received before Bind is answered with
+// Result 1 (illegal login), a second Bind with Result 2 (repeated login).
+type SGIPSessionPolicy struct{}
+
+func (SGIPSessionPolicy) Bind(p protocol.PDU) (SessionState, bool) {
+	if sgip.CommandID(p.GetCommand().ToUint32()) == sgip.SGIP_BIND {
+		return SessionBoundTx, true
+	}
+	return 0, false
+}
+
+func (SGIPSessionPolicy) BindAccepted(resp []byte) bool {
+	return len(resp) > sgip.HeaderLength && resp[sgip.HeaderLength] == 0
+}
+
+func (SGIPSessionPolicy) IsUnbind(p protocol.PDU) bool {
+	return sgip.CommandID(p.GetCommand().ToUint32()) == sgip.SGIP_UNBIND
+}
+
+func (SGIPSessionPolicy) Allowed(s SessionState, p protocol.PDU) bool {
+	if s == SessionUnbinding {
+		return isResponse(p)
+	}
+	return s.IsBound()
+}
+
+func (SGIPSessionPolicy) Reject(s SessionState, p protocol.PDU) ([]byte, bool) {
+	// every SGIP response starts with a 1 byte Result
+	resp := encodeEmptyResponse(p)
+	if len(resp) <= sgip.HeaderLength {
+		return nil, false
+	}
+	result := sgip.STAT_ILLLOGIN
+	if s.IsBound() {
+		result = sgip.STAT_RPTLOGIN
+	}
+	resp[sgip.HeaderLength] = uint8(result)
+	return resp, false
+}
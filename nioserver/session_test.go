@@ -0,0 +1,227 @@
+package nioserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+func TestWithSession(t *testing.T) {
+	handled := make(chan SessionState, 8)
+	changes := make(chan SessionState, 8)
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			state, _ := GetSessionState[string](ctx)
+			handled <- state
+			if bind, ok := p.(*smpp34.Bind); ok && bind.Password != "secret" {
+				resp := p.GenEmptyResponse().(*smpp34.BindResp)
+				resp.Header.Status = smpp.ESME_RBINDFAIL
+				return resp.IEncode()
+			}
+			if resp := p.GenEmptyResponse(); resp != nil {
+				return resp.IEncode()
+			}
+			return nil, nil
+		}),
+		WithSession[string](SessionConfig{
+			Policy: SMPPSessionPolicy{},
+			OnStateChange: func(ctx context.Context, from, to SessionState) {
+				changes <- to
+			},
+		}),
+	)
+
+	esme := dialServer(t, addr)
+
+	// submit before bind
+	esme.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 1}})
+	resp := esme.read(t)
+	if assert.IsType(t, &smpp34.SubmitSmResp{}, resp) {
+		assert.Equal(t, smpp.ESME_RINVBNDSTS, resp.(*smpp34.SubmitSmResp).Header.Status)
+	}
+	assert.Len(t, handled, 0)
+
+	// enquire_link is allowed before bind
+	esme.send(t, &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK, Sequence: 2}})
+	assert.IsType(t, &smpp34.EnquireLinkResp{}, esme.read(t))
+	assert.Equal(t, SessionOpen, <-handled)
+
+	// rejected bind keeps the session open
+	esme.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSMITTER, Sequence: 3}, Password: "bad"})
+	resp = esme.read(t)
+	if assert.IsType(t, &smpp34.BindResp{}, resp) {
+		assert.Equal(t, smpp.ESME_RBINDFAIL, resp.(*smpp34.BindResp).Header.Status)
+	}
+	assert.Equal(t, SessionOpen, <-handled)
+
+	esme.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSMITTER, Sequence: 4}, Password: "secret"})
+	resp = esme.read(t)
+	if assert.IsType(t, &smpp34.BindResp{}, resp) {
+		assert.Equal(t, smpp.ESME_ROK, resp.(*smpp34.BindResp).Header.Status)
+	}
+	assert.Equal(t, SessionOpen, <-handled)
+	assert.Equal(t, SessionBoundTx, <-changes)
+
+	// second bind
+	esme.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_RECEIVER, Sequence: 5}, Password: "secret"})
+	resp = esme.read(t)
+	if assert.IsType(t, &smpp34.BindResp{}, resp) {
+		assert.Equal(t, smpp.ESME_RALYBND, resp.(*smpp34.BindResp).Header.Status)
+		assert.Equal(t, smpp.BIND_RECEIVER_RESP, resp.(*smpp34.BindResp).Header.ID)
+	}
+
+	// deliver_sm_resp is not expected from a transmitter and dropped
+	esme.send(t, &smpp34.DeliverSmResp{Header: smpp.Header{ID: smpp.DELIVER_SM_RESP, Sequence: 6}})
+
+	esme.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 7}})
+	resp = esme.read(t)
+	if assert.IsType(t, &smpp34.SubmitSmResp{}, resp) {
+		assert.Equal(t, smpp.ESME_ROK, resp.(*smpp34.SubmitSmResp).Header.Status)
+		assert.Equal(t, uint32(7), resp.GetSequenceID())
+	}
+	assert.Equal(t, SessionBoundTx, <-handled)
+	assert.Len(t, handled, 0)
+
+	esme.send(t, &smpp34.Unbind{Header: smpp.Header{ID: smpp.UNBIND, Sequence: 8}})
+	assert.IsType(t, &smpp34.UnBindResp{}, esme.read(t))
+	assert.Equal(t, SessionUnbinding, <-handled)
+	assert.Equal(t, SessionUnbinding, <-changes)
+
+	_ = esme.Close()
+	select {
+	case state := <-changes:
+		assert.Equal(t, SessionClosed, state)
+	case <-time.After(time.Second):
+		t.Fatal("session not closed")
+	}
+}
+
+func TestWithSession_PipelinedBind(t *testing.T) {
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			if _, ok := p.(*smpp34.Bind); ok {
+				time.Sleep(50 * time.Millisecond)
+			}
+			return p.GenEmptyResponse().IEncode()
+		}),
+		WithSession[string](SessionConfig{Policy: SMPPSessionPolicy{}}),
+	)
+
+	// bind and submit_sm in one write
+	bind, _ := (&smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}}).IEncode()
+	submit, _ := (&smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 2}}).IEncode()
+	esme := dialServer(t, addr)
+	_, err := esme.Write(append(bind, submit...))
+	assert.Nil(t, err)
+
+	resp := esme.read(t)
+	if assert.IsType(t, &smpp34.BindResp{}, resp) {
+		assert.Equal(t, smpp.ESME_ROK, resp.(*smpp34.BindResp).Header.Status)
+	}
+	resp = esme.read(t)
+	if assert.IsType(t, &smpp34.SubmitSmResp{}, resp) {
+		assert.Equal(t, smpp.ESME_ROK, resp.(*smpp34.SubmitSmResp).Header.Status)
+	}
+}
+
+func TestWithSession_BindTimeout(t *testing.T) {
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			return p.GenEmptyResponse().IEncode()
+		}),
+		WithSession[string](SessionConfig{Policy: SMPPSessionPolicy{}, BindTimeout: 100 * time.Millisecond}),
+	)
+
+	// bound in time
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}})
+	assert.IsType(t, &smpp34.BindResp{}, esme.read(t))
+	time.Sleep(200 * time.Millisecond)
+	esme.send(t, &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK, Sequence: 2}})
+	assert.IsType(t, &smpp34.EnquireLinkResp{}, esme.read(t))
+
+	// not bound
+	esme = dialServer(t, addr)
+	time.Sleep(200 * time.Millisecond)
+	assert.Nil(t, esme.read(t))
+}
+
+func TestSessionPolicy(t *testing.T) {
+	// CMPP
+	cmppPolicy := CMPPSessionPolicy{}
+	connect := &cmpp30.Connect{Header: cmpp.NewHeader(0, cmpp.CommandConnect, 1)}
+	state, ok := cmppPolicy.Bind(connect)
+	assert.True(t, ok)
+	assert.Equal(t, SessionBoundTrx, state)
+	data, closeConn := cmppPolicy.Reject(SessionBoundTrx, connect)
+	assert.False(t, closeConn)
+	resp, err := cmpp30.DecodeCMPP30(data)
+	if assert.Nil(t, err) {
+		assert.Equal(t, uint32(cmpp20.ConnectRespStatusTooConns), resp.(*cmpp30.ConnectResp).Status)
+	}
+	data, closeConn = cmppPolicy.Reject(SessionBoundTrx, &cmpp20.PduConnect{Header: cmpp.NewHeader(0, cmpp.CommandConnect, 1)})
+	assert.False(t, closeConn)
+	assert.False(t, cmppPolicy.BindAccepted(data))
+	resp, err = cmpp20.DecodeCMPP20(data)
+	if assert.Nil(t, err) {
+		assert.Equal(t, cmpp20.ConnectRespStatusTooConns, resp.(*cmpp20.PduConnectResp).Status)
+	}
+	ok20, _ := (&cmpp20.PduConnectResp{Header: cmpp.NewHeader(0, cmpp.CommandConnectResp, 1)}).IEncode()
+	assert.True(t, cmppPolicy.BindAccepted(ok20))
+
+	submit := &cmpp30.Submit{Header: cmpp.NewHeader(0, cmpp.CommandSubmit, 2)}
+	assert.False(t, cmppPolicy.Allowed(SessionOpen, submit))
+	assert.True(t, cmppPolicy.Allowed(SessionBoundTrx, submit))
+	assert.True(t, cmppPolicy.Allowed(SessionOpen, &cmpp30.ActiveTest{Header: cmpp.NewHeader(0, cmpp.CommandActiveTest, 3)}))
+	data, closeConn = cmppPolicy.Reject(SessionOpen, submit)
+	assert.Nil(t, data)
+	assert.True(t, closeConn)
+
+	// SMGP
+	smgpPolicy := SMGPSessionPolicy{}
+	login := &smgp30.Login{Header: smgp.NewHeader(0, smgp.CommandLogin, 1), LoginMode: smgp.RECEIVE_MODE}
+	state, ok = smgpPolicy.Bind(login)
+	assert.True(t, ok)
+	assert.Equal(t, SessionBoundRx, state)
+	data, closeConn = smgpPolicy.Reject(SessionBoundRx, login)
+	assert.False(t, closeConn)
+	assert.False(t, smgpPolicy.BindAccepted(data))
+	login.LoginMode = 3
+	state, ok = smgpPolicy.Bind(login)
+	assert.True(t, ok)
+	assert.Equal(t, SessionOpen, state)
+	data, closeConn = smgpPolicy.Reject(SessionOpen, login)
+	assert.False(t, closeConn)
+	assert.False(t, smgpPolicy.BindAccepted(data))
+	resp, err = smgp30.DecodeSMGP30(data)
+	if assert.Nil(t, err) {
+		assert.Equal(t, smgp30.LoginRespStatusMsgStructErr, resp.(*smgp30.LoginResp).Status)
+	}
+	_, closeConn = smgpPolicy.Reject(SessionOpen, &smgp30.Submit{Header: smgp.NewHeader(0, smgp.CommandSubmit, 2)})
+	assert.True(t, closeConn)
+	assert.True(t, smgpPolicy.IsUnbind(&smgp30.Exit{Header: smgp.NewHeader(0, smgp.CommandExit, 3)}))
+
+	// SGIP
+	sgipPolicy := SGIPSessionPolicy{}
+	data, closeConn = sgipPolicy.Reject(SessionOpen, &sgip12.Submit{Header: sgip.Header{CommandID: sgip.SGIP_SUBMIT}})
+	assert.False(t, closeConn)
+	if assert.Len(t, data, sgip.MaxRespLength) {
+		assert.Equal(t, uint8(sgip.STAT_ILLLOGIN), data[sgip.HeaderLength])
+	}
+	data, _ = sgipPolicy.Reject(SessionBoundTx, &sgip12.Bind{Header: sgip.Header{CommandID: sgip.SGIP_BIND}})
+	assert.Equal(t, uint8(sgip.STAT_RPTLOGIN), data[sgip.HeaderLength])
+	assert.False(t, sgipPolicy.BindAccepted(data))
+}
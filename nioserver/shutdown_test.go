@@ -0,0 +1,146 @@
+package nioserver
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// newStartedServer starts a BaseServer with Start on a random port.
+func newStartedServer(t *testing.T, opts ...ServerOption[string]) (*BaseServer[string], string) {
+	return startTestServer(t, func(s *BaseServer[string]) {
+		assert.Nil(t, s.Start())
+	}, opts...)
+}
+
+func TestBaseServer_StartShutdown(t *testing.T) {
+	s, addr := newStartedServer(t, WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+		return p.GenEmptyResponse().IEncode()
+	}))
+	assert.NotNil(t, s.Start())
+
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK, Sequence: 1}})
+	assert.IsType(t, &smpp34.EnquireLinkResp{}, esme.read(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, s.Shutdown(ctx))
+	assert.Nil(t, esme.read(t))
+	_, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
+	assert.NotNil(t, err)
+
+	assert.ErrorIs(t, s.Start(), ErrServerClosed)
+	assert.ErrorIs(t, s.Shutdown(ctx), ErrServerClosed)
+}
+
+func TestBaseServer_Shutdown_Unbind(t *testing.T) {
+	var handled int32
+	s, addr := newStartedServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			if p.GetCommand() == smpp.SUBMIT_SM {
+				time.Sleep(200 * time.Millisecond)
+				atomic.StoreInt32(&handled, 1)
+			}
+			return p.GenEmptyResponse().IEncode()
+		}),
+		WithSession[string](SessionConfig{Policy: SMPPSessionPolicy{}}),
+		WithUnbindBuilder[string](smpp34.NewUnBindBytes),
+	)
+
+	bound := dialServer(t, addr)
+	bound.send(t, &smpp34.Bind{Header: smpp.Header{ID: smpp.BIND_TRANSCEIVER, Sequence: 1}})
+	assert.IsType(t, &smpp34.BindResp{}, bound.read(t))
+	notBound := dialServer(t, addr)
+
+	bound.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 2}})
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		done <- s.Shutdown(ctx)
+	}()
+
+	unbind := bound.read(t)
+	if assert.IsType(t, &smpp34.Unbind{}, unbind) {
+		bound.send(t, &smpp34.UnBindResp{Header: smpp.Header{ID: smpp.UNBIND_RESP, Sequence: unbind.GetSequenceID()}})
+	}
+	// a request is rejected while unbinding
+	bound.send(t, &smpp34.SubmitSm{Header: smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 3}})
+
+	var resps []protocol.PDU
+	for p := bound.read(t); p != nil; p = bound.read(t) {
+		resps = append(resps, p)
+	}
+	if assert.Len(t, resps, 2) {
+		for _, resp := range resps {
+			if assert.IsType(t, &smpp34.SubmitSmResp{}, resp) && resp.GetSequenceID() == 3 {
+				assert.Equal(t, smpp.ESME_RINVBNDSTS, resp.(*smpp34.SubmitSmResp).Header.Status)
+			}
+		}
+	}
+	assert.Nil(t, notBound.read(t))
+
+	assert.Nil(t, <-done)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handled))
+}
+
+func TestBaseServer_Shutdown_Timeout(t *testing.T) {
+	s, addr := newStartedServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			return p.GenEmptyResponse().IEncode()
+		}),
+		WithUnbindBuilder[string](smpp34.NewUnBindBytes),
+	)
+
+	// the peer never answers the unbind
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK, Sequence: 1}})
+	assert.IsType(t, &smpp34.EnquireLinkResp{}, esme.read(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Shutdown(ctx), context.DeadlineExceeded)
+	assert.IsType(t, &smpp34.Unbind{}, esme.read(t))
+	assert.Nil(t, esme.read(t))
+}
+
+func TestBaseServer_Shutdown_NoSession(t *testing.T) {
+	var handled int32
+	s, addr := newStartedServer(t, WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+		atomic.AddInt32(&handled, 1)
+		time.Sleep(100 * time.Millisecond)
+		return p.GenEmptyResponse().IEncode()
+	}))
+
+	esme := dialServer(t, addr)
+	esme.send(t, &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK, Sequence: 1}})
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		done <- s.Shutdown(ctx)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// the requests received while shutting down are dropped, Shutdown does not wait for them
+	esme.send(t, &smpp34.EnquireLink{Header: smpp.Header{ID: smpp.ENQUIRE_LINK, Sequence: 2}})
+	if resp := esme.read(t); assert.IsType(t, &smpp34.EnquireLinkResp{}, resp) {
+		assert.Equal(t, uint32(1), resp.GetSequenceID())
+	}
+	assert.Nil(t, esme.read(t))
+	assert.Nil(t, <-done)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&handled))
+}
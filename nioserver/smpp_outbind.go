@@ -0,0 +1,105 @@
+package nioserver
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// outbind states of a connection
+const (
+	outbindNone    uint32 = iota // no outbind received
+	outbindPending               // bind_receiver sent, waiting for bind_receiver_resp
+	outbindBound                 // bound as a receiver
+)
+
+// OutbindConfig configures how a BaseServer answers an SMPP outbind, i.e. an SMSC connecting to the ESME.
+type OutbindConfig struct {
+	// SystemID, Password, SystemType, AddrTon, AddrNpi and AddressRange are sent in the bind_receiver.
+	SystemID     string
+	Password     string
+	SystemType   string
+	AddrTon      uint8
+	AddrNpi      uint8
+	AddressRange string
+
+	// Authenticate checks the system_id and password of the outbind.
+	// If it returns false, the connection is closed. If nil, every outbind is accepted.
+	Authenticate func(ctx context.Context, outbind *smpp34.Outbind) bool
+
+	// OnBound is called when the SMSC accepts the bind_receiver.
+	// The connection is a receiver session from then on, e.g. deliver_sm is passed to HandleFunc.
+	OnBound func(ctx context.Context, resp *smpp34.BindResp)
+}
+
+// WithSMPPOutbind makes the BaseServer accept SMPP outbind: an outbind is answered with a bind_receiver,
+// and the bind_receiver_resp switches the connection into a receiver session. Neither of them is passed to HandleFunc.
+// A rejected outbind or a failed bind_receiver_resp closes the connection.
+// UnpackFunc must decode Outbind and BindResp, e.g. with smpp34.DecodeSMPP34.
+func WithSMPPOutbind[T any](cfg OutbindConfig) ServerOption[T] {
+	return func(s *BaseServer[T]) {
+		s.outbind = &cfg
+	}
+}
+
+// IsSMPPReceiver reports whether the connection in ctx has been switched into a receiver session by an outbind.
+func IsSMPPReceiver[T any](ctx context.Context) bool {
+	mc, ok := GetCtxConn[T](ctx)
+	if !ok {
+		return false
+	}
+	m, ok := mc.(*muxConn[T])
+	return ok && atomic.LoadUint32(&m.outbind) == outbindBound
+}
+
+// handleOutbind wraps next with the outbind handling.
+func (s *BaseServer[T]) handleOutbind(next HandleFunc) HandleFunc {
+	cfg := s.outbind
+	return func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+		mc, ok := GetCtxConn[T](ctx)
+		if !ok {
+			return next(ctx, p)
+		}
+		m, ok := mc.(*muxConn[T])
+		if !ok {
+			return next(ctx, p)
+		}
+
+		switch pdu := p.(type) {
+		case *smpp34.Outbind:
+			if !atomic.CompareAndSwapUint32(&m.outbind, outbindNone, outbindPending) {
+				return nil, fmt.Errorf("unexpected outbind from %s", pdu.SystemID)
+			}
+			if cfg.Authenticate != nil && !cfg.Authenticate(ctx, pdu) {
+				return nil, fmt.Errorf("outbind from %s rejected", pdu.SystemID)
+			}
+			bind := pdu.GenBindReceiver(cfg.SystemID, cfg.Password)
+			bind.SystemType = cfg.SystemType
+			bind.AddrTon = cfg.AddrTon
+			bind.AddrNpi = cfg.AddrNpi
+			bind.AddressRange = cfg.AddressRange
+			bind.SetSequenceID(mc.NextSequenceID())
+			return bind.IEncode()
+		case *smpp34.BindResp:
+			if pdu.Header.ID != smpp.BIND_RECEIVER_RESP || atomic.LoadUint32(&m.outbind) != outbindPending {
+				break
+			}
+			if pdu.Header.Status != smpp.ESME_ROK {
+				return nil, fmt.Errorf("bind_receiver error: %w", pdu.Header.Status)
+			}
+			atomic.StoreUint32(&m.outbind, outbindBound)
+			if s.session != nil {
+				s.setSessionState(ctx, m, SessionOpen, SessionBoundRx)
+			}
+			if cfg.OnBound != nil {
+				cfg.OnBound(ctx, pdu)
+			}
+			return nil, nil
+		}
+		return next(ctx, p)
+	}
+}
@@ -0,0 +1,160 @@
+package nioserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/netpoll"
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/codec"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// unpackSMPP reads one SMPP PDU from the netpoll reader.
+func unpackSMPP(ctx context.Context, r netpoll.Reader) (protocol.PDU, error) {
+	lenBytes, err := r.Peek(4)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := r.Next(int(binary.BigEndian.Uint32(lenBytes)))
+	if err != nil {
+		return nil, err
+	}
+	data := append([]byte(nil), buf...)
+	_ = r.Release()
+	return smpp34.DecodeSMPP34(data)
+}
+
+// startTestServer creates a BaseServer on a random port and starts it with start.
+func startTestServer(t *testing.T, start func(s *BaseServer[string]), opts ...ServerOption[string]) (*BaseServer[string], string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	s, err := NewBaseServer[string]("tcp", addr, append([]ServerOption[string]{WithUnpackFunc[string](unpackSMPP)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start(s)
+	return s, addr
+}
+
+// newTestServer starts a BaseServer on a random port and returns its address.
+func newTestServer(t *testing.T, opts ...ServerOption[string]) string {
+	_, addr := startTestServer(t, func(s *BaseServer[string]) {
+		go func() { _ = s.eventLoop.Serve(s.listener) }()
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_ = s.eventLoop.Shutdown(ctx)
+		})
+	}, opts...)
+	return addr
+}
+
+// smscConn is the SMSC side of a connection to the server.
+type smscConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func dialServer(t *testing.T, addr string) *smscConn {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return &smscConn{Conn: conn, r: bufio.NewReader(conn)}
+}
+
+func (c *smscConn) send(t *testing.T, p protocol.PDU) {
+	data, err := p.IEncode()
+	assert.Nil(t, err)
+	_, err = c.Write(data)
+	assert.Nil(t, err)
+}
+
+// read returns the next PDU, or nil if the connection is closed.
+func (c *smscConn) read(t *testing.T) protocol.PDU {
+	_ = c.SetReadDeadline(time.Now().Add(time.Second))
+	data, err := codec.NewSMPPCodec().DecodeBlocked(c.r)
+	if err != nil {
+		return nil
+	}
+	p, err := smpp34.DecodeSMPP34(data)
+	assert.Nil(t, err)
+	return p
+}
+
+func TestWithSMPPOutbind(t *testing.T) {
+	bound := make(chan bool, 1)
+	handled := make(chan protocol.PDU, 4)
+	addr := newTestServer(t,
+		WithHandleFunc[string](func(ctx context.Context, p protocol.PDU) ([]byte, error) {
+			handled <- p
+			if !IsSMPPReceiver[string](ctx) {
+				return nil, nil
+			}
+			return p.GenEmptyResponse().IEncode()
+		}),
+		WithSMPPOutbind[string](OutbindConfig{
+			SystemID: "esme",
+			Password: "pwd",
+			Authenticate: func(ctx context.Context, outbind *smpp34.Outbind) bool {
+				return outbind.Password == "secret"
+			},
+			OnBound: func(ctx context.Context, resp *smpp34.BindResp) {
+				bound <- IsSMPPReceiver[string](ctx)
+			},
+		}),
+	)
+
+	// rejected outbind
+	smsc := dialServer(t, addr)
+	smsc.send(t, &smpp34.Outbind{Header: smpp.Header{ID: smpp.OUTBIND, Sequence: 1}, SystemID: "smsc", Password: "bad"})
+	assert.Nil(t, smsc.read(t))
+
+	smsc = dialServer(t, addr)
+	smsc.send(t, &smpp34.Outbind{Header: smpp.Header{ID: smpp.OUTBIND, Sequence: 1}, SystemID: "smsc", Password: "secret"})
+	bind, ok := smsc.read(t).(*smpp34.Bind)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, smpp.BIND_RECEIVER, bind.Header.ID)
+	assert.Equal(t, "esme", bind.SystemID)
+	assert.Equal(t, "pwd", bind.Password)
+	assert.Equal(t, uint8(0x34), bind.InterfaceVersion)
+
+	smsc.send(t, &smpp34.BindResp{Header: smpp.Header{ID: smpp.BIND_RECEIVER_RESP, Sequence: bind.Sequence}, SystemID: "smsc"})
+	select {
+	case ok := <-bound:
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("not bound")
+	}
+
+	smsc.send(t, &smpp34.DeliverSm{Header: smpp.Header{ID: smpp.DELIVER_SM, Sequence: 2}, SmLength: 2, ShortMessage: []byte("hi")})
+	resp, ok := smsc.read(t).(*smpp34.DeliverSmResp)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint32(2), resp.Sequence)
+	}
+	assert.IsType(t, &smpp34.DeliverSm{}, <-handled)
+	assert.Len(t, handled, 0)
+
+	// failed bind_receiver_resp closes the connection
+	smsc = dialServer(t, addr)
+	smsc.send(t, &smpp34.Outbind{Header: smpp.Header{ID: smpp.OUTBIND, Sequence: 1}, SystemID: "smsc", Password: "secret"})
+	bind = smsc.read(t).(*smpp34.Bind)
+	smsc.send(t, &smpp34.BindResp{Header: smpp.Header{ID: smpp.BIND_RECEIVER_RESP, Status: smpp.ESME_RBINDFAIL, Sequence: bind.Sequence}})
+	assert.Nil(t, smsc.read(t))
+}
@@ -0,0 +1,219 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/packet"
+)
+
+// ErrInvalidPacket indicates that the data is shorter than the header of the protocol,
+// or its length field does not match.
+var ErrInvalidPacket = errors.New("invalid packet")
+
+// DecodeFunc decodes a complete packet into a PDU, e.g. cmpp30.DecodeCMPP30.
+// It returns ErrUnsupportedPacket for unknown command IDs.
+type DecodeFunc func(data []byte) (PDU, error)
+
+// PDUFactory returns a new empty PDU, which is then filled by IDecode.
+type PDUFactory func() PDU
+
+// versionKey identifies a version of a protocol.
+type versionKey struct {
+	protocol consts.Protocol
+	version  int
+}
+
+// commandKey identifies a command of a version of a protocol.
+type commandKey struct {
+	versionKey
+	commandID uint32
+}
+
+// registry holds the decoders of the protocol packages and the PDU factories registered by users.
+var registry = struct {
+	sync.RWMutex
+	decoders  map[versionKey]DecodeFunc
+	factories map[commandKey]PDUFactory
+}{
+	decoders:  make(map[versionKey]DecodeFunc),
+	factories: make(map[commandKey]PDUFactory),
+}
+
+func newVersionKey(proto consts.Protocol, version consts.Version) versionKey {
+	k := versionKey{protocol: proto}
+	if version != nil {
+		k.version = version.ToInt()
+	}
+	return k
+}
+
+// RegisterDecoder registers the decoder of a protocol version.
+// The protocol packages register their own decoders when they are imported,
+// e.g. cmpp30 registers DecodeCMPP30 for consts.CMPPVersion3_0.
+func RegisterDecoder(proto consts.Protocol, version consts.Version, decode DecodeFunc) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.decoders[newVersionKey(proto, version)] = decode
+}
+
+// RegisterPDU registers a factory for a command ID of a protocol version, e.g. for a vendor extension.
+// It takes precedence over the decoder registered with RegisterDecoder.
+func RegisterPDU(proto consts.Protocol, version consts.Version, commandID uint32, factory PDUFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.factories[commandKey{versionKey: newVersionKey(proto, version), commandID: commandID}] = factory
+}
+
+// Decode decodes a complete packet of a protocol version into a PDU.
+// The PDU is created by the factory registered with RegisterPDU, or else by the decoder of the version.
+// Command IDs unknown to both are decoded into a *RawPDU.
+//
+// The decoder of a version is only available after its package is imported, e.g.
+//
+//	import _ "github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+func Decode(proto consts.Protocol, version consts.Version, data []byte) (PDU, error) {
+	headerLength, ok := headerLengths[proto]
+	if !ok {
+		return nil, fmt.Errorf("unsupported protocol: %s", proto)
+	}
+	if len(data) < headerLength {
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidPacket, len(data))
+	}
+
+	vk := newVersionKey(proto, version)
+	commandID := binary.BigEndian.Uint32(data[4:8])
+
+	registry.RLock()
+	factory := registry.factories[commandKey{versionKey: vk, commandID: commandID}]
+	decode := registry.decoders[vk]
+	registry.RUnlock()
+
+	if factory != nil {
+		pdu := factory()
+		if err := pdu.IDecode(data); err != nil {
+			return nil, err
+		}
+		return pdu, nil
+	}
+
+	if decode != nil {
+		pdu, err := decode(data)
+		if !errors.Is(err, ErrUnsupportedPacket) {
+			return pdu, err
+		}
+	}
+
+	raw := &RawPDU{Protocol: proto, Version: version}
+	if err := raw.IDecode(data); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// headerLengths is the header length of each protocol.
+// The total length is always the first 4 bytes and the command ID the next 4 bytes.
+var headerLengths = map[consts.Protocol]int{
+	consts.ProtocolCMPP: 12,
+	consts.ProtocolSMPP: 16,
+	consts.ProtocolSGIP: 20,
+	consts.ProtocolSMGP: 12,
+}
+
+// RawCommand is the command ID of a RawPDU.
+type RawCommand uint32
+
+func (r RawCommand) String() string {
+	return fmt.Sprintf("UNKNOWN_COMMAND(0x%08X)", uint32(r))
+}
+
+func (r RawCommand) ToUint32() uint32 {
+	return uint32(r)
+}
+
+// RawPDU is a PDU whose command ID is unknown. It keeps the header and the body as they are,
+// so it can be logged, forwarded or encoded again.
+type RawPDU struct {
+	Protocol consts.Protocol
+	Version  consts.Version
+
+	// Header is the header of the protocol, including the total length and the command ID.
+	Header []byte
+
+	Body []byte
+}
+
+func (r *RawPDU) IDecode(data []byte) error {
+	headerLength := headerLengths[r.Protocol]
+	if headerLength == 0 || len(data) < headerLength {
+		return ErrInvalidPacket
+	}
+	if int(binary.BigEndian.Uint32(data[:4])) != len(data) {
+		return ErrInvalidPacket
+	}
+	r.Header = append([]byte(nil), data[:headerLength]...)
+	r.Body = append([]byte(nil), data[headerLength:]...)
+	return nil
+}
+
+// IEncode returns the header and the body, with the total length updated.
+func (r *RawPDU) IEncode() ([]byte, error) {
+	if len(r.Header) < headerLengths[r.Protocol] || len(r.Header) < 8 {
+		return nil, ErrInvalidPacket
+	}
+	data := make([]byte, 0, len(r.Header)+len(r.Body))
+	data = append(data, r.Header...)
+	data = append(data, r.Body...)
+	binary.BigEndian.PutUint32(data, uint32(len(data)))
+	return data, nil
+}
+
+// sequenceOffset returns the offset of the sequence ID in the header.
+// For SGIP it is the last part of the 3-part sequence number.
+func (r *RawPDU) sequenceOffset() int {
+	return headerLengths[r.Protocol] - 4
+}
+
+func (r *RawPDU) SetSequenceID(id uint32) {
+	if off := r.sequenceOffset(); off >= 8 && len(r.Header) >= off+4 {
+		binary.BigEndian.PutUint32(r.Header[off:], id)
+	}
+}
+
+func (r *RawPDU) GetSequenceID() uint32 {
+	if off := r.sequenceOffset(); off >= 8 && len(r.Header) >= off+4 {
+		return binary.BigEndian.Uint32(r.Header[off:])
+	}
+	return 0
+}
+
+func (r *RawPDU) GetCommand() ICommander {
+	if len(r.Header) < 8 {
+		return RawCommand(0)
+	}
+	return RawCommand(binary.BigEndian.Uint32(r.Header[4:8]))
+}
+
+// GenEmptyResponse returns nil, since the response of an unknown command is unknown.
+func (r *RawPDU) GenEmptyResponse() PDU {
+	return nil
+}
+
+func (r *RawPDU) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Protocol", r.Protocol)
+	if r.Version != nil {
+		w.Write("Version", r.Version.String())
+	}
+	w.Write("Command", r.GetCommand().String())
+	w.Write("SequenceID", r.GetSequenceID())
+	w.WriteWithBytes("Header", r.Header)
+	w.WriteWithBytes("Body", r.Body)
+
+	return w.String()
+}
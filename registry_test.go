@@ -0,0 +1,119 @@
+package protocol_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/cmpp"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp20"
+	"github.com/hujm2023/go-sms-protocol/cmpp/cmpp30"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+	"github.com/hujm2023/go-sms-protocol/sgip/sgip12"
+	"github.com/hujm2023/go-sms-protocol/smgp/smgp30"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+func TestDecode(t *testing.T) {
+	pdu, err := protocol.Decode(consts.ProtocolCMPP, consts.CMPPVersion3_0, cmpp20.NewActiveTestPacket(1))
+	assert.Nil(t, err)
+	assert.IsType(t, &cmpp30.ActiveTest{}, pdu)
+
+	pdu, err = protocol.Decode(consts.ProtocolCMPP, consts.CMPPVersion2_0, cmpp20.NewActiveTestPacket(1))
+	assert.Nil(t, err)
+	assert.IsType(t, &cmpp20.PduActiveTest{}, pdu)
+
+	pdu, err = protocol.Decode(consts.ProtocolSMPP, consts.SMPPVersion3_4, smpp34.NewEnquireLinkReqBytes(2))
+	assert.Nil(t, err)
+	assert.IsType(t, &smpp34.EnquireLink{}, pdu)
+
+	pdu, err = protocol.Decode(consts.ProtocolSMGP, consts.SMGPVersion3_0, smgp30.NewActiveTestPacket(3))
+	assert.Nil(t, err)
+	assert.IsType(t, &smgp30.ActiveTest{}, pdu)
+
+	data, _ := sgip12.NewBind("sp", "pwd", 1, 4).IEncode()
+	pdu, err = protocol.Decode(consts.ProtocolSGIP, consts.SGIPVersion1_2, data)
+	assert.Nil(t, err)
+	assert.IsType(t, &sgip12.Bind{}, pdu)
+
+	_, err = protocol.Decode(consts.ProtocolUnknown, nil, data)
+	assert.NotNil(t, err)
+	_, err = protocol.Decode(consts.ProtocolSGIP, consts.SGIPVersion1_2, data[:sgip.HeaderLength-1])
+	assert.True(t, errors.Is(err, protocol.ErrInvalidPacket))
+}
+
+func TestDecode_Raw(t *testing.T) {
+	data := []byte{
+		0x00, 0x00, 0x00, 0x10, // total length
+		0x00, 0x00, 0x00, 0x99, // unknown command
+		0x00, 0x00, 0x00, 0x07, // sequence id
+		0x01, 0x02, 0x03, 0x04, // body
+	}
+	pdu, err := protocol.Decode(consts.ProtocolCMPP, consts.CMPPVersion3_0, data)
+	if !assert.Nil(t, err) {
+		return
+	}
+	raw, ok := pdu.(*protocol.RawPDU)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, data[:cmpp.HeaderLength], raw.Header)
+	assert.Equal(t, []byte{1, 2, 3, 4}, raw.Body)
+	assert.Equal(t, uint32(0x99), raw.GetCommand().ToUint32())
+	assert.Equal(t, "UNKNOWN_COMMAND(0x00000099)", raw.GetCommand().String())
+	assert.Equal(t, uint32(7), raw.GetSequenceID())
+	assert.Nil(t, raw.GenEmptyResponse())
+
+	encoded, err := raw.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, data, encoded)
+
+	raw.SetSequenceID(8)
+	raw.Body = append(raw.Body, 5)
+	encoded, err = raw.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(17), binary.BigEndian.Uint32(encoded))
+	assert.Equal(t, uint32(8), binary.BigEndian.Uint32(encoded[8:]))
+
+	// the sequence id of SGIP is the last part of the sequence number
+	sgipData, _ := (&sgip12.Unbind{Header: sgip.NewHeader(0, sgip.SGIP_UNBIND, 1, 9)}).IEncode()
+	binary.BigEndian.PutUint32(sgipData[4:], 0x99)
+	pdu, err = protocol.Decode(consts.ProtocolSGIP, consts.SGIPVersion1_2, sgipData)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(9), pdu.GetSequenceID())
+
+	// the length field must match
+	_, err = protocol.Decode(consts.ProtocolCMPP, consts.CMPPVersion3_0, data[:15])
+	assert.True(t, errors.Is(err, protocol.ErrInvalidPacket))
+}
+
+// vendorReport is a vendor extension of CMPP 3.0.
+type vendorReport struct {
+	*protocol.RawPDU
+}
+
+func (v *vendorReport) GetCommand() protocol.ICommander {
+	return protocol.RawCommand(0x00000100)
+}
+
+func TestRegisterPDU(t *testing.T) {
+	protocol.RegisterPDU(consts.ProtocolCMPP, consts.CMPPVersion3_0, 0x00000100, func() protocol.PDU {
+		return &vendorReport{RawPDU: &protocol.RawPDU{Protocol: consts.ProtocolCMPP}}
+	})
+
+	data := []byte{0x00, 0x00, 0x00, 0x0d, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x01, 0xff}
+	pdu, err := protocol.Decode(consts.ProtocolCMPP, consts.CMPPVersion3_0, data)
+	assert.Nil(t, err)
+	if v, ok := pdu.(*vendorReport); assert.True(t, ok) {
+		assert.Equal(t, []byte{0xff}, v.Body)
+	}
+
+	// only for the registered version
+	pdu, err = protocol.Decode(consts.ProtocolCMPP, consts.CMPPVersion2_0, data)
+	assert.Nil(t, err)
+	assert.IsType(t, &protocol.RawPDU{}, pdu)
+}
@@ -20,6 +20,10 @@ const (
 	MaxBindLength = HeaderLength + 1 + 16 + 16 + 8
 
 	MaxRespLength = HeaderLength + 1 + 8
+
+	MaxUserRptLength = HeaderLength + 21 + 21 + 1 + 8
+
+	MaxTraceLength = HeaderLength + 12 + 21 + 8
 )
 
 // LoginType 登录类型
@@ -65,6 +69,14 @@ func (c CommandID) String() string {
 		return "SGIP_DELIVER_REP"
 	case SGIP_REPORT_REP:
 		return "SGIP_REPORT_REP"
+	case SGIP_USERRPT:
+		return "SGIP_USERRPT"
+	case SGIP_USERRPT_REP:
+		return "SGIP_USERRPT_REP"
+	case SGIP_TRACE:
+		return "SGIP_TRACE"
+	case SGIP_TRACE_REP:
+		return "SGIP_TRACE_REP"
 	}
 	return fmt.Sprintf("unknown(%d)", uint32(c))
 }
@@ -114,6 +126,11 @@ const (
 	SGIP_USERRPT_REP
 )
 
+const (
+	SGIP_TRACE     CommandID = 0x00001000 // 跟踪请求
+	SGIP_TRACE_REP CommandID = 0x80001000 // 跟踪响应
+)
+
 func (rs RespStatus) String() string {
 	switch rs {
 	case STAT_OK:
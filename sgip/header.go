@@ -71,6 +71,16 @@ func (p *Header) GetSequenceID() uint32 {
 	return p.Sequence[2]
 }
 
+// GetSequence 获取完整的序列号 {节点编号, 时间, 序号}
+func (p *Header) GetSequence() [3]uint32 {
+	return p.Sequence
+}
+
+// SetSequence 设置完整的序列号 {节点编号, 时间, 序号}
+func (p *Header) SetSequence(sequence [3]uint32) {
+	p.Sequence = sequence
+}
+
 // GetMsgId 获取SGIP的MsgId
 func (p *Header) GetMsgId() string {
 	return strconv.FormatUint(uint64(p.Sequence[2]), 10)
@@ -0,0 +1,174 @@
+package sgip12
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+)
+
+// Trace SP 或 SMG 用该命令请求跟踪某一条 MT 短消息的状态，通过测试连接（LoginType=11）发送
+type Trace struct {
+	sgip.Header
+
+	// body
+	// 12 字节 被跟踪 MT 短消息的命令序列号
+	SubmitSequence [3]uint32
+
+	// 21 字节 被跟踪 MT 短消息的目的手机号，手机号码前加“86”国别标志
+	UserNumber string
+
+	// 8 字节 保留，扩展用
+	Reserved string
+}
+
+func (p *Trace) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+	sgip.WriteHeaderNoLength(p.Header, b)
+	b.WriteUint32(p.SubmitSequence[0])
+	b.WriteUint32(p.SubmitSequence[1])
+	b.WriteUint32(p.SubmitSequence[2])
+	b.WriteFixedLenString(p.UserNumber, 21)
+	b.WriteFixedLenString(p.Reserved, 8)
+	return b.BytesWithLength()
+}
+
+func (p *Trace) IDecode(data []byte) error {
+	if len(data) < sgip.MinSGIPPduLength {
+		return sgip.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+	p.Header = sgip.ReadHeader(b)
+	p.SubmitSequence[0] = b.ReadUint32()
+	p.SubmitSequence[1] = b.ReadUint32()
+	p.SubmitSequence[2] = b.ReadUint32()
+	p.UserNumber = b.ReadCStringN(21)
+	p.Reserved = b.ReadCStringN(8)
+	return b.Error()
+}
+
+func (p *Trace) SetSequenceID(id uint32) {
+	p.Header.Sequence[2] = id
+}
+
+func (p *Trace) GetSequenceID() uint32 {
+	return p.Header.Sequence[2]
+}
+
+func (p *Trace) GetCommand() sms.ICommander {
+	return sgip.SGIP_TRACE
+}
+
+func (p *Trace) GenEmptyResponse() sms.PDU {
+	return &TraceResp{
+		Header: sgip.Header{
+			CommandID: sgip.SGIP_TRACE_REP,
+			Sequence:  p.Header.Sequence,
+		},
+	}
+}
+
+func (p *Trace) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("SubmitSequence", p.SubmitSequence)
+	w.Write("UserNumber", p.UserNumber)
+	w.Write("Reserved", p.Reserved)
+
+	return w.String()
+}
+
+// TraceNode 被跟踪短消息经过的一个节点的信息
+type TraceNode struct {
+	// 1 字节 Trace 命令在该节点是否成功接收。 0:接收成功 1:等待处理 其它:错误码
+	Result sgip.RespStatus
+
+	// 6 字节 节点编号
+	NodeID string
+
+	// 16 字节 被跟踪的短消息到达该节点时刻，格式为“yymmddhhmmss”
+	ReceiveTime string
+
+	// 16 字节 该节点发出被跟踪的短消息时刻，格式为“yymmddhhmmss”
+	SendTime string
+
+	// 8 字节 保留，扩展用
+	Reserved string
+}
+
+// TraceResp Trace 命令的应答。协议规定 Count 之后的各个字段（包括 Reserve）按节点重复
+type TraceResp struct {
+	sgip.Header
+
+	// 1 字节 被跟踪 MT 短消息经过的节点个数
+	Count uint8
+
+	// 每个节点的信息，共 Count 个
+	Nodes []TraceNode
+}
+
+func (p *TraceResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+	sgip.WriteHeaderNoLength(p.Header, b)
+	b.WriteUint8(p.Count)
+	for _, node := range p.Nodes {
+		b.WriteUint8(uint8(node.Result))
+		b.WriteFixedLenString(node.NodeID, 6)
+		b.WriteFixedLenString(node.ReceiveTime, 16)
+		b.WriteFixedLenString(node.SendTime, 16)
+		b.WriteFixedLenString(node.Reserved, 8)
+	}
+	return b.BytesWithLength()
+}
+
+func (p *TraceResp) IDecode(data []byte) error {
+	if len(data) < sgip.MinSGIPPduLength {
+		return sgip.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+	p.Header = sgip.ReadHeader(b)
+	p.Count = b.ReadUint8()
+	p.Nodes = make([]TraceNode, 0, p.Count)
+	for i := 0; i < int(p.Count); i++ {
+		var node TraceNode
+		node.Result = sgip.RespStatus(b.ReadUint8())
+		node.NodeID = b.ReadCStringN(6)
+		node.ReceiveTime = b.ReadCStringN(16)
+		node.SendTime = b.ReadCStringN(16)
+		node.Reserved = b.ReadCStringN(8)
+		p.Nodes = append(p.Nodes, node)
+	}
+	return b.Error()
+}
+
+func (p *TraceResp) SetSequenceID(id uint32) {
+	p.Header.Sequence[2] = id
+}
+
+func (p *TraceResp) GetSequenceID() uint32 {
+	return p.Header.Sequence[2]
+}
+
+func (p *TraceResp) GetCommand() sms.ICommander {
+	return sgip.SGIP_TRACE_REP
+}
+
+func (p *TraceResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (p *TraceResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("Count", p.Count)
+	w.Write("Nodes", p.Nodes)
+
+	return w.String()
+}
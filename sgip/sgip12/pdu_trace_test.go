@@ -0,0 +1,46 @@
+package sgip12
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/sgip"
+)
+
+func TestTrace(t *testing.T) {
+	trace := &Trace{
+		Header: sgip.Header{
+			CommandID: sgip.SGIP_TRACE,
+			Sequence:  [3]uint32{3057100001, 1017120000, 8},
+		},
+		SubmitSequence: [3]uint32{3057100001, 1017115959, 2},
+		UserNumber:     "8617611000000",
+	}
+	value, err := trace.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, sgip.MaxTraceLength, len(value))
+	assert.Equal(t, []byte{0x0, 0x0, 0x10, 0x0}, value[4:8])
+
+	pdu, err := DecodeSGIP12(value)
+	assert.Nil(t, err)
+	trace.TotalLength = uint32(sgip.MaxTraceLength)
+	assert.Equal(t, trace, pdu)
+
+	resp := pdu.GenEmptyResponse().(*TraceResp)
+	resp.Count = 2
+	resp.Nodes = []TraceNode{
+		{Result: 0, NodeID: "30571", ReceiveTime: "241017115959", SendTime: "241017120000"},
+		{Result: 1, NodeID: "30572", ReceiveTime: "241017120000"},
+	}
+	value, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, sgip.HeaderLength+1+2*47, len(value))
+
+	pdu, err = DecodeSGIP12(value)
+	assert.Nil(t, err)
+	resp.TotalLength = uint32(len(value))
+	assert.Equal(t, resp, pdu)
+	assert.Equal(t, "SGIP_TRACE_REP", pdu.GetCommand().String())
+	assert.Nil(t, pdu.GenEmptyResponse())
+}
@@ -0,0 +1,149 @@
+package sgip12
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/sgip"
+)
+
+// UserCondition 手机用户状态
+const (
+	UserConditionCancelled uint8 = iota // 注销
+	UserConditionSuspended              // 欠费停机
+	UserConditionNormal                 // 恢复正常
+)
+
+// UserRpt SMG 用该命令通知 SP 一条手机用户的状态信息
+type UserRpt struct {
+	sgip.Header
+
+	// body
+	// 21 字节 SP 的接入号码
+	SPNumber string
+
+	// 21 字节 待配置的手机号码，手机号码前加“86”国别标志
+	UserNumber string
+
+	// 1 字节 0:注销  1:欠费停机  2:恢复正常
+	UserCondition uint8
+
+	// 8 字节 保留，扩展用
+	Reserved string
+}
+
+func (p *UserRpt) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+	sgip.WriteHeaderNoLength(p.Header, b)
+	b.WriteFixedLenString(p.SPNumber, 21)
+	b.WriteFixedLenString(p.UserNumber, 21)
+	b.WriteUint8(p.UserCondition)
+	b.WriteFixedLenString(p.Reserved, 8)
+	return b.BytesWithLength()
+}
+
+func (p *UserRpt) IDecode(data []byte) error {
+	if len(data) < sgip.MinSGIPPduLength {
+		return sgip.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+	p.Header = sgip.ReadHeader(b)
+	p.SPNumber = b.ReadCStringN(21)
+	p.UserNumber = b.ReadCStringN(21)
+	p.UserCondition = b.ReadUint8()
+	p.Reserved = b.ReadCStringN(8)
+	return b.Error()
+}
+
+func (p *UserRpt) SetSequenceID(id uint32) {
+	p.Header.Sequence[2] = id
+}
+
+func (p *UserRpt) GetSequenceID() uint32 {
+	return p.Header.Sequence[2]
+}
+
+func (p *UserRpt) GetCommand() sms.ICommander {
+	return sgip.SGIP_USERRPT
+}
+
+func (p *UserRpt) GenEmptyResponse() sms.PDU {
+	return &UserRptResp{
+		Header: sgip.Header{
+			CommandID: sgip.SGIP_USERRPT_REP,
+			Sequence:  p.Header.Sequence,
+		},
+	}
+}
+
+func (p *UserRpt) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("SPNumber", p.SPNumber)
+	w.Write("UserNumber", p.UserNumber)
+	w.Write("UserCondition", p.UserCondition)
+	w.Write("Reserved", p.Reserved)
+
+	return w.String()
+}
+
+type UserRptResp struct {
+	sgip.Header
+
+	// 1 字节 UserRpt 命令执行结果 0:成功 其它:错误码
+	Result sgip.RespStatus
+
+	// 8 字节 保留，扩展用
+	Reserved string
+}
+
+func (p *UserRptResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+	sgip.WriteHeaderNoLength(p.Header, b)
+	b.WriteUint8(uint8(p.Result))
+	b.WriteFixedLenString(p.Reserved, 8)
+	return b.BytesWithLength()
+}
+
+func (p *UserRptResp) IDecode(data []byte) error {
+	if len(data) < sgip.MinSGIPPduLength {
+		return sgip.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+	p.Header = sgip.ReadHeader(b)
+	p.Result = sgip.RespStatus(b.ReadUint8())
+	p.Reserved = b.ReadCStringN(8)
+	return b.Error()
+}
+
+func (p *UserRptResp) SetSequenceID(id uint32) {
+	p.Header.Sequence[2] = id
+}
+
+func (p *UserRptResp) GetSequenceID() uint32 {
+	return p.Header.Sequence[2]
+}
+
+func (p *UserRptResp) GetCommand() sms.ICommander {
+	return sgip.SGIP_USERRPT_REP
+}
+
+func (p *UserRptResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (p *UserRptResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.Write("Result", p.Result)
+	w.Write("Reserved", p.Reserved)
+
+	return w.String()
+}
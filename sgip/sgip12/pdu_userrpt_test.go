@@ -0,0 +1,42 @@
+package sgip12
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/sgip"
+)
+
+func TestUserRpt(t *testing.T) {
+	rpt := &UserRpt{
+		Header: sgip.Header{
+			CommandID: sgip.SGIP_USERRPT,
+			Sequence:  [3]uint32{3057100001, 1017120000, 7},
+		},
+		SPNumber:      "10655000",
+		UserNumber:    "8617611000000",
+		UserCondition: UserConditionSuspended,
+	}
+	value, err := rpt.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, sgip.MaxUserRptLength, len(value))
+
+	pdu, err := DecodeSGIP12(value)
+	assert.Nil(t, err)
+	rpt.TotalLength = uint32(sgip.MaxUserRptLength)
+	assert.Equal(t, rpt, pdu)
+	assert.Equal(t, "SGIP_USERRPT", pdu.GetCommand().String())
+
+	resp := pdu.GenEmptyResponse()
+	assert.Equal(t, rpt.Sequence, resp.(*UserRptResp).Sequence)
+	value, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, sgip.MaxRespLength, len(value))
+
+	pdu, err = DecodeSGIP12(value)
+	assert.Nil(t, err)
+	assert.Equal(t, sgip.SGIP_USERRPT_REP, pdu.GetCommand())
+	assert.Equal(t, uint32(7), pdu.GetSequenceID())
+	assert.Nil(t, pdu.GenEmptyResponse())
+}
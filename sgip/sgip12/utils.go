@@ -4,6 +4,7 @@ import (
 	"time"
 
 	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/consts"
 	"github.com/hujm2023/go-sms-protocol/sgip"
 )
 
@@ -22,6 +23,22 @@ func NewBind(account, passwd string, nodeID, seqID uint32) *Bind {
 	return connectPdu
 }
 
+// NewUnbindPacket creates a new Unbind PDU and encodes it into a byte slice.
+func NewUnbindPacket(nodeID, seqID uint32) []byte {
+	pdu := &Unbind{
+		Header: sgip.Header{
+			CommandID: sgip.SGIP_UNBIND,
+			Sequence:  [3]uint32{nodeID, sgip.Timestamp(time.Now()), seqID},
+		},
+	}
+	data, _ := pdu.IEncode()
+	return data
+}
+
+func init() {
+	sms.RegisterDecoder(consts.ProtocolSGIP, consts.SGIPVersion1_2, DecodeSGIP12)
+}
+
 // DecodeSGIP12 解析对应 sgip12 指令
 func DecodeSGIP12(data []byte) (sms.PDU, error) {
 	header, err := sgip.PeekHeader(data)
@@ -37,6 +54,8 @@ func DecodeSGIP12(data []byte) (sms.PDU, error) {
 		pdu = new(BindResp)
 	case sgip.SGIP_UNBIND:
 		pdu = new(Unbind)
+	case sgip.SGIP_UNBIND_REP:
+		pdu = new(UnbindResp)
 	case sgip.SGIP_SUBMIT:
 		pdu = new(Submit)
 	case sgip.SGIP_SUBMIT_REP:
@@ -49,6 +68,14 @@ func DecodeSGIP12(data []byte) (sms.PDU, error) {
 		pdu = new(Deliver)
 	case sgip.SGIP_DELIVER_REP:
 		pdu = new(DeliverResp)
+	case sgip.SGIP_USERRPT:
+		pdu = new(UserRpt)
+	case sgip.SGIP_USERRPT_REP:
+		pdu = new(UserRptResp)
+	case sgip.SGIP_TRACE:
+		pdu = new(Trace)
+	case sgip.SGIP_TRACE_REP:
+		pdu = new(TraceResp)
 	}
 
 	if pdu == nil {
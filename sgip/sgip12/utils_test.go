@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/sgip"
 )
 
 func TestNewBind(t *testing.T) {
@@ -35,3 +37,13 @@ func TestNewBind(t *testing.T) {
 		})
 	}
 }
+
+func TestNewUnbindPacket(t *testing.T) {
+	pdu, err := DecodeSGIP12(NewUnbindPacket(3, 7))
+	if assert.Nil(t, err) && assert.IsType(t, &Unbind{}, pdu) {
+		unbind := pdu.(*Unbind)
+		assert.Equal(t, uint32(sgip.HeaderLength), unbind.TotalLength)
+		assert.Equal(t, uint32(3), unbind.Sequence[0])
+		assert.Equal(t, uint32(7), unbind.GetSequenceID())
+	}
+}
@@ -12,6 +12,13 @@ const (
 
 	MaxActiveTestRespLength = 13
 	MaxDeliverRespLength    = HeaderLength + 10 + 4
+
+	MaxQueryLength            = HeaderLength + 8 + 1 + 10
+	MaxQueryRespLength        = HeaderLength + 8 + 1 + 10 + 4*8 + 8
+	MaxQueryRouteLength       = HeaderLength + 6 + 21
+	MaxQueryTERouteRespLength = HeaderLength + 4 + 4 + 6 + 15 + 2 + 10 + 4 + 1 + 14
+	MaxQuerySPRouteRespLength = HeaderLength + 4 + 4 + 6 + 15 + 2 + 8 + 21 + 1 + 1 + 14
+	MinFwdRespLength          = HeaderLength + 10 + 4
 )
 
 type CommandID uint32
@@ -38,6 +45,30 @@ func (c CommandID) String() string {
 		return "SMGP_EXIT"
 	case CommandExitResp:
 		return "SMGP_EXIT_RESP"
+	case CommandFwd:
+		return "SMGP_FORWARD"
+	case CommandFwdResp:
+		return "SMGP_FORWARD_RESP"
+	case CommandQuery:
+		return "SMGP_QUERY"
+	case CommandQueryResp:
+		return "SMGP_QUERY_RESP"
+	case CommandQueryTERoute:
+		return "SMGP_QUERY_TE_ROUTE"
+	case CommandQueryTERouteResp:
+		return "SMGP_QUERY_TE_ROUTE_RESP"
+	case CommandQuerySPRoute:
+		return "SMGP_QUERY_SP_ROUTE"
+	case CommandQuerySPRouteResp:
+		return "SMGP_QUERY_SP_ROUTE_RESP"
+	case CommandPaymentRequest:
+		return "SMGP_PAYMENT_REQUEST"
+	case CommandPaymentRequestResp:
+		return "SMGP_PAYMENT_REQUEST_RESP"
+	case CommandPaymentAffirm:
+		return "SMGP_PAYMENT_AFFIRM"
+	case CommandPaymentAffirmResp:
+		return "SMGP_PAYMENT_AFFIRM_RESP"
 	}
 	return fmt.Sprintf("unknown(%d)", uint32(c))
 }
@@ -59,18 +90,23 @@ const (
 	CommandQueryTERoute             // 查询TE路由
 	CommandQuerySPRoute             // 查询SP路由
 	CommandPaymentRequest           // 扣款请求
-
+	CommandPaymentAffirm            // 扣款确认
 )
 
 // server 应答
 const (
-	CommandResponseNone   CommandID = 0x80000000 + iota
-	CommandLoginResp                // 登陆应答
-	CommandSubmitResp               // 提交短信应答
-	CommandDeliverResp              // 短信下发应答
-	CommandActiveTestResp           // 激活测试应答
-	CommandFwdResp                  // 消息前转应答
-	CommandExitResp                 // 终止连接应答
+	CommandResponseNone       CommandID = 0x80000000 + iota
+	CommandLoginResp                    // 登陆应答
+	CommandSubmitResp                   // 提交短信应答
+	CommandDeliverResp                  // 短信下发应答
+	CommandActiveTestResp               // 激活测试应答
+	CommandFwdResp                      // 消息前转应答
+	CommandExitResp                     // 终止连接应答
+	CommandQueryResp                    // SP统计查询应答
+	CommandQueryTERouteResp             // 查询TE路由应答
+	CommandQuerySPRouteResp             // 查询SP路由应答
+	CommandPaymentRequestResp           // 扣款请求应答
+	CommandPaymentAffirmResp            // 扣款确认应答
 )
 
 var ErrInvalidPudLength = errors.New("invalid pdu length")
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/hujm2023/go-sms-protocol/packet"
 )
@@ -39,6 +40,9 @@ type Option struct {
 	Tag        uint16
 	Length     uint16
 	ValueBytes []byte
+
+	// order 为 Option 加入 Options 的顺序，从 1 开始，Serialize 时按此顺序输出
+	order uint32
 }
 
 func NewOption(tag Tag, value []byte) Option {
@@ -76,26 +80,72 @@ func (o Option) IsEmpty() bool {
 // ---------------------------------------------------------------------------------------
 
 // 可选参数 map
+// 通过 Add/Set 或 ParseOptions/ReadOptions 加入的 Option 按加入顺序输出；
+// 直接赋值到 map 中的 Option 排在最前面，按 Tag 升序输出。
 type Options map[Tag]Option
 
+// Add 添加或替换一个 Option，被替换的 Option 保留原来的位置。o 为 nil 时不生效，请使用 Set
 func (o Options) Add(opt Option) {
 	if o == nil {
-		o = make(Options)
+		return
 	}
 
+	if old, ok := o[Tag(opt.Tag)]; ok && old.order > 0 {
+		opt.order = old.order
+	} else {
+		opt.order = o.maxOrder() + 1
+	}
 	o[Tag(opt.Tag)] = opt
 }
 
+// Set 同 Add，o 为 nil 时会先初始化
+func (o *Options) Set(opt Option) {
+	if *o == nil {
+		*o = make(Options)
+	}
+	o.Add(opt)
+}
+
+// Del 删除指定 Tag 的 Option
+func (o Options) Del(tag Tag) {
+	delete(o, tag)
+}
+
+func (o Options) maxOrder() uint32 {
+	var max uint32
+	for _, v := range o {
+		if v.order > max {
+			max = v.order
+		}
+	}
+	return max
+}
+
+// Sorted 按输出顺序返回所有 Option
+func (o Options) Sorted() []Option {
+	list := make([]Option, 0, len(o))
+	for _, v := range o {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].order != list[j].order {
+			return list[i].order < list[j].order
+		}
+		return list[i].Tag < list[j].Tag
+	})
+	return list
+}
+
 func (o Options) String() string {
 	if len(o) == 0 {
 		return ""
 	}
 	s := "\n"
-	for idx := range o {
-		if o[idx].IsEmpty() {
+	for _, opt := range o.Sorted() {
+		if opt.IsEmpty() {
 			continue
 		}
-		s += fmt.Sprintf("\t%s\n", o[idx].String())
+		s += fmt.Sprintf("\t%s\n", opt.String())
 	}
 
 	return s
@@ -113,13 +163,14 @@ func (o Options) Len() int {
 }
 
 func (o Options) Serialize() []byte {
-	b := make([]byte, 0)
-	for _, v := range o {
+	b := make([]byte, 0, o.Len())
+	for _, v := range o.Sorted() {
 		b = append(b, v.Bytes()...)
 	}
 	return b
 }
 
+// TP_udhi 返回 TP_udhi 的值，不存在时返回 0，见 TPUdhi
 func (o Options) TP_udhi() uint8 {
 	if val, exist := o[TAG_TP_udhi]; exist {
 		return val.ValueBytes[0]
@@ -152,11 +203,11 @@ func ParseOptions(rawData []byte) (Options, error) {
 		value := rawData[p : p+int(vlen)]
 		p += int(vlen)
 
-		ops[Tag(tag)] = Option{
+		ops.Add(Option{
 			Tag:        tag,
 			Length:     vlen,
 			ValueBytes: value,
-		}
+		})
 	}
 
 	return ops, nil
@@ -202,11 +253,11 @@ func ReadOptions(r *packet.Reader) Options {
 			return nil
 		}
 
-		options[Tag(tag)] = Option{
+		options.Add(Option{
 			Tag:        tag,
 			Length:     length,
 			ValueBytes: value,
-		}
+		})
 	}
 
 	return options
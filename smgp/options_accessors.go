@@ -0,0 +1,279 @@
+package smgp
+
+import (
+	"bytes"
+	"fmt"
+)
+
+var tagNames = map[Tag]string{
+	TAG_TP_pid:           "TP_pid",
+	TAG_TP_udhi:          "TP_udhi",
+	TAG_LinkID:           "LinkID",
+	TAG_ChargeUserType:   "ChargeUserType",
+	TAG_ChargeTermType:   "ChargeTermType",
+	TAG_ChargeTermPseudo: "ChargeTermPseudo",
+	TAG_DestTermType:     "DestTermType",
+	TAG_DestTermPseudo:   "DestTermPseudo",
+	TAG_PkTotal:          "PkTotal",
+	TAG_PkNumber:         "PkNumber",
+	TAG_SubmitMsgType:    "SubmitMsgType",
+	TAG_SPDealResult:     "SPDealResult",
+	TAG_SrcTermType:      "SrcTermType",
+	TAG_SrcTermPseudo:    "SrcTermPseudo",
+	TAG_NodesCount:       "NodesCount",
+	TAG_MsgSrc:           "MsgSrc",
+	TAG_SrcType:          "SrcType",
+	TAG_MServiceID:       "MServiceID",
+}
+
+func (t Tag) String() string {
+	if name, ok := tagNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(0x%04x)", uint16(t))
+}
+
+// 定长 Octet String 类型可选参数的 Value 长度
+const (
+	LinkIDLength     = 20
+	MsgSrcLength     = 8
+	MServiceIDLength = 21
+)
+
+// ChargeUserType 计费用户类型
+const (
+	ChargeUserTypeDest    uint8 = iota // 对短消息接收方计费
+	ChargeUserTypeSrc                  // 对短消息发送方计费
+	ChargeUserTypeSP                   // 对 SP 计费
+	ChargeUserTypeInvalid              // 本字段无效，对谁计费参见 ChargeTermID 或 ChargeTermPseudo
+)
+
+// TermType 号码类型，用于 ChargeTermType、DestTermType、SrcTermType 和 SrcType
+const (
+	TermTypeReal   uint8 = iota // 真实号码
+	TermTypePseudo              // 伪码
+)
+
+// SubmitMsgType SP 发送的消息类型
+const (
+	SubmitMsgTypeNormal           uint8 = iota // 普通短消息
+	SubmitMsgTypeWebSubscribe                  // WEB 方式订阅通知消息
+	SubmitMsgTypeWebUnsubscribe                // WEB 方式取消订阅通知消息
+	SubmitMsgTypeTermSubscribe                 // 终端方式订阅通知消息
+	SubmitMsgTypeTermUnsubscribe               // 终端方式取消订阅通知消息
+	SubmitMsgTypeMonthlyFeeNotify              // 包月扣费通知消息
+)
+
+// SPDealResult SP 对消息的处理结果，SubmitMsgType 为 0 时无效
+const (
+	SPDealResultSuccess uint8 = iota // 订阅/取消订阅/扣费成功
+	SPDealResultFailure              // 订阅/取消订阅/扣费失败
+)
+
+// Uint8 返回 1 字节 Integer 类型可选参数的值
+func (o Options) Uint8(tag Tag) (uint8, bool) {
+	opt, ok := o[tag]
+	if !ok || len(opt.ValueBytes) != 1 {
+		return 0, false
+	}
+	return opt.ValueBytes[0], true
+}
+
+// OctetString 返回 Octet String 类型可选参数的值，去掉末尾补齐的 0x00
+func (o Options) OctetString(tag Tag) (string, bool) {
+	opt, ok := o[tag]
+	if !ok {
+		return "", false
+	}
+	return string(bytes.TrimRight(opt.ValueBytes, "\x00")), true
+}
+
+// Octets 返回可选参数的原始值
+func (o Options) Octets(tag Tag) ([]byte, bool) {
+	opt, ok := o[tag]
+	if !ok {
+		return nil, false
+	}
+	return opt.ValueBytes, true
+}
+
+func (o *Options) SetUint8(tag Tag, v uint8) {
+	o.Set(NewOption(tag, []byte{v}))
+}
+
+// SetOctetString 设置 Octet String 类型的可选参数，n > 0 时按定长 n 左对齐、不足补 0x00，超出截断
+func (o *Options) SetOctetString(tag Tag, v string, n int) {
+	if n <= 0 {
+		o.Set(NewOption(tag, []byte(v)))
+		return
+	}
+	b := make([]byte, n)
+	copy(b, v)
+	o.Set(NewOption(tag, b))
+}
+
+func (o *Options) SetOctets(tag Tag, v []byte) {
+	o.Set(NewOption(tag, v))
+}
+
+// TPPid GSM 协议类型
+func (o Options) TPPid() (uint8, bool) {
+	return o.Uint8(TAG_TP_pid)
+}
+
+func (o *Options) SetTPPid(v uint8) {
+	o.SetUint8(TAG_TP_pid, v)
+}
+
+// TPUdhi GSM 协议类型，仅使用 1 位，右对齐
+func (o Options) TPUdhi() (uint8, bool) {
+	return o.Uint8(TAG_TP_udhi)
+}
+
+func (o *Options) SetTPUdhi(v uint8) {
+	o.SetUint8(TAG_TP_udhi, v)
+}
+
+// LinkID 交易标识，用于唯一标识一次交易
+func (o Options) LinkID() (string, bool) {
+	return o.OctetString(TAG_LinkID)
+}
+
+func (o *Options) SetLinkID(v string) {
+	o.SetOctetString(TAG_LinkID, v, LinkIDLength)
+}
+
+// ChargeUserType 计费用户类型
+func (o Options) ChargeUserType() (uint8, bool) {
+	return o.Uint8(TAG_ChargeUserType)
+}
+
+func (o *Options) SetChargeUserType(v uint8) {
+	o.SetUint8(TAG_ChargeUserType, v)
+}
+
+// ChargeTermType 计费用户的号码类型
+func (o Options) ChargeTermType() (uint8, bool) {
+	return o.Uint8(TAG_ChargeTermType)
+}
+
+func (o *Options) SetChargeTermType(v uint8) {
+	o.SetUint8(TAG_ChargeTermType, v)
+}
+
+// ChargeTermPseudo 计费用户的伪码
+func (o Options) ChargeTermPseudo() (string, bool) {
+	return o.OctetString(TAG_ChargeTermPseudo)
+}
+
+func (o *Options) SetChargeTermPseudo(v string) {
+	o.SetOctetString(TAG_ChargeTermPseudo, v, 0)
+}
+
+// DestTermType 短消息接收方的号码类型
+func (o Options) DestTermType() (uint8, bool) {
+	return o.Uint8(TAG_DestTermType)
+}
+
+func (o *Options) SetDestTermType(v uint8) {
+	o.SetUint8(TAG_DestTermType, v)
+}
+
+// DestTermPseudo 短消息接收方的伪码，有多个接收方伪码时，每个伪码的长度相同
+func (o Options) DestTermPseudo() (string, bool) {
+	return o.OctetString(TAG_DestTermPseudo)
+}
+
+func (o *Options) SetDestTermPseudo(v string) {
+	o.SetOctetString(TAG_DestTermPseudo, v, 0)
+}
+
+// PkTotal 相同 MsgID 的消息总条数
+func (o Options) PkTotal() (uint8, bool) {
+	return o.Uint8(TAG_PkTotal)
+}
+
+func (o *Options) SetPkTotal(v uint8) {
+	o.SetUint8(TAG_PkTotal, v)
+}
+
+// PkNumber 相同 MsgID 的消息序号，从 1 开始
+func (o Options) PkNumber() (uint8, bool) {
+	return o.Uint8(TAG_PkNumber)
+}
+
+func (o *Options) SetPkNumber(v uint8) {
+	o.SetUint8(TAG_PkNumber, v)
+}
+
+// SubmitMsgType SP 发送的消息类型
+func (o Options) SubmitMsgType() (uint8, bool) {
+	return o.Uint8(TAG_SubmitMsgType)
+}
+
+func (o *Options) SetSubmitMsgType(v uint8) {
+	o.SetUint8(TAG_SubmitMsgType, v)
+}
+
+// SPDealResult SP 对消息的处理结果
+func (o Options) SPDealResult() (uint8, bool) {
+	return o.Uint8(TAG_SPDealResult)
+}
+
+func (o *Options) SetSPDealResult(v uint8) {
+	o.SetUint8(TAG_SPDealResult, v)
+}
+
+// SrcTermType 短消息发送方的号码类型
+func (o Options) SrcTermType() (uint8, bool) {
+	return o.Uint8(TAG_SrcTermType)
+}
+
+func (o *Options) SetSrcTermType(v uint8) {
+	o.SetUint8(TAG_SrcTermType, v)
+}
+
+// SrcTermPseudo 短消息发送方的伪码
+func (o Options) SrcTermPseudo() (string, bool) {
+	return o.OctetString(TAG_SrcTermPseudo)
+}
+
+func (o *Options) SetSrcTermPseudo(v string) {
+	o.SetOctetString(TAG_SrcTermPseudo, v, 0)
+}
+
+// NodesCount 经过的网关数量，初始值为 1
+func (o Options) NodesCount() (uint8, bool) {
+	return o.Uint8(TAG_NodesCount)
+}
+
+func (o *Options) SetNodesCount(v uint8) {
+	o.SetUint8(TAG_NodesCount, v)
+}
+
+// MsgSrc 信息内容的来源。固定网业务中填写 SP 的服务代码，移动网业务中填写 SP 的企业代码
+func (o Options) MsgSrc() (string, bool) {
+	return o.OctetString(TAG_MsgSrc)
+}
+
+func (o *Options) SetMsgSrc(v string) {
+	o.SetOctetString(TAG_MsgSrc, v, MsgSrcLength)
+}
+
+// SrcType 传递给 SP 的源号码的类型
+func (o Options) SrcType() (uint8, bool) {
+	return o.Uint8(TAG_SrcType)
+}
+
+func (o *Options) SetSrcType(v uint8) {
+	o.SetUint8(TAG_SrcType, v)
+}
+
+// MServiceID 业务代码，用于移动网业务，填写产品 ID（Productid）
+func (o Options) MServiceID() (string, bool) {
+	return o.OctetString(TAG_MServiceID)
+}
+
+func (o *Options) SetMServiceID(v string) {
+	o.SetOctetString(TAG_MServiceID, v, MServiceIDLength)
+}
@@ -92,3 +92,111 @@ func TestOptionsTP_udhi(t *testing.T) {
 	expectedDefaultValue := uint8(0)
 	assert.Equal(t, expectedDefaultValue, options.TP_udhi())
 }
+
+func TestOptions_Order(t *testing.T) {
+	rawData := []byte{
+		0x00, 0x09, 0x00, 0x01, 0x02, // TAG_PkTotal
+		0x00, 0xF0, 0x00, 0x02, 0x01, 0x02, // unknown tag
+		0x00, 0x01, 0x00, 0x01, 0x00, // TAG_TP_pid
+	}
+
+	options, err := ParseOptions(rawData)
+	assert.NoError(t, err)
+	assert.Len(t, options, 3)
+	assert.Equal(t, []byte{0x01, 0x02}, options[Tag(0xF0)].ValueBytes)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, rawData, options.Serialize())
+	}
+
+	// 替换保留原位置，新增的排在最后
+	options.SetPkTotal(3)
+	options.SetPkNumber(1)
+	assert.Equal(t, []Tag{TAG_PkTotal, Tag(0xF0), TAG_TP_pid, TAG_PkNumber}, sortedTags(options))
+
+	options.Del(Tag(0xF0))
+	assert.Equal(t, []Tag{TAG_PkTotal, TAG_TP_pid, TAG_PkNumber}, sortedTags(options))
+}
+
+func sortedTags(o Options) []Tag {
+	tags := make([]Tag, 0, len(o))
+	for _, opt := range o.Sorted() {
+		tags = append(tags, Tag(opt.Tag))
+	}
+	return tags
+}
+
+func TestOptions_Accessors(t *testing.T) {
+	var options Options
+
+	_, ok := options.LinkID()
+	assert.False(t, ok)
+
+	options.SetTPPid(1)
+	options.SetTPUdhi(1)
+	options.SetLinkID("link-1")
+	options.SetChargeUserType(ChargeUserTypeSP)
+	options.SetChargeTermType(TermTypePseudo)
+	options.SetChargeTermPseudo("pseudo-charge")
+	options.SetDestTermType(TermTypeReal)
+	options.SetDestTermPseudo("pseudo-dest")
+	options.SetPkTotal(2)
+	options.SetPkNumber(1)
+	options.SetSubmitMsgType(SubmitMsgTypeMonthlyFeeNotify)
+	options.SetSPDealResult(SPDealResultFailure)
+	options.SetSrcTermType(TermTypePseudo)
+	options.SetSrcTermPseudo("pseudo-src")
+	options.SetNodesCount(1)
+	options.SetMsgSrc("12345678")
+	options.SetSrcType(TermTypeReal)
+	options.SetMServiceID("PID0001")
+	assert.Len(t, options, 18)
+
+	assert.Equal(t, LinkIDLength, options[TAG_LinkID].Len())
+	assert.Equal(t, MServiceIDLength, options[TAG_MServiceID].Len())
+
+	parsed, err := ParseOptions(options.Serialize())
+	assert.NoError(t, err)
+	assert.Equal(t, options, parsed)
+
+	for _, c := range []struct {
+		get  func() (uint8, bool)
+		want uint8
+	}{
+		{parsed.TPPid, 1},
+		{parsed.TPUdhi, 1},
+		{parsed.ChargeUserType, ChargeUserTypeSP},
+		{parsed.ChargeTermType, TermTypePseudo},
+		{parsed.DestTermType, TermTypeReal},
+		{parsed.PkTotal, 2},
+		{parsed.PkNumber, 1},
+		{parsed.SubmitMsgType, SubmitMsgTypeMonthlyFeeNotify},
+		{parsed.SPDealResult, SPDealResultFailure},
+		{parsed.SrcTermType, TermTypePseudo},
+		{parsed.NodesCount, 1},
+		{parsed.SrcType, TermTypeReal},
+	} {
+		v, ok := c.get()
+		assert.True(t, ok)
+		assert.Equal(t, c.want, v)
+	}
+
+	for _, c := range []struct {
+		get  func() (string, bool)
+		want string
+	}{
+		{parsed.LinkID, "link-1"},
+		{parsed.ChargeTermPseudo, "pseudo-charge"},
+		{parsed.DestTermPseudo, "pseudo-dest"},
+		{parsed.SrcTermPseudo, "pseudo-src"},
+		{parsed.MsgSrc, "12345678"},
+		{parsed.MServiceID, "PID0001"},
+	} {
+		v, ok := c.get()
+		assert.True(t, ok)
+		assert.Equal(t, c.want, v)
+	}
+
+	assert.Equal(t, uint8(1), parsed.TP_udhi())
+	assert.Equal(t, "MServiceID", TAG_MServiceID.String())
+	assert.Equal(t, "unknown(0x00f0)", Tag(0xF0).String())
+}
@@ -19,3 +19,9 @@ func TestPduExit(t *testing.T) {
 	assert.Equal(t, smgp.CommandExit, tt.CommandID)
 	assert.Equal(t, uint32(1234), tt.SequenceID)
 }
+
+func TestNewExitPacket(t *testing.T) {
+	assert.Equal(t, []byte{
+		0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x0, 0x4, 0xd2,
+	}, NewExitPacket(1234))
+}
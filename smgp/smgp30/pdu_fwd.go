@@ -0,0 +1,266 @@
+package smgp30
+
+import (
+	"encoding/hex"
+
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+// Fwd 网关之间转发短消息（Forward），用于 SMGW 之间转发 MT/MO 短消息及状态报告
+type Fwd struct {
+	smgp.Header
+
+	// 10 字节，短消息流水号，格式同 Deliver.MsgID，这里以 16 进制字符串表示
+	MsgID string
+
+	// 6 字节，目的 SMGW 代码
+	DestSMGWNo string
+
+	// 6 字节，源 SMGW 代码
+	SrcSMGWNo string
+
+	// 6 字节，短消息中心代码
+	SMCNo string
+
+	// 短消息类型
+	MsgType uint8
+
+	// 状态报告标志
+	ReportFlag uint8
+
+	// 短消息发送优先级
+	Priority uint8
+
+	// 业务代码 10
+	ServiceID string
+
+	// 收费类型 2
+	FeeType string
+
+	// 资费代码 6
+	FeeCode string
+
+	// 包月费/封顶费 6
+	FixedFee string
+
+	// 短消息格式
+	MsgFormat uint8
+
+	// 短消息有效时间 17
+	ValidTime string
+
+	// 短消息定时发送时间 17
+	AtTime string
+
+	// 短消息发送用户号码 21
+	SrcTermID string
+
+	// 短消息接收用户号码 21
+	DestTermID string
+
+	// 计费用户号码 21
+	ChargeTermID string
+
+	// 短消息长度
+	MsgLength uint8
+
+	// 短消息内容
+	MsgContent []byte
+
+	// 保留
+	Reserve string
+
+	// 可选字段
+	Options smgp.Options
+}
+
+func (f *Fwd) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	f.Header = smgp.ReadHeader(b)
+	f.MsgID = hex.EncodeToString([]byte(b.ReadCStringNWithoutTrim(10)))
+	f.DestSMGWNo = b.ReadCStringN(6)
+	f.SrcSMGWNo = b.ReadCStringN(6)
+	f.SMCNo = b.ReadCStringN(6)
+	f.MsgType = b.ReadUint8()
+	f.ReportFlag = b.ReadUint8()
+	f.Priority = b.ReadUint8()
+	f.ServiceID = b.ReadCStringN(10)
+	f.FeeType = b.ReadCStringN(2)
+	f.FeeCode = b.ReadCStringN(6)
+	f.FixedFee = b.ReadCStringN(6)
+	f.MsgFormat = b.ReadUint8()
+	f.ValidTime = b.ReadCStringN(17)
+	f.AtTime = b.ReadCStringN(17)
+	f.SrcTermID = b.ReadCStringN(21)
+	f.DestTermID = b.ReadCStringN(21)
+	f.ChargeTermID = b.ReadCStringN(21)
+	f.MsgLength = b.ReadUint8()
+	f.MsgContent = b.ReadNBytes(int(f.MsgLength))
+	f.Reserve = b.ReadCStringN(8)
+	f.Options = smgp.ReadOptions(b)
+
+	return b.Error()
+}
+
+func (f *Fwd) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(f.Header, b)
+	msgID, err := hex.DecodeString(f.MsgID)
+	if err != nil {
+		return nil, err
+	}
+	b.WriteBytes(msgID)
+	b.WriteFixedLenString(f.DestSMGWNo, 6)
+	b.WriteFixedLenString(f.SrcSMGWNo, 6)
+	b.WriteFixedLenString(f.SMCNo, 6)
+	b.WriteUint8(f.MsgType)
+	b.WriteUint8(f.ReportFlag)
+	b.WriteUint8(f.Priority)
+	b.WriteFixedLenString(f.ServiceID, 10)
+	b.WriteFixedLenString(f.FeeType, 2)
+	b.WriteFixedLenString(f.FeeCode, 6)
+	b.WriteFixedLenString(f.FixedFee, 6)
+	b.WriteUint8(f.MsgFormat)
+	b.WriteFixedLenString(f.ValidTime, 17)
+	b.WriteFixedLenString(f.AtTime, 17)
+	b.WriteFixedLenString(f.SrcTermID, 21)
+	b.WriteFixedLenString(f.DestTermID, 21)
+	b.WriteFixedLenString(f.ChargeTermID, 21)
+	b.WriteUint8(f.MsgLength)
+	b.WriteBytes(f.MsgContent)
+	b.WriteFixedLenString(f.Reserve, 8)
+	b.WriteBytes(f.Options.Serialize())
+
+	return b.BytesWithLength()
+}
+
+func (f *Fwd) SetSequenceID(id uint32) {
+	f.Header.SequenceID = id
+}
+
+func (f *Fwd) GetSequenceID() uint32 {
+	return f.Header.SequenceID
+}
+
+func (f *Fwd) GetCommand() sms.ICommander {
+	return smgp.CommandFwd
+}
+
+func (f *Fwd) GenEmptyResponse() sms.PDU {
+	return &FwdResp{
+		Header: smgp.NewHeader(smgp.MinFwdRespLength, smgp.CommandFwdResp, f.GetSequenceID()),
+		MsgID:  f.MsgID,
+	}
+}
+
+func (f *Fwd) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", f.Header)
+	w.Write("MsgID", f.MsgID)
+	w.Write("DestSMGWNo", f.DestSMGWNo)
+	w.Write("SrcSMGWNo", f.SrcSMGWNo)
+	w.Write("SMCNo", f.SMCNo)
+	w.Write("MsgType", f.MsgType)
+	w.Write("ReportFlag", f.ReportFlag)
+	w.Write("Priority", f.Priority)
+	w.Write("ServiceID", f.ServiceID)
+	w.Write("FeeType", f.FeeType)
+	w.Write("FeeCode", f.FeeCode)
+	w.Write("FixedFee", f.FixedFee)
+	w.Write("MsgFormat", f.MsgFormat)
+	w.Write("ValidTime", f.ValidTime)
+	w.Write("AtTime", f.AtTime)
+	w.Write("SrcTermID", f.SrcTermID)
+	w.Write("DestTermID", f.DestTermID)
+	w.Write("ChargeTermID", f.ChargeTermID)
+	w.Write("MsgLength", f.MsgLength)
+	w.WriteWithBytes("MsgContent", f.MsgContent)
+	w.Write("Reserve", f.Reserve)
+	w.OmitWrite("Options", f.Options.String())
+
+	return w.String()
+}
+
+// FwdResp 转发短消息应答
+type FwdResp struct {
+	smgp.Header
+
+	// 10 字节，SMGW 产生的短消息流水号
+	MsgID string
+
+	// 4 字节，请求返回结果
+	Status smgp.Status
+
+	// 可选字段，PkTotal/PkNumber
+	Options smgp.Options
+}
+
+func (f *FwdResp) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	f.Header = smgp.ReadHeader(b)
+	f.MsgID = hex.EncodeToString([]byte(b.ReadCStringNWithoutTrim(10)))
+	f.Status = smgp.Status(b.ReadUint32())
+	f.Options = smgp.ReadOptions(b)
+
+	return b.Error()
+}
+
+func (f *FwdResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(f.Header, b)
+	msgID, err := hex.DecodeString(f.MsgID)
+	if err != nil {
+		return nil, err
+	}
+	b.WriteBytes(msgID)
+	b.WriteUint32(f.Status.Data())
+	b.WriteBytes(f.Options.Serialize())
+
+	return b.BytesWithLength()
+}
+
+func (f *FwdResp) SetSequenceID(id uint32) {
+	f.Header.SequenceID = id
+}
+
+func (f *FwdResp) GetSequenceID() uint32 {
+	return f.Header.SequenceID
+}
+
+func (f *FwdResp) GetCommand() sms.ICommander {
+	return smgp.CommandFwdResp
+}
+
+func (f *FwdResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (f *FwdResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", f.Header)
+	w.Write("MsgID", f.MsgID)
+	w.Write("Status", f.Status)
+	w.OmitWrite("Options", f.Options.String())
+
+	return w.String()
+}
@@ -0,0 +1,54 @@
+package smgp30
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+func TestFwd(t *testing.T) {
+	content := []byte("hello forward")
+	f := &Fwd{
+		Header:       smgp.NewHeader(0, smgp.CommandFwd, 99),
+		MsgID:        "01006101161700012345",
+		DestSMGWNo:   "020061",
+		SrcSMGWNo:    "010061",
+		SMCNo:        "000000",
+		MsgType:      smgp.MT,
+		ReportFlag:   1,
+		Priority:     1,
+		ServiceID:    "TEST",
+		FeeType:      "01",
+		FeeCode:      "000010",
+		FixedFee:     "000000",
+		MsgFormat:    smgp.GB18030,
+		SrcTermID:    "1181234",
+		DestTermID:   "13300000000",
+		ChargeTermID: "13300000000",
+		MsgLength:    uint8(len(content)),
+		MsgContent:   content,
+	}
+	f.Options.SetNodesCount(1)
+	data, err := f.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, 12+10+6+6+6+3+10+2+6+6+1+17+17+21*3+1+len(content)+8+5, len(data))
+
+	pdu, err := DecodeSMGP30(data)
+	assert.Nil(t, err)
+	f.TotalLength = uint32(len(data))
+	assert.Equal(t, f, pdu)
+
+	resp := pdu.GenEmptyResponse().(*FwdResp)
+	assert.Equal(t, f.MsgID, resp.MsgID)
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MinFwdRespLength, len(data))
+
+	pdu, err = DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.CommandFwdResp, pdu.GetCommand())
+	assert.Equal(t, uint32(99), pdu.GetSequenceID())
+	assert.Equal(t, f.MsgID, pdu.(*FwdResp).MsgID)
+}
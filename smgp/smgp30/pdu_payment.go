@@ -0,0 +1,243 @@
+package smgp30
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+// 扣款请求（Payment_Request）与扣款确认（Payment_Affirm）用于预付费系统。
+// SMGP 3.0.3 只定义了这两组命令的 CommandID，消息体格式由《增值业务计费方案》规定，
+// 因此这里不对消息体做解析，原样保存在 Body 中，由调用方按计费方案自行编解码。
+
+// PaymentRequest 扣款请求
+type PaymentRequest struct {
+	smgp.Header
+
+	// 消息体，格式参见《增值业务计费方案》
+	Body []byte
+}
+
+func (p *PaymentRequest) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = smgp.ReadHeader(b)
+	p.Body = b.ReadNBytes(b.Remaining())
+
+	return b.Error()
+}
+
+func (p *PaymentRequest) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(p.Header, b)
+	b.WriteBytes(p.Body)
+
+	return b.BytesWithLength()
+}
+
+func (p *PaymentRequest) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+func (p *PaymentRequest) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+func (p *PaymentRequest) GetCommand() sms.ICommander {
+	return smgp.CommandPaymentRequest
+}
+
+func (p *PaymentRequest) GenEmptyResponse() sms.PDU {
+	return &PaymentRequestResp{
+		Header: smgp.NewHeader(smgp.MinSMGPPduLength, smgp.CommandPaymentRequestResp, p.GetSequenceID()),
+	}
+}
+
+func (p *PaymentRequest) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.WriteWithBytes("Body", p.Body)
+
+	return w.String()
+}
+
+// PaymentRequestResp 扣款请求应答
+type PaymentRequestResp struct {
+	smgp.Header
+
+	// 消息体，格式参见《增值业务计费方案》
+	Body []byte
+}
+
+func (p *PaymentRequestResp) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = smgp.ReadHeader(b)
+	p.Body = b.ReadNBytes(b.Remaining())
+
+	return b.Error()
+}
+
+func (p *PaymentRequestResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(p.Header, b)
+	b.WriteBytes(p.Body)
+
+	return b.BytesWithLength()
+}
+
+func (p *PaymentRequestResp) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+func (p *PaymentRequestResp) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+func (p *PaymentRequestResp) GetCommand() sms.ICommander {
+	return smgp.CommandPaymentRequestResp
+}
+
+func (p *PaymentRequestResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (p *PaymentRequestResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.WriteWithBytes("Body", p.Body)
+
+	return w.String()
+}
+
+// PaymentAffirm 扣款确认，通知扣款结果
+type PaymentAffirm struct {
+	smgp.Header
+
+	// 消息体，格式参见《增值业务计费方案》
+	Body []byte
+}
+
+func (p *PaymentAffirm) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = smgp.ReadHeader(b)
+	p.Body = b.ReadNBytes(b.Remaining())
+
+	return b.Error()
+}
+
+func (p *PaymentAffirm) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(p.Header, b)
+	b.WriteBytes(p.Body)
+
+	return b.BytesWithLength()
+}
+
+func (p *PaymentAffirm) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+func (p *PaymentAffirm) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+func (p *PaymentAffirm) GetCommand() sms.ICommander {
+	return smgp.CommandPaymentAffirm
+}
+
+func (p *PaymentAffirm) GenEmptyResponse() sms.PDU {
+	return &PaymentAffirmResp{
+		Header: smgp.NewHeader(smgp.MinSMGPPduLength, smgp.CommandPaymentAffirmResp, p.GetSequenceID()),
+	}
+}
+
+func (p *PaymentAffirm) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.WriteWithBytes("Body", p.Body)
+
+	return w.String()
+}
+
+// PaymentAffirmResp 扣款确认应答
+type PaymentAffirmResp struct {
+	smgp.Header
+
+	// 消息体，格式参见《增值业务计费方案》
+	Body []byte
+}
+
+func (p *PaymentAffirmResp) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	p.Header = smgp.ReadHeader(b)
+	p.Body = b.ReadNBytes(b.Remaining())
+
+	return b.Error()
+}
+
+func (p *PaymentAffirmResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(p.Header, b)
+	b.WriteBytes(p.Body)
+
+	return b.BytesWithLength()
+}
+
+func (p *PaymentAffirmResp) SetSequenceID(id uint32) {
+	p.Header.SequenceID = id
+}
+
+func (p *PaymentAffirmResp) GetSequenceID() uint32 {
+	return p.Header.SequenceID
+}
+
+func (p *PaymentAffirmResp) GetCommand() sms.ICommander {
+	return smgp.CommandPaymentAffirmResp
+}
+
+func (p *PaymentAffirmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (p *PaymentAffirmResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", p.Header)
+	w.WriteWithBytes("Body", p.Body)
+
+	return w.String()
+}
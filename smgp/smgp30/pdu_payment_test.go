@@ -0,0 +1,37 @@
+package smgp30
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+func TestPayment(t *testing.T) {
+	req := &PaymentRequest{Header: smgp.NewHeader(0, smgp.CommandPaymentRequest, 1), Body: []byte{1, 2, 3}}
+	data, err := req.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.HeaderLength+3, len(data))
+
+	pdu, err := DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, pdu.(*PaymentRequest).Body)
+
+	data, err = pdu.GenEmptyResponse().IEncode()
+	assert.Nil(t, err)
+	pdu, err = DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.CommandPaymentRequestResp, pdu.GetCommand())
+	assert.Nil(t, pdu.(*PaymentRequestResp).Body)
+
+	affirm := &PaymentAffirm{Header: smgp.NewHeader(0, smgp.CommandPaymentAffirm, 2), Body: []byte{4, 5}}
+	data, err = affirm.IEncode()
+	assert.Nil(t, err)
+	pdu, err = DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{4, 5}, pdu.(*PaymentAffirm).Body)
+	assert.Equal(t, smgp.CommandPaymentAffirmResp, pdu.GenEmptyResponse().GetCommand())
+	assert.Equal(t, "SMGP_PAYMENT_AFFIRM_RESP", smgp.CommandPaymentAffirmResp.String())
+	assert.Equal(t, smgp.CommandID(0x8000000B), smgp.CommandPaymentAffirmResp)
+}
@@ -0,0 +1,220 @@
+package smgp30
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+// QueryType
+const (
+	QueryTypeTotal   uint8 = 0 // 总数查询
+	QueryTypeService uint8 = 1 // 按业务类型查询
+)
+
+// Query SP 统计查询，用于 SP 向 SMGW 查询某天的短消息统计信息
+type Query struct {
+	smgp.Header
+
+	// 8 字节，查询时间，格式为 YYYYMMDD（精确至日）
+	QueryTime string
+
+	// 1 字节，查询类别：0：总数查询；1：按业务类型查询
+	QueryType uint8
+
+	// 10 字节，查询码。当 QueryType 为 0 时，此项无效；当 QueryType 为 1 时，此项填写业务类型 ServiceID
+	QueryCode string
+}
+
+// NewQuery 生成一个 SP 统计查询，queryTime 格式为 YYYYMMDD
+func NewQuery(queryTime string, queryType uint8, queryCode string, seqID uint32) *Query {
+	return &Query{
+		Header:    smgp.NewHeader(smgp.MaxQueryLength, smgp.CommandQuery, seqID),
+		QueryTime: queryTime,
+		QueryType: queryType,
+		QueryCode: queryCode,
+	}
+}
+
+func (q *Query) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	q.Header = smgp.ReadHeader(b)
+	q.QueryTime = b.ReadCStringN(8)
+	q.QueryType = b.ReadUint8()
+	q.QueryCode = b.ReadCStringN(10)
+
+	return b.Error()
+}
+
+func (q *Query) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(q.Header, b)
+	b.WriteFixedLenString(q.QueryTime, 8)
+	b.WriteUint8(q.QueryType)
+	b.WriteFixedLenString(q.QueryCode, 10)
+
+	return b.BytesWithLength()
+}
+
+func (q *Query) SetSequenceID(id uint32) {
+	q.Header.SequenceID = id
+}
+
+func (q *Query) GetSequenceID() uint32 {
+	return q.Header.SequenceID
+}
+
+func (q *Query) GetCommand() sms.ICommander {
+	return smgp.CommandQuery
+}
+
+func (q *Query) GenEmptyResponse() sms.PDU {
+	return &QueryResp{
+		Header:    smgp.NewHeader(smgp.MaxQueryRespLength, smgp.CommandQueryResp, q.GetSequenceID()),
+		QueryTime: q.QueryTime,
+		QueryType: q.QueryType,
+		QueryCode: q.QueryCode,
+	}
+}
+
+func (q *Query) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", q.Header)
+	w.Write("QueryTime", q.QueryTime)
+	w.Write("QueryType", q.QueryType)
+	w.Write("QueryCode", q.QueryCode)
+
+	return w.String()
+}
+
+// QueryResp SP 统计查询应答
+type QueryResp struct {
+	smgp.Header
+
+	// 8 字节，查询时间，格式为 YYYYMMDD
+	QueryTime string
+
+	// 1 字节，查询类别：0：总数查询；1：按业务类型查询
+	QueryType uint8
+
+	// 10 字节，查询码
+	QueryCode string
+
+	// 4 字节，从 SP 接收信息总数
+	MTTLMsg uint32
+
+	// 4 字节，发送用户总数
+	MTTLUsr uint32
+
+	// 4 字节，成功转发数量
+	MTScs uint32
+
+	// 4 字节，待转发数量
+	MTWT uint32
+
+	// 4 字节，转发失败数量
+	MTFL uint32
+
+	// 4 字节，向 SP 成功送达数量
+	MOScs uint32
+
+	// 4 字节，向 SP 待送达数量
+	MOWT uint32
+
+	// 4 字节，向 SP 送达失败数量
+	MOFL uint32
+
+	// 8 字节，保留
+	Reserve string
+}
+
+func (q *QueryResp) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	q.Header = smgp.ReadHeader(b)
+	q.QueryTime = b.ReadCStringN(8)
+	q.QueryType = b.ReadUint8()
+	q.QueryCode = b.ReadCStringN(10)
+	q.MTTLMsg = b.ReadUint32()
+	q.MTTLUsr = b.ReadUint32()
+	q.MTScs = b.ReadUint32()
+	q.MTWT = b.ReadUint32()
+	q.MTFL = b.ReadUint32()
+	q.MOScs = b.ReadUint32()
+	q.MOWT = b.ReadUint32()
+	q.MOFL = b.ReadUint32()
+	q.Reserve = b.ReadCStringN(8)
+
+	return b.Error()
+}
+
+func (q *QueryResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(q.Header, b)
+	b.WriteFixedLenString(q.QueryTime, 8)
+	b.WriteUint8(q.QueryType)
+	b.WriteFixedLenString(q.QueryCode, 10)
+	b.WriteUint32(q.MTTLMsg)
+	b.WriteUint32(q.MTTLUsr)
+	b.WriteUint32(q.MTScs)
+	b.WriteUint32(q.MTWT)
+	b.WriteUint32(q.MTFL)
+	b.WriteUint32(q.MOScs)
+	b.WriteUint32(q.MOWT)
+	b.WriteUint32(q.MOFL)
+	b.WriteFixedLenString(q.Reserve, 8)
+
+	return b.BytesWithLength()
+}
+
+func (q *QueryResp) SetSequenceID(id uint32) {
+	q.Header.SequenceID = id
+}
+
+func (q *QueryResp) GetSequenceID() uint32 {
+	return q.Header.SequenceID
+}
+
+func (q *QueryResp) GetCommand() sms.ICommander {
+	return smgp.CommandQueryResp
+}
+
+func (q *QueryResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (q *QueryResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", q.Header)
+	w.Write("QueryTime", q.QueryTime)
+	w.Write("QueryType", q.QueryType)
+	w.Write("QueryCode", q.QueryCode)
+	w.Write("MTTLMsg", q.MTTLMsg)
+	w.Write("MTTLUsr", q.MTTLUsr)
+	w.Write("MTScs", q.MTScs)
+	w.Write("MTWT", q.MTWT)
+	w.Write("MTFL", q.MTFL)
+	w.Write("MOScs", q.MOScs)
+	w.Write("MOWT", q.MOWT)
+	w.Write("MOFL", q.MOFL)
+	w.Write("Reserve", q.Reserve)
+
+	return w.String()
+}
@@ -0,0 +1,40 @@
+package smgp30
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+func TestQuery(t *testing.T) {
+	q := NewQuery("20240101", QueryTypeService, "TEST", 12)
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MaxQueryLength, len(data))
+
+	pdu, err := DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, q, pdu)
+
+	resp := q.GenEmptyResponse().(*QueryResp)
+	resp.MTTLMsg = 100
+	resp.MTTLUsr = 20
+	resp.MTScs = 90
+	resp.MTWT = 6
+	resp.MTFL = 4
+	resp.MOScs = 8
+	resp.MOWT = 1
+	resp.MOFL = 1
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MaxQueryRespLength, len(data))
+
+	pdu, err = DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, resp, pdu)
+	assert.Equal(t, uint32(12), pdu.GetSequenceID())
+	assert.Equal(t, "20240101", pdu.(*QueryResp).QueryTime)
+	assert.Nil(t, pdu.GenEmptyResponse())
+}
@@ -0,0 +1,371 @@
+package smgp30
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+// QueryTERoute 查询 TE 路由，用于查询某个终端号码对应的目标网关
+type QueryTERoute struct {
+	smgp.Header
+
+	// 6 字节，源网关代码
+	SrcGatewayID string
+
+	// 21 字节，查询号码
+	QueryTermID string
+}
+
+// NewQueryTERoute 生成一个 TE 路由查询
+func NewQueryTERoute(srcGatewayID, queryTermID string, seqID uint32) *QueryTERoute {
+	return &QueryTERoute{
+		Header:       smgp.NewHeader(smgp.MaxQueryRouteLength, smgp.CommandQueryTERoute, seqID),
+		SrcGatewayID: srcGatewayID,
+		QueryTermID:  queryTermID,
+	}
+}
+
+func (q *QueryTERoute) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	q.Header = smgp.ReadHeader(b)
+	q.SrcGatewayID = b.ReadCStringN(6)
+	q.QueryTermID = b.ReadCStringN(21)
+
+	return b.Error()
+}
+
+func (q *QueryTERoute) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(q.Header, b)
+	b.WriteFixedLenString(q.SrcGatewayID, 6)
+	b.WriteFixedLenString(q.QueryTermID, 21)
+
+	return b.BytesWithLength()
+}
+
+func (q *QueryTERoute) SetSequenceID(id uint32) {
+	q.Header.SequenceID = id
+}
+
+func (q *QueryTERoute) GetSequenceID() uint32 {
+	return q.Header.SequenceID
+}
+
+func (q *QueryTERoute) GetCommand() sms.ICommander {
+	return smgp.CommandQueryTERoute
+}
+
+func (q *QueryTERoute) GenEmptyResponse() sms.PDU {
+	return &QueryTERouteResp{
+		Header: smgp.NewHeader(smgp.MaxQueryTERouteRespLength, smgp.CommandQueryTERouteResp, q.GetSequenceID()),
+	}
+}
+
+func (q *QueryTERoute) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", q.Header)
+	w.Write("SrcGatewayID", q.SrcGatewayID)
+	w.Write("QueryTermID", q.QueryTermID)
+
+	return w.String()
+}
+
+// QueryTERouteResp 查询 TE 路由应答
+type QueryTERouteResp struct {
+	smgp.Header
+
+	// 4 字节，请求返回结果
+	Status smgp.Status
+
+	// 4 字节，路由编号
+	RouteID uint32
+
+	// 6 字节，目标网关代码
+	DestGatewayID string
+
+	// 15 字节，目标网关 IP 地址
+	DestGatewayIP string
+
+	// 2 字节，目标网关 IP 端口
+	DestGatewayPort uint16
+
+	// 10 字节，路由号码段
+	TermRangeID string
+
+	// 4 字节，终端所属省代号
+	ProvinceCode string
+
+	// 1 字节，用户类型，该字段保留
+	UserType uint8
+
+	// 14 字节，时间戳，格式为 YYYYMMDDHHMMSS
+	Time string
+}
+
+func (q *QueryTERouteResp) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	q.Header = smgp.ReadHeader(b)
+	q.Status = smgp.Status(b.ReadUint32())
+	q.RouteID = b.ReadUint32()
+	q.DestGatewayID = b.ReadCStringN(6)
+	q.DestGatewayIP = b.ReadCStringN(15)
+	q.DestGatewayPort = b.ReadUint16()
+	q.TermRangeID = b.ReadCStringN(10)
+	q.ProvinceCode = b.ReadCStringN(4)
+	q.UserType = b.ReadUint8()
+	q.Time = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+func (q *QueryTERouteResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(q.Header, b)
+	b.WriteUint32(q.Status.Data())
+	b.WriteUint32(q.RouteID)
+	b.WriteFixedLenString(q.DestGatewayID, 6)
+	b.WriteFixedLenString(q.DestGatewayIP, 15)
+	b.WriteUint16(q.DestGatewayPort)
+	b.WriteFixedLenString(q.TermRangeID, 10)
+	b.WriteFixedLenString(q.ProvinceCode, 4)
+	b.WriteUint8(q.UserType)
+	b.WriteFixedLenString(q.Time, 14)
+
+	return b.BytesWithLength()
+}
+
+func (q *QueryTERouteResp) SetSequenceID(id uint32) {
+	q.Header.SequenceID = id
+}
+
+func (q *QueryTERouteResp) GetSequenceID() uint32 {
+	return q.Header.SequenceID
+}
+
+func (q *QueryTERouteResp) GetCommand() sms.ICommander {
+	return smgp.CommandQueryTERouteResp
+}
+
+func (q *QueryTERouteResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (q *QueryTERouteResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", q.Header)
+	w.Write("Status", q.Status)
+	w.Write("RouteID", q.RouteID)
+	w.Write("DestGatewayID", q.DestGatewayID)
+	w.Write("DestGatewayIP", q.DestGatewayIP)
+	w.Write("DestGatewayPort", q.DestGatewayPort)
+	w.Write("TermRangeID", q.TermRangeID)
+	w.Write("ProvinceCode", q.ProvinceCode)
+	w.Write("UserType", q.UserType)
+	w.Write("Time", q.Time)
+
+	return w.String()
+}
+
+// QuerySPRoute 查询 SP 路由，用于查询某个 SP 服务代码（QueryTermID）对应的目标网关
+type QuerySPRoute struct {
+	smgp.Header
+
+	// 6 字节，源网关代码
+	SrcGatewayID string
+
+	// 21 字节，查询号码
+	QueryTermID string
+}
+
+// NewQuerySPRoute 生成一个 SP 路由查询
+func NewQuerySPRoute(srcGatewayID, queryTermID string, seqID uint32) *QuerySPRoute {
+	return &QuerySPRoute{
+		Header:       smgp.NewHeader(smgp.MaxQueryRouteLength, smgp.CommandQuerySPRoute, seqID),
+		SrcGatewayID: srcGatewayID,
+		QueryTermID:  queryTermID,
+	}
+}
+
+func (q *QuerySPRoute) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	q.Header = smgp.ReadHeader(b)
+	q.SrcGatewayID = b.ReadCStringN(6)
+	q.QueryTermID = b.ReadCStringN(21)
+
+	return b.Error()
+}
+
+func (q *QuerySPRoute) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(q.Header, b)
+	b.WriteFixedLenString(q.SrcGatewayID, 6)
+	b.WriteFixedLenString(q.QueryTermID, 21)
+
+	return b.BytesWithLength()
+}
+
+func (q *QuerySPRoute) SetSequenceID(id uint32) {
+	q.Header.SequenceID = id
+}
+
+func (q *QuerySPRoute) GetSequenceID() uint32 {
+	return q.Header.SequenceID
+}
+
+func (q *QuerySPRoute) GetCommand() sms.ICommander {
+	return smgp.CommandQuerySPRoute
+}
+
+func (q *QuerySPRoute) GenEmptyResponse() sms.PDU {
+	return &QuerySPRouteResp{
+		Header: smgp.NewHeader(smgp.MaxQuerySPRouteRespLength, smgp.CommandQuerySPRouteResp, q.GetSequenceID()),
+	}
+}
+
+func (q *QuerySPRoute) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", q.Header)
+	w.Write("SrcGatewayID", q.SrcGatewayID)
+	w.Write("QueryTermID", q.QueryTermID)
+
+	return w.String()
+}
+
+// QuerySPRouteResp 查询 SP 路由应答
+type QuerySPRouteResp struct {
+	smgp.Header
+
+	// 4 字节，请求返回结果
+	Status smgp.Status
+
+	// 4 字节，路由编号
+	RouteID uint32
+
+	// 6 字节，目标网关代码
+	DestGatewayID string
+
+	// 15 字节，目标网关 IP 地址
+	DestGatewayIP string
+
+	// 2 字节，目标网关 IP 端口
+	DestGatewayPort uint16
+
+	// 8 字节，SP 企业代码
+	SPID string
+
+	// 21 字节，SP 服务代码
+	SPCode string
+
+	// 1 字节，SP 接入类型：0：全网业务 SP 全网接入；1：全网业务 SP 镜像接入
+	SPAccessType uint8
+
+	// 1 字节，SP 类型：0：本地性 SP；1：全国性 SP
+	SPType uint8
+
+	// 14 字节，时间戳，格式为 YYYYMMDDHHMMSS
+	Time string
+}
+
+func (q *QuerySPRouteResp) IDecode(data []byte) error {
+	if len(data) < smgp.MinSMGPPduLength {
+		return smgp.ErrInvalidPudLength
+	}
+	b := packet.NewPacketReader(data)
+	defer b.Release()
+
+	q.Header = smgp.ReadHeader(b)
+	q.Status = smgp.Status(b.ReadUint32())
+	q.RouteID = b.ReadUint32()
+	q.DestGatewayID = b.ReadCStringN(6)
+	q.DestGatewayIP = b.ReadCStringN(15)
+	q.DestGatewayPort = b.ReadUint16()
+	q.SPID = b.ReadCStringN(8)
+	q.SPCode = b.ReadCStringN(21)
+	q.SPAccessType = b.ReadUint8()
+	q.SPType = b.ReadUint8()
+	q.Time = b.ReadCStringN(14)
+
+	return b.Error()
+}
+
+func (q *QuerySPRouteResp) IEncode() ([]byte, error) {
+	b := packet.NewPacketWriter()
+	defer b.Release()
+
+	smgp.WriteHeaderNoLength(q.Header, b)
+	b.WriteUint32(q.Status.Data())
+	b.WriteUint32(q.RouteID)
+	b.WriteFixedLenString(q.DestGatewayID, 6)
+	b.WriteFixedLenString(q.DestGatewayIP, 15)
+	b.WriteUint16(q.DestGatewayPort)
+	b.WriteFixedLenString(q.SPID, 8)
+	b.WriteFixedLenString(q.SPCode, 21)
+	b.WriteUint8(q.SPAccessType)
+	b.WriteUint8(q.SPType)
+	b.WriteFixedLenString(q.Time, 14)
+
+	return b.BytesWithLength()
+}
+
+func (q *QuerySPRouteResp) SetSequenceID(id uint32) {
+	q.Header.SequenceID = id
+}
+
+func (q *QuerySPRouteResp) GetSequenceID() uint32 {
+	return q.Header.SequenceID
+}
+
+func (q *QuerySPRouteResp) GetCommand() sms.ICommander {
+	return smgp.CommandQuerySPRouteResp
+}
+
+func (q *QuerySPRouteResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (q *QuerySPRouteResp) String() string {
+	w := packet.NewPDUStringer()
+	defer w.Release()
+
+	w.Write("Header", q.Header)
+	w.Write("Status", q.Status)
+	w.Write("RouteID", q.RouteID)
+	w.Write("DestGatewayID", q.DestGatewayID)
+	w.Write("DestGatewayIP", q.DestGatewayIP)
+	w.Write("DestGatewayPort", q.DestGatewayPort)
+	w.Write("SPID", q.SPID)
+	w.Write("SPCode", q.SPCode)
+	w.Write("SPAccessType", q.SPAccessType)
+	w.Write("SPType", q.SPType)
+	w.Write("Time", q.Time)
+
+	return w.String()
+}
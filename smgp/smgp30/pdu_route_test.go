@@ -0,0 +1,67 @@
+package smgp30
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smgp"
+)
+
+func TestQueryTERoute(t *testing.T) {
+	q := NewQueryTERoute("010061", "13300000000", 7)
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MaxQueryRouteLength, len(data))
+
+	pdu, err := DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, q, pdu)
+
+	resp := q.GenEmptyResponse().(*QueryTERouteResp)
+	resp.RouteID = 3
+	resp.DestGatewayID = "020061"
+	resp.DestGatewayIP = "67.221.134.12"
+	resp.DestGatewayPort = 8890
+	resp.TermRangeID = "1330000"
+	resp.ProvinceCode = "020"
+	resp.Time = "20040901021324"
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MaxQueryTERouteRespLength, len(data))
+
+	pdu, err = DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, resp, pdu)
+}
+
+func TestQuerySPRoute(t *testing.T) {
+	q := NewQuerySPRoute("010061", "1181234", 8)
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MaxQueryRouteLength, len(data))
+
+	pdu, err := DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, q, pdu)
+
+	resp := q.GenEmptyResponse().(*QuerySPRouteResp)
+	resp.Status = 58
+	resp.RouteID = 5
+	resp.DestGatewayID = "020061"
+	resp.DestGatewayIP = "67.221.134.12"
+	resp.DestGatewayPort = 8890
+	resp.SPID = "12345678"
+	resp.SPCode = "1234"
+	resp.SPAccessType = 1
+	resp.SPType = 1
+	resp.Time = "20040901021324"
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, smgp.MaxQuerySPRouteRespLength, len(data))
+
+	pdu, err = DecodeSMGP30(data)
+	assert.Nil(t, err)
+	assert.Equal(t, resp, pdu)
+	assert.Equal(t, "没有匹配路由", pdu.(*QuerySPRouteResp).Status.String())
+}
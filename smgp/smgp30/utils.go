@@ -7,6 +7,7 @@ import (
 	"time"
 
 	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/consts"
 	"github.com/hujm2023/go-sms-protocol/smgp"
 )
 
@@ -31,6 +32,17 @@ func NewActiveTestPacket(seqID uint32) []byte {
 	return data
 }
 
+// NewExitPacket creates a new Exit PDU and encodes it into a byte slice.
+func NewExitPacket(seqID uint32) []byte {
+	pdu := &Exit{Header: smgp.NewHeader(smgp.HeaderLength, smgp.CommandExit, seqID)}
+	data, _ := pdu.IEncode()
+	return data
+}
+
+func init() {
+	sms.RegisterDecoder(consts.ProtocolSMGP, consts.SMGPVersion3_0, DecodeSMGP30)
+}
+
 func DecodeSMGP30(data []byte) (sms.PDU, error) {
 	header, err := smgp.PeekHeader(data)
 	if err != nil {
@@ -59,6 +71,30 @@ func DecodeSMGP30(data []byte) (sms.PDU, error) {
 		pdu = new(Exit)
 	case smgp.CommandExitResp:
 		pdu = new(ExitResp)
+	case smgp.CommandFwd:
+		pdu = new(Fwd)
+	case smgp.CommandFwdResp:
+		pdu = new(FwdResp)
+	case smgp.CommandQuery:
+		pdu = new(Query)
+	case smgp.CommandQueryResp:
+		pdu = new(QueryResp)
+	case smgp.CommandQueryTERoute:
+		pdu = new(QueryTERoute)
+	case smgp.CommandQueryTERouteResp:
+		pdu = new(QueryTERouteResp)
+	case smgp.CommandQuerySPRoute:
+		pdu = new(QuerySPRoute)
+	case smgp.CommandQuerySPRouteResp:
+		pdu = new(QuerySPRouteResp)
+	case smgp.CommandPaymentRequest:
+		pdu = new(PaymentRequest)
+	case smgp.CommandPaymentRequestResp:
+		pdu = new(PaymentRequestResp)
+	case smgp.CommandPaymentAffirm:
+		pdu = new(PaymentAffirm)
+	case smgp.CommandPaymentAffirmResp:
+		pdu = new(PaymentAffirmResp)
 	}
 
 	if pdu == nil {
@@ -7,8 +7,9 @@ import (
 var ErrInvalidPudLength = errors.New("invalid pdu length")
 
 const (
-	// Max PDU size to minimize some attack vectors
-	MAX_PDU_SIZE = 4096 // 4KB
+	// Max PDU size to minimize some attack vectors,
+	// large enough for a message_payload TLV of 65535 bytes
+	MAX_PDU_SIZE = 68 * 1024 // 68KB
 
 	// Sequence number start/end
 	SEQUENCE_NUM_START = 0x00000001
@@ -70,6 +71,29 @@ const (
 	ESME_RUNKNOWNERR      CMDStatus = 0x000000FF // Unknown Error
 )
 
+const (
+	// ESME Error Constants added in SMPP 5.0
+	ESME_RSERTYPUNAUTH       CMDStatus = 0x000000C5 // ESME Not authorised to use specified service_type
+	ESME_RPROHIBITED         CMDStatus = 0x000000C6 // ESME Prohibited from using specified operation
+	ESME_RSERTYPUNAVAIL      CMDStatus = 0x000000C7 // Specified service_type is unavailable
+	ESME_RSERTYPDENIED       CMDStatus = 0x000000C8 // Specified service_type is denied
+	ESME_RINVDCS             CMDStatus = 0x000000C9 // Invalid Data Coding Scheme
+	ESME_RINVSRCADDRSUBUNIT  CMDStatus = 0x000000CA // Source Address Sub unit is Invalid
+	ESME_RINVDSTADDRSUBUNIT  CMDStatus = 0x000000CB // Destination Address Sub unit is Invalid
+	ESME_RINVBCASTFREQINT    CMDStatus = 0x000000CC // Broadcast Frequency Interval is invalid
+	ESME_RINVBCASTALIAS_NAME CMDStatus = 0x000000CD // Broadcast Alias Name is invalid
+	ESME_RINVBCASTAREAFMT    CMDStatus = 0x000000CE // Broadcast Area Format is invalid
+	ESME_RINVNUMBCAST_AREAS  CMDStatus = 0x000000CF // Number of Broadcast Areas is invalid
+	ESME_RINVBCASTCNTTYPE    CMDStatus = 0x000000D0 // Broadcast Content Type is invalid
+	ESME_RINVBCASTMSGCLASS   CMDStatus = 0x000000D1 // Broadcast Message Class is invalid
+	ESME_RBCASTFAIL          CMDStatus = 0x000000D2 // broadcast_sm operation failed
+	ESME_RBCASTQUERYFAIL     CMDStatus = 0x000000D3 // query_broadcast_sm operation failed
+	ESME_RBCASTCANCELFAIL    CMDStatus = 0x000000D4 // cancel_broadcast_sm operation failed
+	ESME_RINVBCAST_REP       CMDStatus = 0x000000D5 // Number of Repeated Broadcasts is invalid
+	ESME_RINVBCASTSRVGRP     CMDStatus = 0x000000D6 // Broadcast Service Group is invalid
+	ESME_RINVBCASTCHANIND    CMDStatus = 0x000000D7 // Broadcast Channel Indicator is invalid
+)
+
 const (
 	// PDU Types
 	GENERIC_NACK          CMDId = 0x80000000
@@ -99,6 +123,14 @@ const (
 	ALERT_NOTIFICATION    CMDId = 0x00000102
 	DATA_SM               CMDId = 0x00000103
 	DATA_SM_RESP          CMDId = 0x80000103
+
+	// PDU Types added in SMPP 5.0
+	BROADCAST_SM             CMDId = 0x00000111
+	BROADCAST_SM_RESP        CMDId = 0x80000111
+	QUERY_BROADCAST_SM       CMDId = 0x00000112
+	QUERY_BROADCAST_SM_RESP  CMDId = 0x80000112
+	CANCEL_BROADCAST_SM      CMDId = 0x00000113
+	CANCEL_BROADCAST_SM_RESP CMDId = 0x80000113
 )
 
 const (
@@ -190,6 +222,30 @@ const (
 	TEMPLATE_ID                 = 0x1401
 )
 
+const (
+	// Optional Parameter Tags added in SMPP 5.0
+	CONGESTION_STATE             = 0x0428
+	BROADCAST_CHANNEL_INDICATOR  = 0x0600
+	BROADCAST_CONTENT_TYPE       = 0x0601
+	BROADCAST_CONTENT_TYPE_INFO  = 0x0602
+	BROADCAST_MESSAGE_CLASS      = 0x0603
+	BROADCAST_REP_NUM            = 0x0604
+	BROADCAST_FREQUENCY_INTERVAL = 0x0605
+	BROADCAST_AREA_IDENTIFIER    = 0x0606 // also used for failed_broadcast_area_identifier
+	BROADCAST_ERROR_STATUS       = 0x0607
+	BROADCAST_AREA_SUCCESS       = 0x0608
+	BROADCAST_END_TIME           = 0x0609
+	BROADCAST_SERVICE_GROUP      = 0x060A
+	BILLING_IDENTIFICATION       = 0x060B
+	SOURCE_NETWORK_ID            = 0x060D
+	DEST_NETWORK_ID              = 0x060E
+	SOURCE_NODE_ID               = 0x060F
+	DEST_NODE_ID                 = 0x0610
+	DEST_ADDR_NP_RESOLUTION      = 0x0611
+	DEST_ADDR_NP_INFORMATION     = 0x0612
+	DEST_ADDR_NP_COUNTRY         = 0x0613
+)
+
 const (
 	// Encoding Types
 	ENCODING_DEFAULT   = 0x00 // SMSC Default
@@ -260,3 +316,12 @@ const (
 	NPI_Internet    = 0o0001110
 	NPI_WAPClientID = 0o0010010
 )
+
+const (
+	// These fields define the type of a dest_address in submit_multi
+	DEST_FLAG_SME_ADDRESS       = 0x01 // SME Address
+	DEST_FLAG_DISTRIBUTION_LIST = 0x02 // Distribution List Name
+
+	// MAX_NUMBER_OF_DESTS is the max number of dest_address in one submit_multi, SMPP 3.4 allows 254
+	MAX_NUMBER_OF_DESTS = 254
+)
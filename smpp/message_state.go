@@ -0,0 +1,52 @@
+package smpp
+
+import "fmt"
+
+// MessageState is the state of a short message in the SMSC,
+// as returned in query_sm_resp and the message_state TLV.
+type MessageState uint8
+
+const (
+	MESSAGE_STATE_ENROUTE       MessageState = 1 // The message is in enroute state
+	MESSAGE_STATE_DELIVERED     MessageState = 2 // Message is delivered to destination
+	MESSAGE_STATE_EXPIRED       MessageState = 3 // Message validity period has expired
+	MESSAGE_STATE_DELETED       MessageState = 4 // Message has been deleted
+	MESSAGE_STATE_UNDELIVERABLE MessageState = 5 // Message is undeliverable
+	MESSAGE_STATE_ACCEPTED      MessageState = 6 // Message is in accepted state
+	MESSAGE_STATE_UNKNOWN       MessageState = 7 // Message is in invalid state
+	MESSAGE_STATE_REJECTED      MessageState = 8 // Message is in a rejected state
+)
+
+func (s MessageState) String() string {
+	switch s {
+	case MESSAGE_STATE_ENROUTE:
+		return "ENROUTE"
+	case MESSAGE_STATE_DELIVERED:
+		return "DELIVERED"
+	case MESSAGE_STATE_EXPIRED:
+		return "EXPIRED"
+	case MESSAGE_STATE_DELETED:
+		return "DELETED"
+	case MESSAGE_STATE_UNDELIVERABLE:
+		return "UNDELIVERABLE"
+	case MESSAGE_STATE_ACCEPTED:
+		return "ACCEPTED"
+	case MESSAGE_STATE_UNKNOWN:
+		return "UNKNOWN"
+	case MESSAGE_STATE_REJECTED:
+		return "REJECTED"
+	default:
+		return fmt.Sprintf("MessageState(%d)", uint8(s))
+	}
+}
+
+// IsFinal reports whether the message has reached a final state, which does not change anymore.
+func (s MessageState) IsFinal() bool {
+	switch s {
+	case MESSAGE_STATE_DELIVERED, MESSAGE_STATE_EXPIRED, MESSAGE_STATE_DELETED,
+		MESSAGE_STATE_UNDELIVERABLE, MESSAGE_STATE_ACCEPTED, MESSAGE_STATE_REJECTED:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,26 @@
+package smpp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageState(t *testing.T) {
+	assert.Equal(t, "ENROUTE", MESSAGE_STATE_ENROUTE.String())
+	assert.Equal(t, "DELIVERED", MESSAGE_STATE_DELIVERED.String())
+	assert.Equal(t, "MessageState(9)", MessageState(9).String())
+
+	for state, final := range map[MessageState]bool{
+		MESSAGE_STATE_ENROUTE:       false,
+		MESSAGE_STATE_DELIVERED:     true,
+		MESSAGE_STATE_EXPIRED:       true,
+		MESSAGE_STATE_DELETED:       true,
+		MESSAGE_STATE_UNDELIVERABLE: true,
+		MESSAGE_STATE_ACCEPTED:      true,
+		MESSAGE_STATE_UNKNOWN:       false,
+		MESSAGE_STATE_REJECTED:      true,
+	} {
+		assert.Equal(t, final, state.IsFinal(), state.String())
+	}
+}
@@ -121,6 +121,18 @@ func (s CMDId) String() string {
 		return "SMPP_DATA_SM"
 	case DATA_SM_RESP:
 		return "SMPP_DATA_SM_RESP"
+	case BROADCAST_SM:
+		return "SMPP_BROADCAST_SM"
+	case BROADCAST_SM_RESP:
+		return "SMPP_BROADCAST_SM_RESP"
+	case QUERY_BROADCAST_SM:
+		return "SMPP_QUERY_BROADCAST_SM"
+	case QUERY_BROADCAST_SM_RESP:
+		return "SMPP_QUERY_BROADCAST_SM_RESP"
+	case CANCEL_BROADCAST_SM:
+		return "SMPP_CANCEL_BROADCAST_SM"
+	case CANCEL_BROADCAST_SM_RESP:
+		return "SMPP_CANCEL_BROADCAST_SM_RESP"
 	default:
 		return fmt.Sprintf("UNKNOWN PDU %d", s)
 	}
@@ -230,6 +242,44 @@ func (s CMDStatus) Error() string {
 		return "CMPPDelivery Failure (used for data_sm_resp)"
 	case ESME_RUNKNOWNERR:
 		return "Unknown Error"
+	case ESME_RSERTYPUNAUTH:
+		return "ESME Not authorised to use specified service_type"
+	case ESME_RPROHIBITED:
+		return "ESME Prohibited from using specified operation"
+	case ESME_RSERTYPUNAVAIL:
+		return "Specified service_type is unavailable"
+	case ESME_RSERTYPDENIED:
+		return "Specified service_type is denied"
+	case ESME_RINVDCS:
+		return "Invalid Data Coding Scheme"
+	case ESME_RINVSRCADDRSUBUNIT:
+		return "Source Address Sub unit is Invalid"
+	case ESME_RINVDSTADDRSUBUNIT:
+		return "Destination Address Sub unit is Invalid"
+	case ESME_RINVBCASTFREQINT:
+		return "Broadcast Frequency Interval is invalid"
+	case ESME_RINVBCASTALIAS_NAME:
+		return "Broadcast Alias Name is invalid"
+	case ESME_RINVBCASTAREAFMT:
+		return "Broadcast Area Format is invalid"
+	case ESME_RINVNUMBCAST_AREAS:
+		return "Number of Broadcast Areas is invalid"
+	case ESME_RINVBCASTCNTTYPE:
+		return "Broadcast Content Type is invalid"
+	case ESME_RINVBCASTMSGCLASS:
+		return "Broadcast Message Class is invalid"
+	case ESME_RBCASTFAIL:
+		return "broadcast_sm operation failed"
+	case ESME_RBCASTQUERYFAIL:
+		return "query_broadcast_sm operation failed"
+	case ESME_RBCASTCANCELFAIL:
+		return "cancel_broadcast_sm operation failed"
+	case ESME_RINVBCAST_REP:
+		return "Number of Repeated Broadcasts is invalid"
+	case ESME_RINVBCASTSRVGRP:
+		return "Broadcast Service Group is invalid"
+	case ESME_RINVBCASTCHANIND:
+		return "Broadcast Channel Indicator is invalid"
 	}
 }
 
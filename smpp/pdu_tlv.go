@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/hujm2023/go-sms-protocol/packet"
 )
@@ -13,6 +14,9 @@ type TLV struct {
 	Tag        uint16
 	Length     uint16
 	ValueBytes []byte
+
+	// order is the insertion order in TLVs, starting from 1. TLVs are encoded in this order.
+	order uint32
 }
 
 func NewTLV(tag uint16, value []byte) TLV {
@@ -50,6 +54,8 @@ func (t TLV) String() string {
 	return fmt.Sprintf("TLV{Tag=%#x, Length=%d, Value=%v, ValueString=%s}", t.Tag, t.Length, t.ValueBytes, string(t.ValueBytes))
 }
 
+// ReadTLVs reads the TLVs to the end of the PDU, in order.
+// The lengths are not checked, as SMSCs do not always follow the spec, see ReadTLVsStrict and TLVs.Validate.
 func ReadTLVs(r *packet.Reader) (TLVs, error) {
 	if r.Remaining() == 0 {
 		return nil, nil
@@ -59,7 +65,7 @@ func ReadTLVs(r *packet.Reader) (TLVs, error) {
 		return nil, r.Error()
 	}
 
-	tlvs := make(map[uint16]TLV)
+	tlvs := make(TLVs)
 	for {
 		if r.Remaining() == 0 {
 			return tlvs, nil
@@ -89,77 +95,91 @@ func ReadTLVs(r *packet.Reader) (TLVs, error) {
 			return nil, e
 		}
 
-		tlvs[tag] = TLV{
+		tlvs.SetTLV(TLV{
 			Tag:        tag,
 			Length:     length,
 			ValueBytes: value,
-		}
+		})
 	}
 
 	return tlvs, nil
 }
 
-func ReadTLVs1(r *packet.Reader) TLVs {
-	if r.Remaining() == 0 {
-		return nil
+// ReadTLVsStrict reads the TLVs like ReadTLVs, a known tag with an invalid length
+// is reported as ErrInvalidTLVLength, see LookupTLVSpec.
+func ReadTLVsStrict(r *packet.Reader) (TLVs, error) {
+	tlvs, err := ReadTLVs(r)
+	if err != nil {
+		return nil, err
 	}
-
-	if r.Error() != nil {
-		return nil
+	if err = tlvs.Validate(); err != nil {
+		return nil, err
 	}
+	return tlvs, nil
+}
 
-	tlvs := make(map[uint16]TLV)
-	for {
-		if r.Remaining() == 0 {
-			return tlvs
-		}
-
-		temp := make([]byte, 4)
-		r.ReadBytes(temp)
-		if e := r.Error(); e != nil {
-			if errors.Is(e, io.EOF) {
-				r.SetErrNil()
-				break
-			}
-			return nil
-		}
-
-		tag := binary.BigEndian.Uint16(temp[:2])
-		length := binary.BigEndian.Uint16(temp[2:4])
-
-		// read left value
-		value := make([]byte, length)
-		r.ReadBytes(value)
-		if e := r.Error(); e != nil {
-			if errors.Is(r.Error(), io.EOF) {
-				r.SetErrNil()
-				break
-			}
-			return nil
-		}
-
-		tlvs[tag] = TLV{
-			Tag:        tag,
-			Length:     length,
-			ValueBytes: value,
-		}
+// ReadTLVs1 reads the TLVs like ReadTLVs, but returns nil on any error.
+//
+// Deprecated: use ReadTLVs, which reports the errors.
+func ReadTLVs1(r *packet.Reader) TLVs {
+	tlvs, err := ReadTLVs(r)
+	if err != nil {
+		return nil
 	}
-
 	return tlvs
 }
 
+// TLVs holds the optional parameters of a PDU by tag.
+// TLVs set with SetTLV or read with ReadTLVs are encoded in insertion order;
+// TLVs assigned to the map directly come first, ordered by tag.
 type TLVs map[uint16]TLV
 
+// SetTLV adds or replaces a TLV. A replaced TLV keeps its position.
 func (t *TLVs) SetTLV(tlv TLV) {
 	if *t == nil {
 		*t = make(TLVs)
 	}
+	if old, ok := (*t)[tlv.Tag]; ok && old.order > 0 {
+		tlv.order = old.order
+	} else {
+		tlv.order = t.maxOrder() + 1
+	}
 	(*t)[tlv.Tag] = tlv
 }
 
+// Del removes the TLV with the tag.
+func (t TLVs) Del(tag uint16) {
+	delete(t, tag)
+}
+
+func (t TLVs) maxOrder() uint32 {
+	var max uint32
+	for _, tlv := range t {
+		if tlv.order > max {
+			max = tlv.order
+		}
+	}
+	return max
+}
+
+// Sorted returns the TLVs in encoding order.
+func (t TLVs) Sorted() []TLV {
+	list := make([]TLV, 0, len(t))
+	for _, tlv := range t {
+		list = append(list, tlv)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].order != list[j].order {
+			return list[i].order < list[j].order
+		}
+		return list[i].Tag < list[j].Tag
+	})
+	return list
+}
+
 func (t TLVs) Bytes() []byte {
 	b := make([]byte, 0)
-	for _, tlv := range t {
+	for _, tlv := range t.Sorted() {
 		b = append(b, tlv.Bytes()...)
 	}
 	return b
@@ -170,12 +190,21 @@ func (t TLVs) String() string {
 		return ""
 	}
 	s := "\n"
-	for idx := range t {
-		if t[idx].IsEmpty() {
+	for _, tlv := range t.Sorted() {
+		if tlv.IsEmpty() {
 			continue
 		}
-		s += fmt.Sprintf("\t%s\n", t[idx].String())
+		s += fmt.Sprintf("\t%s\n", tlv.String())
 	}
 
 	return s
 }
+
+// MessageContent returns shortMessage, or the value of the message_payload TLV if shortMessage is empty.
+func MessageContent(shortMessage []byte, tlvs TLVs) []byte {
+	if len(shortMessage) > 0 {
+		return shortMessage
+	}
+	payload, _ := tlvs.MessagePayload()
+	return payload
+}
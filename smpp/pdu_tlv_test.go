@@ -2,6 +2,7 @@ package smpp
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -90,3 +91,110 @@ func TestStringerForTLVS(t *testing.T) {
 	s.OmitWrite("TLV", tlvs.String())
 	t.Log(s.String())
 }
+
+func TestTLVs_Order(t *testing.T) {
+	var tlvs TLVs
+	tlvs.SetUint16(SAR_MSG_REF_NUM, 0x0102)
+	tlvs.SetUint8(SAR_TOTAL_SEGMENTS, 2)
+	tlvs.SetUint8(PAYLOAD_TYPE, 0)
+	assert.Equal(t, []byte{
+		0x02, 0x0C, 0, 2, 1, 2,
+		0x02, 0x0E, 0, 1, 2,
+		0, 0x19, 0, 1, 0,
+	}, tlvs.Bytes())
+
+	// a replaced TLV keeps its position
+	tlvs.SetUint16(SAR_MSG_REF_NUM, 0x0304)
+	assert.Equal(t, []uint16{SAR_MSG_REF_NUM, SAR_TOTAL_SEGMENTS, PAYLOAD_TYPE}, tagsOf(tlvs.Sorted()))
+
+	tlvs.Del(SAR_TOTAL_SEGMENTS)
+	tlvs.SetUint8(SAR_TOTAL_SEGMENTS, 2)
+	assert.Equal(t, []uint16{SAR_MSG_REF_NUM, PAYLOAD_TYPE, SAR_TOTAL_SEGMENTS}, tagsOf(tlvs.Sorted()))
+
+	// decoding keeps the order on the wire
+	r := packet.NewPacketReader(tlvs.Bytes())
+	defer r.Release()
+	decoded, err := ReadTLVs(r)
+	assert.Nil(t, err)
+	assert.Equal(t, tlvs.Bytes(), decoded.Bytes())
+}
+
+func tagsOf(list []TLV) []uint16 {
+	tags := make([]uint16, 0, len(list))
+	for _, tlv := range list {
+		tags = append(tags, tlv.Tag)
+	}
+	return tags
+}
+
+func TestTLVs_Accessors(t *testing.T) {
+	var tlvs TLVs
+	_, ok := tlvs.SarMsgRefNum()
+	assert.False(t, ok)
+	assert.Equal(t, "", tlvs.ReceiptedMessageID())
+
+	tlvs.SetSarMsgRefNum(0x1234)
+	tlvs.SetReceiptedMessageID("abc")
+	tlvs.SetMessageState(MESSAGE_STATE_DELIVERED)
+	tlvs.SetMessagePayload([]byte("hello"))
+	tlvs.SetQosTimeToLive(3600)
+	tlvs.SetAlertOnMessageDelivery()
+
+	ref, ok := tlvs.SarMsgRefNum()
+	assert.True(t, ok)
+	assert.Equal(t, uint16(0x1234), ref)
+	assert.Equal(t, []byte("abc\x00"), tlvs[RECEIPTED_MESSAGE_ID].ValueBytes)
+	assert.Equal(t, "abc", tlvs.ReceiptedMessageID())
+	state, ok := tlvs.MessageState()
+	assert.True(t, ok)
+	assert.Equal(t, MESSAGE_STATE_DELIVERED, state)
+	payload, ok := tlvs.MessagePayload()
+	assert.True(t, ok)
+	assert.Equal(t, []byte("hello"), payload)
+	ttl, ok := tlvs.QosTimeToLive()
+	assert.True(t, ok)
+	assert.Equal(t, uint32(3600), ttl)
+	assert.True(t, tlvs.AlertOnMessageDelivery())
+
+	for _, tlv := range tlvs {
+		assert.Nil(t, tlv.Validate())
+	}
+
+	// receipted_message_id without the NULL, as some SMSCs send it
+	tlvs.SetOctets(RECEIPTED_MESSAGE_ID, []byte("xyz"))
+	assert.Equal(t, "xyz", tlvs.ReceiptedMessageID())
+}
+
+func TestReadTLVs_InvalidLength(t *testing.T) {
+	data := []byte{0x02, 0x0C, 0, 1, 1} // sar_msg_ref_num must be 2 bytes
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+	_, err := ReadTLVsStrict(r)
+	assert.True(t, errors.Is(err, ErrInvalidTLVLength))
+
+	// ReadTLVs keeps it, Validate reports it
+	r1 := packet.NewPacketReader(data)
+	defer r1.Release()
+	tlvs, err := ReadTLVs(r1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1}, tlvs[SAR_MSG_REF_NUM].ValueBytes)
+	assert.True(t, errors.Is(tlvs.Validate(), ErrInvalidTLVLength))
+
+	// unknown tags are not checked
+	r2 := packet.NewPacketReader([]byte{0x14, 0x10, 0, 1, 1})
+	defer r2.Release()
+	tlvs, err = ReadTLVsStrict(r2)
+	assert.Nil(t, err)
+	assert.Len(t, tlvs, 1)
+
+	RegisterTLVSpec(0x1410, TLVSpec{Name: "vendor", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2})
+	defer func() {
+		tlvSpecsMu.Lock()
+		delete(tlvSpecs, 0x1410)
+		tlvSpecsMu.Unlock()
+	}()
+	r3 := packet.NewPacketReader([]byte{0x14, 0x10, 0, 1, 1})
+	defer r3.Release()
+	_, err = ReadTLVsStrict(r3)
+	assert.True(t, errors.Is(err, ErrInvalidTLVLength))
+}
@@ -0,0 +1,99 @@
+package smpp34
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+// AlertNotification is sent by the SMSC when a mobile subscriber, for which a delivery_pending_flag was set
+// by a data_sm, becomes available. There is no response.
+type AlertNotification struct {
+	smpp.Header
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 65. The address of the mobile subscriber.
+	SourceAddr string
+
+	EsmeAddrTon uint8
+	EsmeAddrNpi uint8
+	// CString, max 65. The address of the ESME which requested the alert.
+	EsmeAddr string
+
+	// Optional: ms_availability_status
+	TLVs smpp.TLVs
+}
+
+func (a *AlertNotification) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	a.Header = smpp.ReadHeader(r)
+	a.SourceAddrTon = r.ReadUint8()
+	a.SourceAddrNpi = r.ReadUint8()
+	a.SourceAddr = r.ReadCString()
+	a.EsmeAddrTon = r.ReadUint8()
+	a.EsmeAddrNpi = r.ReadUint8()
+	a.EsmeAddr = r.ReadCString()
+	tlvs, err := smpp.ReadTLVs(r)
+	if err != nil {
+		return err
+	}
+	a.TLVs = tlvs
+
+	return r.Error()
+}
+
+func (a *AlertNotification) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(a.Header, w)
+	w.WriteUint8(a.SourceAddrTon)
+	w.WriteUint8(a.SourceAddrNpi)
+	w.WriteCString(a.SourceAddr)
+	w.WriteUint8(a.EsmeAddrTon)
+	w.WriteUint8(a.EsmeAddrNpi)
+	w.WriteCString(a.EsmeAddr)
+	w.WriteBytes(a.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (a *AlertNotification) SetSequenceID(id uint32) {
+	a.Header.Sequence = id
+}
+
+func (a *AlertNotification) GetSequenceID() uint32 {
+	return a.Header.Sequence
+}
+
+func (a *AlertNotification) GetCommand() sms.ICommander {
+	return smpp.ALERT_NOTIFICATION
+}
+
+// GenEmptyResponse returns nil, since alert_notification has no response.
+func (a *AlertNotification) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (a *AlertNotification) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", a.Header)
+	str.Write("SourceAddrTon", a.SourceAddrTon)
+	str.Write("SourceAddrNpi", a.SourceAddrNpi)
+	str.Write("SourceAddr", a.SourceAddr)
+	str.Write("EsmeAddrTon", a.EsmeAddrTon)
+	str.Write("EsmeAddrNpi", a.EsmeAddrNpi)
+	str.Write("EsmeAddr", a.EsmeAddr)
+	str.OmitWrite("TLVs", a.TLVs.String())
+
+	return str.String()
+}
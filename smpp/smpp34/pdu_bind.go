@@ -148,7 +148,11 @@ func (b *BindResp) IDecode(data []byte) error {
 
 	b.SystemID = buf.ReadCString()
 
-	b.TLVs = smpp.ReadTLVs1(buf)
+	tlvs, err := smpp.ReadTLVs(buf)
+	if err != nil {
+		return err
+	}
+	b.TLVs = tlvs
 
 	return buf.Error()
 }
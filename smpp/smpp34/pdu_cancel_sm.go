@@ -0,0 +1,153 @@
+package smpp34
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+type CancelSm struct {
+	smpp.Header
+
+	// CString, max 6
+	ServiceType string
+
+	// CString, max 65. If empty, all messages matching SourceAddr, DestinationAddr and ServiceType are cancelled.
+	MessageID string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21. Must match the source address of the original message.
+	SourceAddr string
+
+	DestAddrTon uint8
+	DestAddrNpi uint8
+	// CString, max 21
+	DestinationAddr string
+}
+
+func (c *CancelSm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	c.Header = smpp.ReadHeader(r)
+	c.ServiceType = r.ReadCString()
+	c.MessageID = r.ReadCString()
+	c.SourceAddrTon = r.ReadUint8()
+	c.SourceAddrNpi = r.ReadUint8()
+	c.SourceAddr = r.ReadCString()
+	c.DestAddrTon = r.ReadUint8()
+	c.DestAddrNpi = r.ReadUint8()
+	c.DestinationAddr = r.ReadCString()
+
+	return r.Error()
+}
+
+func (c *CancelSm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(c.Header, w)
+	w.WriteCString(c.ServiceType)
+	w.WriteCString(c.MessageID)
+	w.WriteUint8(c.SourceAddrTon)
+	w.WriteUint8(c.SourceAddrNpi)
+	w.WriteCString(c.SourceAddr)
+	w.WriteUint8(c.DestAddrTon)
+	w.WriteUint8(c.DestAddrNpi)
+	w.WriteCString(c.DestinationAddr)
+
+	return w.BytesWithLength()
+}
+
+func (c *CancelSm) SetSequenceID(id uint32) {
+	c.Header.Sequence = id
+}
+
+func (c *CancelSm) GetSequenceID() uint32 {
+	return c.Header.Sequence
+}
+
+func (c *CancelSm) GetCommand() sms.ICommander {
+	return smpp.CANCEL_SM
+}
+
+func (c *CancelSm) GenEmptyResponse() sms.PDU {
+	return &CancelSmResp{
+		Header: smpp.Header{
+			ID:       smpp.CANCEL_SM_RESP,
+			Sequence: c.Header.Sequence,
+		},
+	}
+}
+
+func (c *CancelSm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", c.Header)
+	str.Write("ServiceType", c.ServiceType)
+	str.Write("MessageID", c.MessageID)
+	str.Write("SourceAddrTon", c.SourceAddrTon)
+	str.Write("SourceAddrNpi", c.SourceAddrNpi)
+	str.Write("SourceAddr", c.SourceAddr)
+	str.Write("DestAddrTon", c.DestAddrTon)
+	str.Write("DestAddrNpi", c.DestAddrNpi)
+	str.Write("DestinationAddr", c.DestinationAddr)
+
+	return str.String()
+}
+
+type CancelSmResp struct {
+	smpp.Header
+}
+
+func (c *CancelSmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	c.Header = smpp.ReadHeader(r)
+	return r.Error()
+}
+
+func (c *CancelSmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(c.Header, w)
+
+	return w.BytesWithLength()
+}
+
+func (c *CancelSmResp) SetSequenceID(id uint32) {
+	c.Header.Sequence = id
+}
+
+func (c *CancelSmResp) GetSequenceID() uint32 {
+	return c.Header.Sequence
+}
+
+func (c *CancelSmResp) GetCommand() sms.ICommander {
+	return smpp.CANCEL_SM_RESP
+}
+
+func (c *CancelSmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (c *CancelSmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", c.Header)
+
+	return str.String()
+}
@@ -0,0 +1,55 @@
+package smpp34
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestCancelSm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 45, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 7, // header
+		0,                               // service_type
+		'a', 'b', 'c', '1', '2', '3', 0, // message_id
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+		1, 1, // dest_addr_ton, dest_addr_npi
+		'1', '3', '8', '0', '0', '0', '0', '0', '0', '0', '0', 0, // destination_addr
+	}
+	c := &CancelSm{
+		Header:          smpp.Header{ID: smpp.CANCEL_SM, Sequence: 7},
+		MessageID:       "abc123",
+		SourceAddrTon:   1,
+		SourceAddrNpi:   1,
+		SourceAddr:      "1065",
+		DestAddrTon:     1,
+		DestAddrNpi:     1,
+		DestinationAddr: "13800000000",
+	}
+
+	data, err := c.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*CancelSm)
+	if assert.True(t, ok) {
+		c.Header.Length = 45
+		assert.Equal(t, c, decoded)
+		assert.Equal(t, smpp.CANCEL_SM, decoded.GetCommand())
+	}
+
+	resp := c.GenEmptyResponse()
+	assert.Equal(t, uint32(7), resp.GetSequenceID())
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 16, 0x80, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0, 7}, data)
+
+	pdu, err = DecodeSMPP34(data)
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.CANCEL_SM_RESP, pdu.GetCommand())
+	assert.Nil(t, pdu.GenEmptyResponse())
+}
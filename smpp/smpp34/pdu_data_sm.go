@@ -0,0 +1,202 @@
+package smpp34
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+// DataSm is an alternative to submit_sm and deliver_sm, used in both directions.
+// It has no short_message, and the message is carried in the message_payload TLV.
+type DataSm struct {
+	smpp.Header
+
+	// CString, max 6
+	ServiceType string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 65
+	SourceAddr string
+
+	DestAddrTon uint8
+	DestAddrNpi uint8
+	// CString, max 65
+	DestinationAddr string
+
+	ESMClass           uint8
+	RegisteredDelivery uint8
+	DataCoding         uint8
+
+	TLVs smpp.TLVs
+}
+
+func (d *DataSm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	d.Header = smpp.ReadHeader(r)
+	d.ServiceType = r.ReadCString()
+	d.SourceAddrTon = r.ReadUint8()
+	d.SourceAddrNpi = r.ReadUint8()
+	d.SourceAddr = r.ReadCString()
+	d.DestAddrTon = r.ReadUint8()
+	d.DestAddrNpi = r.ReadUint8()
+	d.DestinationAddr = r.ReadCString()
+	d.ESMClass = r.ReadUint8()
+	d.RegisteredDelivery = r.ReadUint8()
+	d.DataCoding = r.ReadUint8()
+	tlvs, err := smpp.ReadTLVs(r)
+	if err != nil {
+		return err
+	}
+	d.TLVs = tlvs
+
+	return r.Error()
+}
+
+func (d *DataSm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(d.Header, w)
+
+	w.WriteCString(d.ServiceType)
+	w.WriteUint8(d.SourceAddrTon)
+	w.WriteUint8(d.SourceAddrNpi)
+	w.WriteCString(d.SourceAddr)
+	w.WriteUint8(d.DestAddrTon)
+	w.WriteUint8(d.DestAddrNpi)
+	w.WriteCString(d.DestinationAddr)
+	w.WriteUint8(d.ESMClass)
+	w.WriteUint8(d.RegisteredDelivery)
+	w.WriteUint8(d.DataCoding)
+	w.WriteBytes(d.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (d *DataSm) SetSequenceID(id uint32) {
+	d.Header.Sequence = id
+}
+
+func (d *DataSm) GetSequenceID() uint32 {
+	return d.Header.Sequence
+}
+
+func (d *DataSm) GetCommand() sms.ICommander {
+	return smpp.DATA_SM
+}
+
+func (d *DataSm) GenEmptyResponse() sms.PDU {
+	return &DataSmResp{
+		Header: smpp.Header{
+			ID:       smpp.DATA_SM_RESP,
+			Sequence: d.Header.Sequence,
+		},
+	}
+}
+
+// MessageContent returns the message_payload TLV.
+func (d *DataSm) MessageContent() []byte {
+	return smpp.MessageContent(nil, d.TLVs)
+}
+
+func (d *DataSm) GetDataCoding() uint8 {
+	return d.DataCoding
+}
+
+func (d *DataSm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", d.Header)
+	str.Write("ServiceType", d.ServiceType)
+	str.Write("SourceAddrTon", d.SourceAddrTon)
+	str.Write("SourceAddrNpi", d.SourceAddrNpi)
+	str.Write("SourceAddr", d.SourceAddr)
+	str.Write("DestAddrTon", d.DestAddrTon)
+	str.Write("DestAddrNpi", d.DestAddrNpi)
+	str.Write("DestinationAddr", d.DestinationAddr)
+	str.Write("ESMClass", d.ESMClass)
+	str.Write("RegisteredDelivery", d.RegisteredDelivery)
+	str.Write("DataCoding", d.DataCoding)
+	str.OmitWrite("TLVs", d.TLVs.String())
+
+	return str.String()
+}
+
+type DataSmResp struct {
+	smpp.Header
+
+	// CString, max 65
+	MessageID string
+
+	// Optional: delivery_failure_reason, network_error_code, additional_status_info_text and dpf_result
+	TLVs smpp.TLVs
+}
+
+func (d *DataSmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	d.Header = smpp.ReadHeader(r)
+	// the body may be empty if command_status is not ESME_ROK
+	if len(data) == smpp.MinSMPPPacketLen {
+		return r.Error()
+	}
+	d.MessageID = r.ReadCString()
+	tlvs, err := smpp.ReadTLVs(r)
+	if err != nil {
+		return err
+	}
+	d.TLVs = tlvs
+
+	return r.Error()
+}
+
+func (d *DataSmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(d.Header, w)
+	w.WriteCString(d.MessageID)
+	w.WriteBytes(d.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (d *DataSmResp) SetSequenceID(id uint32) {
+	d.Header.Sequence = id
+}
+
+func (d *DataSmResp) GetSequenceID() uint32 {
+	return d.Header.Sequence
+}
+
+func (d *DataSmResp) GetCommand() sms.ICommander {
+	return smpp.DATA_SM_RESP
+}
+
+func (d *DataSmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (d *DataSmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", d.Header)
+	str.Write("MessageID", d.MessageID)
+	str.OmitWrite("TLVs", d.TLVs.String())
+
+	return str.String()
+}
@@ -0,0 +1,87 @@
+package smpp34
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestDataSm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 39, 0, 0, 1, 3, 0, 0, 0, 0, 0, 0, 0, 9, // header
+		0,    // service_type
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+		1, 1, // dest_addr_ton, dest_addr_npi
+		'1', '2', '3', 0, // destination_addr
+		0, 1, 0, // esm_class, registered_delivery, data_coding
+		0x04, 0x24, 0, 2, 'h', 'i', // message_payload
+	}
+	d := &DataSm{
+		Header:             smpp.Header{ID: smpp.DATA_SM, Sequence: 9},
+		SourceAddrTon:      1,
+		SourceAddrNpi:      1,
+		SourceAddr:         "1065",
+		DestAddrTon:        1,
+		DestAddrNpi:        1,
+		DestinationAddr:    "123",
+		RegisteredDelivery: 1,
+	}
+	d.TLVs.SetTLV(smpp.NewTLVByString(smpp.MESSAGE_PAYLOAD, "hi"))
+
+	data, err := d.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*DataSm)
+	if assert.True(t, ok) {
+		d.Header.Length = 39
+		assert.Equal(t, d, decoded)
+		assert.Equal(t, smpp.DATA_SM, decoded.GetCommand())
+		assert.Equal(t, []byte("hi"), decoded.MessageContent())
+	}
+
+	resp := d.GenEmptyResponse().(*DataSmResp)
+	assert.Equal(t, smpp.DATA_SM_RESP, resp.GetCommand())
+	assert.Equal(t, uint32(9), resp.GetSequenceID())
+}
+
+func TestDataSmResp(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 20, 0x80, 0, 1, 3, 0, 0, 0, 0, 0, 0, 0, 9, // header
+		'i', 'd', '1', 0, // message_id
+	}
+	d := &DataSmResp{Header: smpp.Header{ID: smpp.DATA_SM_RESP, Sequence: 9}, MessageID: "id1"}
+
+	data, err := d.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*DataSmResp)
+	if assert.True(t, ok) {
+		assert.Equal(t, "id1", decoded.MessageID)
+		assert.Nil(t, decoded.GenEmptyResponse())
+	}
+
+	// header only
+	pdu, err = DecodeSMPP34([]byte{0, 0, 0, 16, 0x80, 0, 1, 3, 0, 0, 0, 0x08, 0, 0, 0, 9})
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.ESME_RSYSERR, pdu.(*DataSmResp).Status)
+}
+
+func TestMessageContent(t *testing.T) {
+	s := &SubmitSm{ShortMessage: []byte("short")}
+	s.TLVs.SetTLV(smpp.NewTLVByString(smpp.MESSAGE_PAYLOAD, "payload"))
+	assert.Equal(t, []byte("short"), s.MessageContent())
+
+	d := &DeliverSm{}
+	assert.Nil(t, d.MessageContent())
+	d.TLVs.SetTLV(smpp.NewTLVByString(smpp.MESSAGE_PAYLOAD, "payload"))
+	assert.Equal(t, []byte("payload"), d.MessageContent())
+}
@@ -69,7 +69,11 @@ func (d *DeliverSm) IDecode(data []byte) error {
 	d.SmDefaultMsgId = buf.ReadUint8()
 	d.SmLength = buf.ReadUint8()
 	d.ShortMessage = buf.ReadNBytes(int(d.SmLength))
-	d.TLVs = smpp.ReadTLVs1(buf)
+	tlvs, err := smpp.ReadTLVs(buf)
+	if err != nil {
+		return err
+	}
+	d.TLVs = tlvs
 
 	return buf.Error()
 }
@@ -124,6 +128,15 @@ func (d *DeliverSm) GenEmptyResponse() sms.PDU {
 	}
 }
 
+// MessageContent returns ShortMessage, or the message_payload TLV if ShortMessage is empty.
+func (d *DeliverSm) MessageContent() []byte {
+	return smpp.MessageContent(d.ShortMessage, d.TLVs)
+}
+
+func (d *DeliverSm) GetDataCoding() uint8 {
+	return d.DataCoding
+}
+
 func (d *DeliverSm) String() string {
 	s := packet.NewPDUStringer()
 	defer s.Release()
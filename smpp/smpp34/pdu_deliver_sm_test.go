@@ -2,6 +2,7 @@ package smpp34
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -192,3 +193,27 @@ func (s *DeliverSmRespTestSuite) TestDeliverSMResp_GenEmptyResponse() {
 func TestDeliveyRespSuite(t *testing.T) {
 	suite.Run(t, new(DeliverSmRespTestSuite))
 }
+
+func TestDeliverSm_InvalidTLVLength(t *testing.T) {
+	d := &DeliverSm{
+		Header:          smpp.Header{ID: smpp.DELIVER_SM, Sequence: 3},
+		SourceAddr:      "1065",
+		DestinationAddr: "123",
+		SmLength:        2,
+		ShortMessage:    []byte("hi"),
+	}
+	// sar_msg_ref_num must be 2 bytes, some SMSCs send 1
+	d.TLVs.SetTLV(smpp.TLV{Tag: smpp.SAR_MSG_REF_NUM, Length: 1, ValueBytes: []byte{7}})
+	d.TLVs.SetTLV(smpp.NewTLVByString(smpp.RECEIPTED_MESSAGE_ID, "id1\x00"))
+	data, err := d.IEncode()
+	assert.Nil(t, err)
+
+	pdu, err := DecodeSMPP34(data)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*DeliverSm)
+	if assert.True(t, ok) {
+		assert.Equal(t, "id1", decoded.TLVs.ReceiptedMessageID())
+		assert.Equal(t, []byte{7}, decoded.TLVs[smpp.SAR_MSG_REF_NUM].ValueBytes)
+		assert.True(t, errors.Is(decoded.TLVs.Validate(), smpp.ErrInvalidTLVLength))
+	}
+}
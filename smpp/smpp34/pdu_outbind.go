@@ -0,0 +1,85 @@
+package smpp34
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+// Outbind is sent by an SMSC that connects to an ESME, asking it to bind_receiver.
+// There is no response; the ESME answers with a bind_receiver on the same connection.
+type Outbind struct {
+	smpp.Header
+
+	// CString, max 16. Identifies the SMSC to the ESME.
+	SystemID string
+
+	// CString, max 9
+	Password string
+}
+
+func (o *Outbind) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	o.Header = smpp.ReadHeader(r)
+	o.SystemID = r.ReadCString()
+	o.Password = r.ReadCString()
+
+	return r.Error()
+}
+
+func (o *Outbind) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(o.Header, w)
+	w.WriteCString(o.SystemID)
+	w.WriteCString(o.Password)
+
+	return w.BytesWithLength()
+}
+
+func (o *Outbind) SetSequenceID(id uint32) {
+	o.Header.Sequence = id
+}
+
+func (o *Outbind) GetSequenceID() uint32 {
+	return o.Header.Sequence
+}
+
+func (o *Outbind) GetCommand() sms.ICommander {
+	return smpp.OUTBIND
+}
+
+// GenEmptyResponse returns nil, since outbind has no response.
+func (o *Outbind) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+// GenBindReceiver returns the bind_receiver answering the outbind.
+// The sequence number is left to the sender.
+func (o *Outbind) GenBindReceiver(systemID, password string) *Bind {
+	return &Bind{
+		Header:           smpp.Header{ID: smpp.BIND_RECEIVER},
+		SystemID:         systemID,
+		Password:         password,
+		InterfaceVersion: uint8(consts.SMPPVersion3_4),
+	}
+}
+
+func (o *Outbind) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", o.Header)
+	str.Write("SystemID", o.SystemID)
+	str.Write("Password", o.Password)
+
+	return str.String()
+}
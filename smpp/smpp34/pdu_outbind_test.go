@@ -0,0 +1,68 @@
+package smpp34
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestOutbind(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 28, 0, 0, 0, 0x0B, 0, 0, 0, 0, 0, 0, 0, 1, // header
+		's', 'm', 's', 'c', 0, // system_id
+		's', 'e', 'c', 'r', 'e', 't', 0, // password
+	}
+	o := &Outbind{Header: smpp.Header{ID: smpp.OUTBIND, Sequence: 1}, SystemID: "smsc", Password: "secret"}
+
+	data, err := o.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*Outbind)
+	if assert.True(t, ok) {
+		o.Header.Length = 28
+		assert.Equal(t, o, decoded)
+		assert.Equal(t, smpp.OUTBIND, decoded.GetCommand())
+		assert.Nil(t, decoded.GenEmptyResponse())
+	}
+
+	bind := o.GenBindReceiver("esme", "pwd")
+	assert.Equal(t, smpp.BIND_RECEIVER, bind.Header.ID)
+	assert.Equal(t, "esme", bind.SystemID)
+	assert.Equal(t, uint8(0x34), bind.InterfaceVersion)
+}
+
+func TestAlertNotification(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 36, 0, 0, 1, 2, 0, 0, 0, 0, 0, 0, 0, 3, // header
+		1, 1, '1', '3', '8', '0', '0', 0, // source_addr_ton, source_addr_npi, source_addr
+		0, 0, '1', '0', '6', '5', 0, // esme_addr_ton, esme_addr_npi, esme_addr
+		0x04, 0x22, 0, 1, 0, // ms_availability_status
+	}
+	a := &AlertNotification{
+		Header:        smpp.Header{ID: smpp.ALERT_NOTIFICATION, Sequence: 3},
+		SourceAddrTon: 1,
+		SourceAddrNpi: 1,
+		SourceAddr:    "13800",
+		EsmeAddr:      "1065",
+	}
+	a.TLVs.SetTLV(smpp.NewTLV(smpp.MS_AVAILABILITY_STATUS, []byte{0}))
+
+	data, err := a.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*AlertNotification)
+	if assert.True(t, ok) {
+		a.Header.Length = 36
+		assert.Equal(t, a, decoded)
+		assert.Equal(t, smpp.ALERT_NOTIFICATION, decoded.GetCommand())
+		assert.Nil(t, decoded.GenEmptyResponse())
+	}
+}
@@ -0,0 +1,162 @@
+package smpp34
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+type QuerySm struct {
+	smpp.Header
+
+	// CString, max 65. Message ID of the message whose state is to be queried.
+	MessageID string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21. Must match the source address of the original message.
+	SourceAddr string
+}
+
+func (q *QuerySm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	q.Header = smpp.ReadHeader(r)
+	q.MessageID = r.ReadCString()
+	q.SourceAddrTon = r.ReadUint8()
+	q.SourceAddrNpi = r.ReadUint8()
+	q.SourceAddr = r.ReadCString()
+
+	return r.Error()
+}
+
+func (q *QuerySm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(q.Header, w)
+	w.WriteCString(q.MessageID)
+	w.WriteUint8(q.SourceAddrTon)
+	w.WriteUint8(q.SourceAddrNpi)
+	w.WriteCString(q.SourceAddr)
+
+	return w.BytesWithLength()
+}
+
+func (q *QuerySm) SetSequenceID(id uint32) {
+	q.Header.Sequence = id
+}
+
+func (q *QuerySm) GetSequenceID() uint32 {
+	return q.Header.Sequence
+}
+
+func (q *QuerySm) GetCommand() sms.ICommander {
+	return smpp.QUERY_SM
+}
+
+func (q *QuerySm) GenEmptyResponse() sms.PDU {
+	return &QuerySmResp{
+		Header: smpp.Header{
+			ID:       smpp.QUERY_SM_RESP,
+			Sequence: q.Header.Sequence,
+		},
+		MessageID: q.MessageID,
+	}
+}
+
+func (q *QuerySm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", q.Header)
+	str.Write("MessageID", q.MessageID)
+	str.Write("SourceAddrTon", q.SourceAddrTon)
+	str.Write("SourceAddrNpi", q.SourceAddrNpi)
+	str.Write("SourceAddr", q.SourceAddr)
+
+	return str.String()
+}
+
+type QuerySmResp struct {
+	smpp.Header
+
+	// CString, max 65
+	MessageID string
+
+	// CString, 1 or 17. Date and time when the message reached its final state, empty if not final.
+	FinalDate string
+
+	MessageState smpp.MessageState
+
+	// Network error code, 0 if none.
+	ErrorCode uint8
+}
+
+func (q *QuerySmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	q.Header = smpp.ReadHeader(r)
+	// the body is empty if command_status is not ESME_ROK
+	if len(data) == smpp.MinSMPPPacketLen {
+		return r.Error()
+	}
+	q.MessageID = r.ReadCString()
+	q.FinalDate = r.ReadCString()
+	q.MessageState = smpp.MessageState(r.ReadUint8())
+	q.ErrorCode = r.ReadUint8()
+
+	return r.Error()
+}
+
+func (q *QuerySmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(q.Header, w)
+	w.WriteCString(q.MessageID)
+	w.WriteCString(q.FinalDate)
+	w.WriteUint8(uint8(q.MessageState))
+	w.WriteUint8(q.ErrorCode)
+
+	return w.BytesWithLength()
+}
+
+func (q *QuerySmResp) SetSequenceID(id uint32) {
+	q.Header.Sequence = id
+}
+
+func (q *QuerySmResp) GetSequenceID() uint32 {
+	return q.Header.Sequence
+}
+
+func (q *QuerySmResp) GetCommand() sms.ICommander {
+	return smpp.QUERY_SM_RESP
+}
+
+func (q *QuerySmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (q *QuerySmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", q.Header)
+	str.Write("MessageID", q.MessageID)
+	str.Write("FinalDate", q.FinalDate)
+	str.Write("MessageState", q.MessageState)
+	str.Write("ErrorCode", q.ErrorCode)
+
+	return str.String()
+}
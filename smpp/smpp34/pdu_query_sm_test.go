@@ -0,0 +1,78 @@
+package smpp34
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestQuerySm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 30, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 5, // header
+		'a', 'b', 'c', '1', '2', '3', 0, // message_id
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+	}
+	q := &QuerySm{
+		Header:        smpp.Header{ID: smpp.QUERY_SM, Sequence: 5},
+		MessageID:     "abc123",
+		SourceAddrTon: 1,
+		SourceAddrNpi: 1,
+		SourceAddr:    "1065",
+	}
+
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*QuerySm)
+	if assert.True(t, ok) {
+		q.Header.Length = 30
+		assert.Equal(t, q, decoded)
+		assert.Equal(t, smpp.QUERY_SM, decoded.GetCommand())
+	}
+
+	resp := q.GenEmptyResponse().(*QuerySmResp)
+	assert.Equal(t, uint32(5), resp.GetSequenceID())
+	assert.Equal(t, "abc123", resp.MessageID)
+}
+
+func TestQuerySmResp(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 42, 0x80, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 5, // header
+		'a', 'b', 'c', '1', '2', '3', 0, // message_id
+		'2', '3', '1', '0', '1', '7', '1', '2', '0', '0', '0', '0', '0', '0', '0', '+', 0, // final_date
+		2, // message_state
+		0, // error_code
+	}
+	q := &QuerySmResp{
+		Header:       smpp.Header{ID: smpp.QUERY_SM_RESP, Sequence: 5},
+		MessageID:    "abc123",
+		FinalDate:    "231017120000000+",
+		MessageState: smpp.MESSAGE_STATE_DELIVERED,
+	}
+
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*QuerySmResp)
+	if assert.True(t, ok) {
+		q.Header.Length = 42
+		assert.Equal(t, q, decoded)
+		assert.Equal(t, "DELIVERED", decoded.MessageState.String())
+		assert.True(t, decoded.MessageState.IsFinal())
+		assert.Nil(t, decoded.GenEmptyResponse())
+	}
+
+	// an error response has no body
+	pdu, err = DecodeSMPP34([]byte{0, 0, 0, 16, 0x80, 0, 0, 3, 0, 0, 0, 0x67, 0, 0, 0, 6})
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.ESME_RQUERYFAIL, pdu.(*QuerySmResp).Header.Status)
+}
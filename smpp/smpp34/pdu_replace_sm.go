@@ -0,0 +1,163 @@
+package smpp34
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+type ReplaceSm struct {
+	smpp.Header
+
+	// CString, max 65. Message ID of the message to be replaced.
+	MessageID string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21. Must match the source address of the original message.
+	SourceAddr string
+
+	// CString, 1~17
+	ScheduleDeliveryTime string
+	// CString, 1~17
+	ValidityPeriod string
+
+	RegisteredDelivery uint8
+	SmDefaultMsgID     uint8
+
+	SmLength uint8
+	// Uint8, max 254
+	ShortMessage []byte
+}
+
+func (s *ReplaceSm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	s.Header = smpp.ReadHeader(r)
+	s.MessageID = r.ReadCString()
+	s.SourceAddrTon = r.ReadUint8()
+	s.SourceAddrNpi = r.ReadUint8()
+	s.SourceAddr = r.ReadCString()
+	s.ScheduleDeliveryTime = r.ReadCString()
+	s.ValidityPeriod = r.ReadCString()
+	s.RegisteredDelivery = r.ReadUint8()
+	s.SmDefaultMsgID = r.ReadUint8()
+	s.SmLength = r.ReadUint8()
+	s.ShortMessage = r.ReadNBytes(int(s.SmLength))
+
+	return r.Error()
+}
+
+func (s *ReplaceSm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(s.Header, w)
+	w.WriteCString(s.MessageID)
+	w.WriteUint8(s.SourceAddrTon)
+	w.WriteUint8(s.SourceAddrNpi)
+	w.WriteCString(s.SourceAddr)
+	w.WriteCString(s.ScheduleDeliveryTime)
+	w.WriteCString(s.ValidityPeriod)
+	w.WriteUint8(s.RegisteredDelivery)
+	w.WriteUint8(s.SmDefaultMsgID)
+	w.WriteUint8(s.SmLength)
+	w.WriteBytes(s.ShortMessage)
+
+	return w.BytesWithLength()
+}
+
+func (s *ReplaceSm) SetSequenceID(id uint32) {
+	s.Header.Sequence = id
+}
+
+func (s *ReplaceSm) GetSequenceID() uint32 {
+	return s.Header.Sequence
+}
+
+func (s *ReplaceSm) GetCommand() sms.ICommander {
+	return smpp.REPLACE_SM
+}
+
+func (s *ReplaceSm) GenEmptyResponse() sms.PDU {
+	return &ReplaceSmResp{
+		Header: smpp.Header{
+			ID:       smpp.REPLACE_SM_RESP,
+			Sequence: s.Header.Sequence,
+		},
+	}
+}
+
+func (s *ReplaceSm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", s.Header)
+	str.Write("MessageID", s.MessageID)
+	str.Write("SourceAddrTon", s.SourceAddrTon)
+	str.Write("SourceAddrNpi", s.SourceAddrNpi)
+	str.Write("SourceAddr", s.SourceAddr)
+	str.Write("ScheduleDeliveryTime", s.ScheduleDeliveryTime)
+	str.Write("ValidityPeriod", s.ValidityPeriod)
+	str.Write("RegisteredDelivery", s.RegisteredDelivery)
+	str.Write("SmDefaultMsgID", s.SmDefaultMsgID)
+	str.Write("SmLength", s.SmLength)
+	str.Write("ShortMessage", s.ShortMessage)
+
+	return str.String()
+}
+
+type ReplaceSmResp struct {
+	smpp.Header
+}
+
+func (s *ReplaceSmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	s.Header = smpp.ReadHeader(r)
+	return r.Error()
+}
+
+func (s *ReplaceSmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(s.Header, w)
+
+	return w.BytesWithLength()
+}
+
+func (s *ReplaceSmResp) SetSequenceID(id uint32) {
+	s.Header.Sequence = id
+}
+
+func (s *ReplaceSmResp) GetSequenceID() uint32 {
+	return s.Header.Sequence
+}
+
+func (s *ReplaceSmResp) GetCommand() sms.ICommander {
+	return smpp.REPLACE_SM_RESP
+}
+
+func (s *ReplaceSmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (s *ReplaceSmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", s.Header)
+
+	return str.String()
+}
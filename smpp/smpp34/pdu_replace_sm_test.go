@@ -0,0 +1,58 @@
+package smpp34
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestReplaceSm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 53, 0, 0, 0, 7, 0, 0, 0, 0, 0, 0, 0, 9, // header
+		'a', 'b', 'c', '1', '2', '3', 0, // message_id
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+		0,                                                                                 // schedule_delivery_time
+		'0', '0', '0', '0', '0', '1', '0', '0', '0', '0', '0', '0', '0', '0', '0', 'R', 0, // validity_period
+		1, // registered_delivery
+		0, // sm_default_msg_id
+		2, // sm_length
+		'h', 'i',
+	}
+	s := &ReplaceSm{
+		Header:             smpp.Header{ID: smpp.REPLACE_SM, Sequence: 9},
+		MessageID:          "abc123",
+		SourceAddrTon:      1,
+		SourceAddrNpi:      1,
+		SourceAddr:         "1065",
+		ValidityPeriod:     "000001000000000R",
+		RegisteredDelivery: 1,
+		SmLength:           2,
+		ShortMessage:       []byte("hi"),
+	}
+
+	data, err := s.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*ReplaceSm)
+	if assert.True(t, ok) {
+		s.Header.Length = 53
+		assert.Equal(t, s, decoded)
+		assert.Equal(t, smpp.REPLACE_SM, decoded.GetCommand())
+	}
+
+	resp := s.GenEmptyResponse()
+	assert.Equal(t, uint32(9), resp.GetSequenceID())
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+
+	pdu, err = DecodeSMPP34(data)
+	assert.Nil(t, err)
+	assert.IsType(t, &ReplaceSmResp{}, pdu)
+	assert.Equal(t, smpp.REPLACE_SM_RESP, pdu.GetCommand())
+}
@@ -0,0 +1,441 @@
+package smpp34
+
+import (
+	"errors"
+	"fmt"
+
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+var (
+	// ErrTooManyDests indicates that a SubmitMulti has more than smpp.MAX_NUMBER_OF_DESTS destinations. Use Split first.
+	ErrTooManyDests = errors.New("too many destinations")
+
+	// ErrInvalidDestFlag indicates that the dest_flag of a dest_address is neither SME Address nor Distribution List.
+	ErrInvalidDestFlag = errors.New("invalid dest_flag")
+)
+
+// DefaultMaxSubmitMultiLength is the default encoded size budget of Split, the same as the default frame limit of codec.SMPPCodec.
+const DefaultMaxSubmitMultiLength = smpp.MAX_PDU_SIZE
+
+// DestAddress is a dest_address of submit_multi, either an SME address or a distribution list name.
+type DestAddress struct {
+	// smpp.DEST_FLAG_SME_ADDRESS or smpp.DEST_FLAG_DISTRIBUTION_LIST
+	DestFlag uint8
+
+	// Only for smpp.DEST_FLAG_SME_ADDRESS
+	DestAddrTon uint8
+	DestAddrNpi uint8
+	// CString, max 21. Only for smpp.DEST_FLAG_SME_ADDRESS
+	DestinationAddr string
+
+	// CString, max 21. Only for smpp.DEST_FLAG_DISTRIBUTION_LIST
+	DlName string
+}
+
+// NewSMEAddress returns a DestAddress of an SME address.
+func NewSMEAddress(ton, npi uint8, addr string) DestAddress {
+	return DestAddress{DestFlag: smpp.DEST_FLAG_SME_ADDRESS, DestAddrTon: ton, DestAddrNpi: npi, DestinationAddr: addr}
+}
+
+// NewDistributionList returns a DestAddress of a distribution list name.
+func NewDistributionList(name string) DestAddress {
+	return DestAddress{DestFlag: smpp.DEST_FLAG_DISTRIBUTION_LIST, DlName: name}
+}
+
+func (d DestAddress) String() string {
+	if d.DestFlag == smpp.DEST_FLAG_DISTRIBUTION_LIST {
+		return fmt.Sprintf("{DestFlag=%d, DlName=%s}", d.DestFlag, d.DlName)
+	}
+	return fmt.Sprintf("{DestFlag=%d, DestAddrTon=%d, DestAddrNpi=%d, DestinationAddr=%s}", d.DestFlag, d.DestAddrTon, d.DestAddrNpi, d.DestinationAddr)
+}
+
+type SubmitMulti struct {
+	smpp.Header
+
+	// CString, max 6
+	ServiceType string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21
+	SourceAddr string
+
+	// Uint8, max 254. IEncode writes len(DestAddresses) instead.
+	NumberOfDests uint8
+	DestAddresses []DestAddress
+
+	ESMClass     uint8
+	ProtocolID   uint8
+	PriorityFlag uint8
+
+	// CString, 1~17
+	ScheduleDeliveryTime string
+	// CString, 1~17
+	ValidityPeriod string
+
+	RegisteredDelivery   uint8
+	ReplaceIfPresentFlag uint8
+	DataCoding           uint8
+	SmDefaultMsgID       uint8
+
+	SmLength uint8
+	// Uint8, max 254
+	ShortMessage []byte
+
+	TLVs smpp.TLVs
+}
+
+// NewSubmitMulti returns a SubmitMulti to the numbers, which are SME addresses with the same ton and npi.
+// The other fields are left to the caller. It returns ErrTooManyDests if there are more than smpp.MAX_NUMBER_OF_DESTS
+// numbers, to send more, set DestAddresses directly and use Split.
+func NewSubmitMulti(ton, npi uint8, numbers []string) (*SubmitMulti, error) {
+	s := &SubmitMulti{Header: smpp.Header{ID: smpp.SUBMIT_MULTI}}
+	if err := s.AddSMEAddresses(ton, npi, numbers...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddSMEAddresses appends SME addresses to DestAddresses and updates NumberOfDests.
+// It returns ErrTooManyDests and appends nothing if there would be more than smpp.MAX_NUMBER_OF_DESTS destinations.
+func (s *SubmitMulti) AddSMEAddresses(ton, npi uint8, numbers ...string) error {
+	if err := s.checkDests(len(numbers)); err != nil {
+		return err
+	}
+	for _, number := range numbers {
+		s.DestAddresses = append(s.DestAddresses, NewSMEAddress(ton, npi, number))
+	}
+	s.NumberOfDests = uint8(len(s.DestAddresses))
+	return nil
+}
+
+// AddDistributionLists appends distribution list names to DestAddresses and updates NumberOfDests.
+// It returns ErrTooManyDests and appends nothing if there would be more than smpp.MAX_NUMBER_OF_DESTS destinations.
+func (s *SubmitMulti) AddDistributionLists(names ...string) error {
+	if err := s.checkDests(len(names)); err != nil {
+		return err
+	}
+	for _, name := range names {
+		s.DestAddresses = append(s.DestAddresses, NewDistributionList(name))
+	}
+	s.NumberOfDests = uint8(len(s.DestAddresses))
+	return nil
+}
+
+func (s *SubmitMulti) checkDests(n int) error {
+	if total := len(s.DestAddresses) + n; total > smpp.MAX_NUMBER_OF_DESTS {
+		return fmt.Errorf("%w: %d", ErrTooManyDests, total)
+	}
+	return nil
+}
+
+// encodedLength returns the number of bytes d takes in a submit_multi.
+func (d DestAddress) encodedLength() int {
+	if d.DestFlag == smpp.DEST_FLAG_DISTRIBUTION_LIST {
+		return 1 + len(d.DlName) + 1
+	}
+	return 1 + 2 + len(d.DestinationAddr) + 1
+}
+
+// Split splits the SubmitMulti into several ones with at most max destinations each,
+// and an encoded size of at most maxLength bytes each, so that the peer's codec accepts them.
+// If max is not in (0, smpp.MAX_NUMBER_OF_DESTS], smpp.MAX_NUMBER_OF_DESTS is used.
+// If maxLength <= 0, DefaultMaxSubmitMultiLength is used. A destination which does not fit even alone
+// is still put into its own SubmitMulti.
+// All other fields are copied, and the sequence IDs are left to the sender.
+func (s *SubmitMulti) Split(max, maxLength int) []*SubmitMulti {
+	if max <= 0 || max > smpp.MAX_NUMBER_OF_DESTS {
+		max = smpp.MAX_NUMBER_OF_DESTS
+	}
+	if maxLength <= 0 {
+		maxLength = DefaultMaxSubmitMultiLength
+	}
+	if len(s.DestAddresses) == 0 {
+		return []*SubmitMulti{s.clone(nil)}
+	}
+
+	// the size without any destination
+	overhead := 0
+	if data, err := s.clone(nil).IEncode(); err == nil {
+		overhead = len(data)
+	}
+
+	var result []*SubmitMulti
+	start, size := 0, overhead
+	for i, d := range s.DestAddresses {
+		n := d.encodedLength()
+		if i > start && (i-start >= max || size+n > maxLength) {
+			result = append(result, s.clone(s.DestAddresses[start:i]))
+			start, size = i, overhead
+		}
+		size += n
+	}
+	return append(result, s.clone(s.DestAddresses[start:]))
+}
+
+// clone returns a deep copy of the SubmitMulti with the given destinations.
+func (s *SubmitMulti) clone(dests []DestAddress) *SubmitMulti {
+	c := *s
+	c.DestAddresses = append([]DestAddress(nil), dests...)
+	c.NumberOfDests = uint8(len(c.DestAddresses))
+	c.ShortMessage = append([]byte(nil), s.ShortMessage...)
+	if s.TLVs != nil {
+		c.TLVs = make(smpp.TLVs, len(s.TLVs))
+		for k, v := range s.TLVs {
+			c.TLVs[k] = v
+		}
+	}
+	return &c
+}
+
+func (s *SubmitMulti) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	s.Header = smpp.ReadHeader(r)
+	s.ServiceType = r.ReadCString()
+	s.SourceAddrTon = r.ReadUint8()
+	s.SourceAddrNpi = r.ReadUint8()
+	s.SourceAddr = r.ReadCString()
+	s.NumberOfDests = r.ReadUint8()
+	s.DestAddresses = make([]DestAddress, 0, s.NumberOfDests)
+	for i := 0; i < int(s.NumberOfDests); i++ {
+		d := DestAddress{DestFlag: r.ReadUint8()}
+		switch d.DestFlag {
+		case smpp.DEST_FLAG_SME_ADDRESS:
+			d.DestAddrTon = r.ReadUint8()
+			d.DestAddrNpi = r.ReadUint8()
+			d.DestinationAddr = r.ReadCString()
+		case smpp.DEST_FLAG_DISTRIBUTION_LIST:
+			d.DlName = r.ReadCString()
+		default:
+			if err := r.Error(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: %d", ErrInvalidDestFlag, d.DestFlag)
+		}
+		s.DestAddresses = append(s.DestAddresses, d)
+	}
+	s.ESMClass = r.ReadUint8()
+	s.ProtocolID = r.ReadUint8()
+	s.PriorityFlag = r.ReadUint8()
+	s.ScheduleDeliveryTime = r.ReadCString()
+	s.ValidityPeriod = r.ReadCString()
+	s.RegisteredDelivery = r.ReadUint8()
+	s.ReplaceIfPresentFlag = r.ReadUint8()
+	s.DataCoding = r.ReadUint8()
+	s.SmDefaultMsgID = r.ReadUint8()
+	s.SmLength = r.ReadUint8()
+	s.ShortMessage = r.ReadNBytes(int(s.SmLength))
+	tlvs, err := smpp.ReadTLVs(r)
+	if err != nil {
+		return err
+	}
+	s.TLVs = tlvs
+
+	return r.Error()
+}
+
+func (s *SubmitMulti) IEncode() ([]byte, error) {
+	if len(s.DestAddresses) > smpp.MAX_NUMBER_OF_DESTS {
+		return nil, fmt.Errorf("%w: %d", ErrTooManyDests, len(s.DestAddresses))
+	}
+
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(s.Header, w)
+
+	w.WriteCString(s.ServiceType)
+	w.WriteUint8(s.SourceAddrTon)
+	w.WriteUint8(s.SourceAddrNpi)
+	w.WriteCString(s.SourceAddr)
+	w.WriteUint8(uint8(len(s.DestAddresses)))
+	for _, d := range s.DestAddresses {
+		w.WriteUint8(d.DestFlag)
+		switch d.DestFlag {
+		case smpp.DEST_FLAG_SME_ADDRESS:
+			w.WriteUint8(d.DestAddrTon)
+			w.WriteUint8(d.DestAddrNpi)
+			w.WriteCString(d.DestinationAddr)
+		case smpp.DEST_FLAG_DISTRIBUTION_LIST:
+			w.WriteCString(d.DlName)
+		default:
+			return nil, fmt.Errorf("%w: %d", ErrInvalidDestFlag, d.DestFlag)
+		}
+	}
+	w.WriteUint8(s.ESMClass)
+	w.WriteUint8(s.ProtocolID)
+	w.WriteUint8(s.PriorityFlag)
+	w.WriteCString(s.ScheduleDeliveryTime)
+	w.WriteCString(s.ValidityPeriod)
+	w.WriteUint8(s.RegisteredDelivery)
+	w.WriteUint8(s.ReplaceIfPresentFlag)
+	w.WriteUint8(s.DataCoding)
+	w.WriteUint8(s.SmDefaultMsgID)
+	w.WriteUint8(s.SmLength)
+	w.WriteBytes(s.ShortMessage)
+	w.WriteBytes(s.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (s *SubmitMulti) SetSequenceID(id uint32) {
+	s.Header.Sequence = id
+}
+
+func (s *SubmitMulti) GetSequenceID() uint32 {
+	return s.Header.Sequence
+}
+
+func (s *SubmitMulti) GetCommand() sms.ICommander {
+	return smpp.SUBMIT_MULTI
+}
+
+func (s *SubmitMulti) GenEmptyResponse() sms.PDU {
+	return &SubmitMultiResp{
+		Header: smpp.Header{
+			ID:       smpp.SUBMIT_MULTI_RESP,
+			Sequence: s.Header.Sequence,
+		},
+	}
+}
+
+func (s *SubmitMulti) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", s.Header)
+	str.Write("ServiceType", s.ServiceType)
+	str.Write("SourceAddrTon", s.SourceAddrTon)
+	str.Write("SourceAddrNpi", s.SourceAddrNpi)
+	str.Write("SourceAddr", s.SourceAddr)
+	str.Write("NumberOfDests", s.NumberOfDests)
+	str.Write("DestAddresses", s.DestAddresses)
+	str.Write("ESMClass", s.ESMClass)
+	str.Write("ProtocolID", s.ProtocolID)
+	str.Write("PriorityFlag", s.PriorityFlag)
+	str.Write("ScheduleDeliveryTime", s.ScheduleDeliveryTime)
+	str.Write("ValidityPeriod", s.ValidityPeriod)
+	str.Write("RegisteredDelivery", s.RegisteredDelivery)
+	str.Write("ReplaceIfPresentFlag", s.ReplaceIfPresentFlag)
+	str.Write("DataCoding", s.DataCoding)
+	str.Write("SmDefaultMsgID", s.SmDefaultMsgID)
+	str.Write("SmLength", s.SmLength)
+	str.Write("ShortMessage", s.ShortMessage)
+	str.OmitWrite("TLVs", s.TLVs.String())
+
+	return str.String()
+}
+
+// UnsuccessSme is an SME the message could not be delivered to, in submit_multi_resp.
+type UnsuccessSme struct {
+	DestAddrTon uint8
+	DestAddrNpi uint8
+	// CString, max 21
+	DestinationAddr string
+
+	// Uint32, the command_status of the failure, e.g. smpp.ESME_RINVDSTADR
+	ErrorStatusCode smpp.CMDStatus
+}
+
+func (u UnsuccessSme) String() string {
+	return fmt.Sprintf("{DestAddrTon=%d, DestAddrNpi=%d, DestinationAddr=%s, ErrorStatusCode=%s}", u.DestAddrTon, u.DestAddrNpi, u.DestinationAddr, u.ErrorStatusCode.Error())
+}
+
+type SubmitMultiResp struct {
+	smpp.Header
+
+	// CString, max 65
+	MessageID string
+
+	// Uint8, number of UnsuccessSmes
+	NoUnsuccess   uint8
+	UnsuccessSmes []UnsuccessSme
+}
+
+// AddUnsuccessSme appends an UnsuccessSme and updates NoUnsuccess.
+func (s *SubmitMultiResp) AddUnsuccessSme(ton, npi uint8, addr string, status smpp.CMDStatus) {
+	s.UnsuccessSmes = append(s.UnsuccessSmes, UnsuccessSme{DestAddrTon: ton, DestAddrNpi: npi, DestinationAddr: addr, ErrorStatusCode: status})
+	s.NoUnsuccess = uint8(len(s.UnsuccessSmes))
+}
+
+func (s *SubmitMultiResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	s.Header = smpp.ReadHeader(r)
+	// the body may be empty if command_status is not ESME_ROK
+	if len(data) == smpp.MinSMPPPacketLen {
+		return r.Error()
+	}
+	s.MessageID = r.ReadCString()
+	s.NoUnsuccess = r.ReadUint8()
+	s.UnsuccessSmes = make([]UnsuccessSme, 0, s.NoUnsuccess)
+	for i := 0; i < int(s.NoUnsuccess); i++ {
+		u := UnsuccessSme{}
+		u.DestAddrTon = r.ReadUint8()
+		u.DestAddrNpi = r.ReadUint8()
+		u.DestinationAddr = r.ReadCString()
+		u.ErrorStatusCode = smpp.CMDStatus(r.ReadUint32())
+		s.UnsuccessSmes = append(s.UnsuccessSmes, u)
+	}
+
+	return r.Error()
+}
+
+func (s *SubmitMultiResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(s.Header, w)
+	w.WriteCString(s.MessageID)
+	w.WriteUint8(s.NoUnsuccess)
+	for _, u := range s.UnsuccessSmes {
+		w.WriteUint8(u.DestAddrTon)
+		w.WriteUint8(u.DestAddrNpi)
+		w.WriteCString(u.DestinationAddr)
+		w.WriteUint32(uint32(u.ErrorStatusCode))
+	}
+
+	return w.BytesWithLength()
+}
+
+func (s *SubmitMultiResp) SetSequenceID(id uint32) {
+	s.Header.Sequence = id
+}
+
+func (s *SubmitMultiResp) GetSequenceID() uint32 {
+	return s.Header.Sequence
+}
+
+func (s *SubmitMultiResp) GetCommand() sms.ICommander {
+	return smpp.SUBMIT_MULTI_RESP
+}
+
+func (s *SubmitMultiResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (s *SubmitMultiResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", s.Header)
+	str.Write("MessageID", s.MessageID)
+	str.Write("NoUnsuccess", s.NoUnsuccess)
+	str.Write("UnsuccessSmes", s.UnsuccessSmes)
+
+	return str.String()
+}
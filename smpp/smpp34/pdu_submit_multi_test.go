@@ -0,0 +1,186 @@
+package smpp34
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestSubmitMulti(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 48, 0, 0, 0, 0x21, 0, 0, 0, 0, 0, 0, 0, 7, // header
+		0,    // service_type
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+		2,                         // number_of_dests
+		1, 1, 1, '1', '2', '3', 0, // SME address
+		2, 'd', 'l', 0, // distribution list
+		0, 0, 0, // esm_class, protocol_id, priority_flag
+		0, 0, // schedule_delivery_time, validity_period
+		1, 0, 0, 0, // registered_delivery, replace_if_present_flag, data_coding, sm_default_msg_id
+		2, 'h', 'i', // sm_length, short_message
+	}
+	s, err := NewSubmitMulti(smpp.TON_International, smpp.NPI_ISDN, []string{"123"})
+	assert.Nil(t, err)
+	assert.Nil(t, s.AddDistributionLists("dl"))
+	s.Sequence = 7
+	s.SourceAddrTon = 1
+	s.SourceAddrNpi = 1
+	s.SourceAddr = "1065"
+	s.RegisteredDelivery = 1
+	s.SmLength = 2
+	s.ShortMessage = []byte("hi")
+	assert.Equal(t, uint8(2), s.NumberOfDests)
+
+	data, err := s.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	// number_of_dests is taken from DestAddresses
+	s.NumberOfDests = 5
+	data, err = s.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+	s.NumberOfDests = 2
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*SubmitMulti)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint32(48), decoded.Length)
+		assert.Equal(t, s.DestAddresses, decoded.DestAddresses)
+		assert.Equal(t, []byte("hi"), decoded.ShortMessage)
+		assert.Equal(t, smpp.SUBMIT_MULTI, decoded.GetCommand())
+	}
+
+	resp := s.GenEmptyResponse().(*SubmitMultiResp)
+	assert.Equal(t, smpp.SUBMIT_MULTI_RESP, resp.GetCommand())
+	assert.Equal(t, uint32(7), resp.GetSequenceID())
+
+	// invalid dest_flag
+	bad := append([]byte(nil), raw...)
+	bad[25] = 3
+	_, err = DecodeSMPP34(bad)
+	assert.True(t, errors.Is(err, ErrInvalidDestFlag))
+}
+
+func TestSubmitMulti_TooManyDests(t *testing.T) {
+	numbers := make([]string, smpp.MAX_NUMBER_OF_DESTS)
+	for i := range numbers {
+		numbers[i] = fmt.Sprintf("%d", i)
+	}
+	s, err := NewSubmitMulti(smpp.TON_International, smpp.NPI_ISDN, numbers)
+	if assert.Nil(t, err) {
+		assert.Equal(t, uint8(254), s.NumberOfDests)
+		assert.True(t, errors.Is(s.AddDistributionLists("dl"), ErrTooManyDests))
+		assert.True(t, errors.Is(s.AddSMEAddresses(1, 1, "1"), ErrTooManyDests))
+		assert.Len(t, s.DestAddresses, smpp.MAX_NUMBER_OF_DESTS)
+	}
+
+	_, err = NewSubmitMulti(smpp.TON_International, smpp.NPI_ISDN, append(numbers, "255"))
+	assert.True(t, errors.Is(err, ErrTooManyDests))
+}
+
+func TestSubmitMulti_Split(t *testing.T) {
+	numbers := make([]string, 600)
+	s := &SubmitMulti{Header: smpp.Header{ID: smpp.SUBMIT_MULTI}}
+	for i := range numbers {
+		numbers[i] = fmt.Sprintf("%d", i)
+		s.DestAddresses = append(s.DestAddresses, NewSMEAddress(smpp.TON_International, smpp.NPI_ISDN, numbers[i]))
+	}
+	s.ShortMessage = []byte("hi")
+	s.SmLength = 2
+
+	_, err := s.IEncode()
+	assert.True(t, errors.Is(err, ErrTooManyDests))
+
+	// short numbers, limited by the count
+	parts := s.Split(0, 0)
+	if assert.Len(t, parts, 3) {
+		assert.Equal(t, uint8(254), parts[0].NumberOfDests)
+		assert.Equal(t, uint8(254), parts[1].NumberOfDests)
+		assert.Equal(t, uint8(92), parts[2].NumberOfDests)
+		assert.Equal(t, numbers[254], parts[1].DestAddresses[0].DestinationAddr)
+		assert.Equal(t, numbers[599], parts[2].DestAddresses[91].DestinationAddr)
+	}
+	for _, p := range parts {
+		_, err := p.IEncode()
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("hi"), p.ShortMessage)
+	}
+
+	parts = s.Split(100, 0)
+	assert.Len(t, parts, 6)
+	parts[0].ShortMessage[0] = 'H'
+	assert.Equal(t, []byte("hi"), s.ShortMessage)
+
+	assert.Len(t, (&SubmitMulti{}).Split(0, 0), 1)
+}
+
+func TestSubmitMulti_SplitLength(t *testing.T) {
+	// 24 bytes per destination, 254 of them are larger than 4096 bytes but fit in DefaultMaxSubmitMultiLength
+	s := &SubmitMulti{Header: smpp.Header{ID: smpp.SUBMIT_MULTI}}
+	for i := 0; i < 600; i++ {
+		s.DestAddresses = append(s.DestAddresses, NewSMEAddress(smpp.TON_International, smpp.NPI_ISDN, fmt.Sprintf("86138%015d", i)))
+	}
+	s.ShortMessage = []byte("hi")
+	s.SmLength = 2
+
+	assert.Len(t, s.Split(0, 0), 3)
+
+	total := 0
+	parts := s.Split(0, 4096)
+	for i, p := range parts {
+		data, err := p.IEncode()
+		assert.Nil(t, err)
+		assert.True(t, len(data) <= 4096, len(data))
+		if i < len(parts)-1 {
+			assert.True(t, len(data) > 4096-24, len(data))
+		}
+		total += len(p.DestAddresses)
+	}
+	assert.Equal(t, 600, total)
+
+	for _, p := range s.Split(0, 1000) {
+		data, _ := p.IEncode()
+		assert.True(t, len(data) <= 1000, len(data))
+	}
+}
+
+func TestSubmitMultiResp(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 31, 0x80, 0, 0, 0x21, 0, 0, 0, 0, 0, 0, 0, 7, // header
+		'i', 'd', '1', 0, // message_id
+		1,                      // no_unsuccess
+		1, 1, '1', '2', '3', 0, // dest_addr_ton, dest_addr_npi, destination_addr
+		0, 0, 0, 0x0B, // error_status_code
+	}
+	r := &SubmitMultiResp{
+		Header:    smpp.Header{ID: smpp.SUBMIT_MULTI_RESP, Sequence: 7},
+		MessageID: "id1",
+	}
+	r.AddUnsuccessSme(1, 1, "123", smpp.ESME_RINVDSTADR)
+
+	data, err := r.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP34(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*SubmitMultiResp)
+	if assert.True(t, ok) {
+		r.Header.Length = 31
+		assert.Equal(t, r, decoded)
+		assert.Nil(t, decoded.GenEmptyResponse())
+		assert.Contains(t, decoded.String(), "123")
+	}
+
+	// header only
+	pdu, err = DecodeSMPP34([]byte{0, 0, 0, 16, 0x80, 0, 0, 0x21, 0, 0, 0, 0x33, 0, 0, 0, 7})
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.ESME_RINVNUMDESTS, pdu.(*SubmitMultiResp).Status)
+}
@@ -71,7 +71,11 @@ func (s *SubmitSm) IDecode(data []byte) error {
 	s.SmDefaultMsgID = r.ReadUint8()
 	s.SmLength = r.ReadUint8()
 	s.ShortMessage = r.ReadNBytes(int(s.SmLength))
-	s.TLVs = smpp.ReadTLVs1(r)
+	tlvs, err := smpp.ReadTLVs(r)
+	if err != nil {
+		return err
+	}
+	s.TLVs = tlvs
 
 	return r.Error()
 }
@@ -126,6 +130,15 @@ func (s *SubmitSm) GenEmptyResponse() sms.PDU {
 	}
 }
 
+// MessageContent returns ShortMessage, or the message_payload TLV if ShortMessage is empty.
+func (s *SubmitSm) MessageContent() []byte {
+	return smpp.MessageContent(s.ShortMessage, s.TLVs)
+}
+
+func (s *SubmitSm) GetDataCoding() uint8 {
+	return s.DataCoding
+}
+
 func (s *SubmitSm) String() string {
 	str := packet.NewPDUStringer()
 	defer str.Release()
@@ -2,6 +2,7 @@ package smpp34
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -199,3 +200,31 @@ func (s *SubmitSmRespTestSuite) TestSubmitSmResp_GenEmptyResponse() {
 func TestSubmitRespSuite(t *testing.T) {
 	suite.Run(t, new(SubmitSmRespTestSuite))
 }
+
+func TestDecodeSMPP34Strict(t *testing.T) {
+	s := &SubmitSm{
+		Header:          smpp.Header{ID: smpp.SUBMIT_SM, Sequence: 5},
+		SourceAddr:      "1065",
+		DestinationAddr: "123",
+		SmLength:        2,
+		ShortMessage:    []byte("hi"),
+	}
+	// sar_msg_ref_num must be 2 bytes
+	s.TLVs.SetTLV(smpp.TLV{Tag: smpp.SAR_MSG_REF_NUM, Length: 1, ValueBytes: []byte{7}})
+	data, err := s.IEncode()
+	assert.Nil(t, err)
+
+	_, err = DecodeSMPP34Strict(data)
+	assert.True(t, errors.Is(err, smpp.ErrInvalidTLVLength))
+
+	pdu, err := DecodeSMPP34(data)
+	assert.Nil(t, err)
+	assert.True(t, errors.Is(ValidateTLVs(pdu), smpp.ErrInvalidTLVLength))
+
+	s.TLVs.SetUint16(smpp.SAR_MSG_REF_NUM, 7)
+	data, err = s.IEncode()
+	assert.Nil(t, err)
+	pdu, err = DecodeSMPP34Strict(data)
+	assert.Nil(t, err)
+	assert.IsType(t, &SubmitSm{}, pdu)
+}
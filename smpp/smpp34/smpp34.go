@@ -2,6 +2,7 @@ package smpp34
 
 import (
 	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/consts"
 	"github.com/hujm2023/go-sms-protocol/smpp"
 )
 
@@ -65,6 +66,11 @@ func NewUnBindBytes(seqID uint32) []byte {
 	return data
 }
 
+func init() {
+	sms.RegisterDecoder(consts.ProtocolSMPP, consts.SMPPVersion3_4, DecodeSMPP34)
+}
+
+// DecodeSMPP34 decodes an SMPP 3.4 PDU. The lengths of the TLVs are not checked, see DecodeSMPP34Strict.
 func DecodeSMPP34(data []byte) (sms.PDU, error) {
 	header, err := smpp.PeekHeader(data)
 	if err != nil {
@@ -91,8 +97,34 @@ func DecodeSMPP34(data []byte) (sms.PDU, error) {
 		pdu = new(BindResp)
 	case smpp.UNBIND:
 		pdu = new(Unbind)
+	case smpp.UNBIND_RESP:
+		pdu = new(UnBindResp)
 	case smpp.GENERIC_NACK:
 		pdu = new(GenericNack)
+	case smpp.QUERY_SM:
+		pdu = new(QuerySm)
+	case smpp.QUERY_SM_RESP:
+		pdu = new(QuerySmResp)
+	case smpp.REPLACE_SM:
+		pdu = new(ReplaceSm)
+	case smpp.REPLACE_SM_RESP:
+		pdu = new(ReplaceSmResp)
+	case smpp.CANCEL_SM:
+		pdu = new(CancelSm)
+	case smpp.CANCEL_SM_RESP:
+		pdu = new(CancelSmResp)
+	case smpp.SUBMIT_MULTI:
+		pdu = new(SubmitMulti)
+	case smpp.SUBMIT_MULTI_RESP:
+		pdu = new(SubmitMultiResp)
+	case smpp.DATA_SM:
+		pdu = new(DataSm)
+	case smpp.DATA_SM_RESP:
+		pdu = new(DataSmResp)
+	case smpp.OUTBIND:
+		pdu = new(Outbind)
+	case smpp.ALERT_NOTIFICATION:
+		pdu = new(AlertNotification)
 	}
 
 	if pdu == nil {
@@ -106,3 +138,38 @@ func DecodeSMPP34(data []byte) (sms.PDU, error) {
 
 	return pdu, nil
 }
+
+// DecodeSMPP34Strict decodes an SMPP 3.4 PDU like DecodeSMPP34, a known TLV with an invalid length
+// is reported as smpp.ErrInvalidTLVLength.
+func DecodeSMPP34Strict(data []byte) (sms.PDU, error) {
+	pdu, err := DecodeSMPP34(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = ValidateTLVs(pdu); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+// ValidateTLVs checks the lengths of the TLVs of p, see smpp.TLVs.Validate.
+// It returns nil if p has no TLVs.
+func ValidateTLVs(p sms.PDU) error {
+	switch pdu := p.(type) {
+	case *SubmitSm:
+		return pdu.TLVs.Validate()
+	case *DeliverSm:
+		return pdu.TLVs.Validate()
+	case *BindResp:
+		return pdu.TLVs.Validate()
+	case *SubmitMulti:
+		return pdu.TLVs.Validate()
+	case *DataSm:
+		return pdu.TLVs.Validate()
+	case *DataSmResp:
+		return pdu.TLVs.Validate()
+	case *AlertNotification:
+		return pdu.TLVs.Validate()
+	}
+	return nil
+}
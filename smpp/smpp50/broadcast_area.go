@@ -0,0 +1,72 @@
+package smpp50
+
+import (
+	"fmt"
+
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+const (
+	// Formats of broadcast_area_identifier
+	BROADCAST_AREA_FORMAT_ALIAS         = 0x00 // Alias / Name
+	BROADCAST_AREA_FORMAT_ELLIPSOID_ARC = 0x01 // Ellipsoid Arc
+	BROADCAST_AREA_FORMAT_POLYGON       = 0x02 // Polygon
+)
+
+// BroadcastArea is the value of a broadcast_area_identifier TLV, which may occur several times in a PDU.
+type BroadcastArea struct {
+	// One of BROADCAST_AREA_FORMAT_*
+	Format uint8
+
+	// The area, e.g. the name for BROADCAST_AREA_FORMAT_ALIAS
+	Details []byte
+}
+
+// TLV returns the broadcast_area_identifier TLV of the area.
+func (b BroadcastArea) TLV() smpp.TLV {
+	return smpp.NewTLV(smpp.BROADCAST_AREA_IDENTIFIER, append([]byte{b.Format}, b.Details...))
+}
+
+func (b BroadcastArea) String() string {
+	return fmt.Sprintf("{Format=%d, Details=%v}", b.Format, b.Details)
+}
+
+func broadcastAreaFromTLV(tlv smpp.TLV) BroadcastArea {
+	if len(tlv.ValueBytes) == 0 {
+		return BroadcastArea{}
+	}
+	return BroadcastArea{Format: tlv.ValueBytes[0], Details: tlv.ValueBytes[1:]}
+}
+
+// readTLVs reads the TLVs to the end of the PDU. The TLVs with the repeatable tags, which may occur
+// several times, are returned in order in repeated, all the others in tlvs.
+// The lengths are not checked, like smpp.ReadTLVs.
+func readTLVs(r *packet.Reader, repeatable ...uint16) (tlvs smpp.TLVs, repeated map[uint16][]smpp.TLV, err error) {
+	for r.Remaining() > 0 && r.Error() == nil {
+		tag := r.ReadUint16()
+		length := r.ReadUint16()
+		value := r.ReadNBytes(int(length))
+		if r.Error() != nil {
+			return
+		}
+		tlv := smpp.TLV{Tag: tag, Length: length, ValueBytes: value}
+
+		isRepeatable := false
+		for _, t := range repeatable {
+			if t == tag {
+				isRepeatable = true
+				break
+			}
+		}
+		if isRepeatable {
+			if repeated == nil {
+				repeated = make(map[uint16][]smpp.TLV)
+			}
+			repeated[tag] = append(repeated[tag], tlv)
+			continue
+		}
+		tlvs.SetTLV(tlv)
+	}
+	return
+}
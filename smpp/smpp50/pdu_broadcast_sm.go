@@ -0,0 +1,226 @@
+package smpp50
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+// BroadcastSm submits a message to be broadcast to all mobiles in the given areas.
+type BroadcastSm struct {
+	smpp.Header
+
+	// CString, max 6
+	ServiceType string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21
+	SourceAddr string
+
+	// CString, max 65. Empty for a new broadcast, or the message_id of the broadcast to be replaced.
+	MessageID string
+
+	PriorityFlag uint8
+
+	// CString, 1~17
+	ScheduleDeliveryTime string
+	// CString, 1~17
+	ValidityPeriod string
+
+	ReplaceIfPresentFlag uint8
+	DataCoding           uint8
+	SmDefaultMsgID       uint8
+
+	// Mandatory, one or more broadcast_area_identifier TLVs
+	BroadcastAreaIdentifiers []BroadcastArea
+
+	// Mandatory: broadcast_content_type, broadcast_rep_num and broadcast_frequency_interval.
+	// Optional: message_payload, broadcast_end_time, broadcast_service_group and others.
+	TLVs smpp.TLVs
+}
+
+func (b *BroadcastSm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	b.Header = smpp.ReadHeader(r)
+	b.ServiceType = r.ReadCString()
+	b.SourceAddrTon = r.ReadUint8()
+	b.SourceAddrNpi = r.ReadUint8()
+	b.SourceAddr = r.ReadCString()
+	b.MessageID = r.ReadCString()
+	b.PriorityFlag = r.ReadUint8()
+	b.ScheduleDeliveryTime = r.ReadCString()
+	b.ValidityPeriod = r.ReadCString()
+	b.ReplaceIfPresentFlag = r.ReadUint8()
+	b.DataCoding = r.ReadUint8()
+	b.SmDefaultMsgID = r.ReadUint8()
+
+	tlvs, repeated, err := readTLVs(r, smpp.BROADCAST_AREA_IDENTIFIER)
+	if err != nil {
+		return err
+	}
+	b.TLVs = tlvs
+	b.BroadcastAreaIdentifiers = nil
+	for _, tlv := range repeated[smpp.BROADCAST_AREA_IDENTIFIER] {
+		b.BroadcastAreaIdentifiers = append(b.BroadcastAreaIdentifiers, broadcastAreaFromTLV(tlv))
+	}
+
+	return r.Error()
+}
+
+func (b *BroadcastSm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(b.Header, w)
+
+	w.WriteCString(b.ServiceType)
+	w.WriteUint8(b.SourceAddrTon)
+	w.WriteUint8(b.SourceAddrNpi)
+	w.WriteCString(b.SourceAddr)
+	w.WriteCString(b.MessageID)
+	w.WriteUint8(b.PriorityFlag)
+	w.WriteCString(b.ScheduleDeliveryTime)
+	w.WriteCString(b.ValidityPeriod)
+	w.WriteUint8(b.ReplaceIfPresentFlag)
+	w.WriteUint8(b.DataCoding)
+	w.WriteUint8(b.SmDefaultMsgID)
+	for _, area := range b.BroadcastAreaIdentifiers {
+		w.WriteBytes(area.TLV().Bytes())
+	}
+	w.WriteBytes(b.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (b *BroadcastSm) SetSequenceID(id uint32) {
+	b.Header.Sequence = id
+}
+
+func (b *BroadcastSm) GetSequenceID() uint32 {
+	return b.Header.Sequence
+}
+
+func (b *BroadcastSm) GetCommand() sms.ICommander {
+	return smpp.BROADCAST_SM
+}
+
+func (b *BroadcastSm) GenEmptyResponse() sms.PDU {
+	return &BroadcastSmResp{
+		Header: smpp.Header{
+			ID:       smpp.BROADCAST_SM_RESP,
+			Sequence: b.Header.Sequence,
+		},
+	}
+}
+
+func (b *BroadcastSm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", b.Header)
+	str.Write("ServiceType", b.ServiceType)
+	str.Write("SourceAddrTon", b.SourceAddrTon)
+	str.Write("SourceAddrNpi", b.SourceAddrNpi)
+	str.Write("SourceAddr", b.SourceAddr)
+	str.Write("MessageID", b.MessageID)
+	str.Write("PriorityFlag", b.PriorityFlag)
+	str.Write("ScheduleDeliveryTime", b.ScheduleDeliveryTime)
+	str.Write("ValidityPeriod", b.ValidityPeriod)
+	str.Write("ReplaceIfPresentFlag", b.ReplaceIfPresentFlag)
+	str.Write("DataCoding", b.DataCoding)
+	str.Write("SmDefaultMsgID", b.SmDefaultMsgID)
+	str.Write("BroadcastAreaIdentifiers", b.BroadcastAreaIdentifiers)
+	str.OmitWrite("TLVs", b.TLVs.String())
+
+	return str.String()
+}
+
+type BroadcastSmResp struct {
+	smpp.Header
+
+	// CString, max 65
+	MessageID string
+
+	// Optional, the failed_broadcast_area_identifier TLVs, which use the tag of broadcast_area_identifier
+	FailedBroadcastAreaIdentifiers []BroadcastArea
+
+	// Optional: broadcast_error_status
+	TLVs smpp.TLVs
+}
+
+func (b *BroadcastSmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	b.Header = smpp.ReadHeader(r)
+	// the body may be empty if command_status is not ESME_ROK
+	if len(data) == smpp.MinSMPPPacketLen {
+		return r.Error()
+	}
+	b.MessageID = r.ReadCString()
+
+	tlvs, repeated, err := readTLVs(r, smpp.BROADCAST_AREA_IDENTIFIER)
+	if err != nil {
+		return err
+	}
+	b.TLVs = tlvs
+	b.FailedBroadcastAreaIdentifiers = nil
+	for _, tlv := range repeated[smpp.BROADCAST_AREA_IDENTIFIER] {
+		b.FailedBroadcastAreaIdentifiers = append(b.FailedBroadcastAreaIdentifiers, broadcastAreaFromTLV(tlv))
+	}
+
+	return r.Error()
+}
+
+func (b *BroadcastSmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(b.Header, w)
+	w.WriteCString(b.MessageID)
+	for _, area := range b.FailedBroadcastAreaIdentifiers {
+		w.WriteBytes(area.TLV().Bytes())
+	}
+	w.WriteBytes(b.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (b *BroadcastSmResp) SetSequenceID(id uint32) {
+	b.Header.Sequence = id
+}
+
+func (b *BroadcastSmResp) GetSequenceID() uint32 {
+	return b.Header.Sequence
+}
+
+func (b *BroadcastSmResp) GetCommand() sms.ICommander {
+	return smpp.BROADCAST_SM_RESP
+}
+
+func (b *BroadcastSmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (b *BroadcastSmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", b.Header)
+	str.Write("MessageID", b.MessageID)
+	str.Write("FailedBroadcastAreaIdentifiers", b.FailedBroadcastAreaIdentifiers)
+	str.OmitWrite("TLVs", b.TLVs.String())
+
+	return str.String()
+}
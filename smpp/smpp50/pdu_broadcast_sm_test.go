@@ -0,0 +1,87 @@
+package smpp50
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestBroadcastSm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 51, 0, 0, 0x01, 0x11, 0, 0, 0, 0, 0, 0, 0, 1, // header
+		0,    // service_type
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+		0,    // message_id
+		0,    // priority_flag
+		0, 0, // schedule_delivery_time, validity_period
+		0, 0, 0, // replace_if_present_flag, data_coding, sm_default_msg_id
+		0x06, 0x06, 0, 3, 0, 'B', 'J', // broadcast_area_identifier
+		0x06, 0x06, 0, 3, 0, 'S', 'H', // broadcast_area_identifier
+		0x06, 0x04, 0, 2, 0, 1, // broadcast_rep_num
+	}
+	b := &BroadcastSm{
+		Header:        smpp.Header{ID: smpp.BROADCAST_SM, Sequence: 1},
+		SourceAddrTon: 1,
+		SourceAddrNpi: 1,
+		SourceAddr:    "1065",
+		BroadcastAreaIdentifiers: []BroadcastArea{
+			{Format: BROADCAST_AREA_FORMAT_ALIAS, Details: []byte("BJ")},
+			{Format: BROADCAST_AREA_FORMAT_ALIAS, Details: []byte("SH")},
+		},
+	}
+	b.TLVs.SetTLV(smpp.NewTLV(smpp.BROADCAST_REP_NUM, []byte{0, 1}))
+
+	data, err := b.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP50(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*BroadcastSm)
+	if assert.True(t, ok) {
+		b.Header.Length = 51
+		assert.Equal(t, b, decoded)
+		assert.Equal(t, smpp.BROADCAST_SM, decoded.GetCommand())
+	}
+
+	resp := b.GenEmptyResponse().(*BroadcastSmResp)
+	assert.Equal(t, smpp.BROADCAST_SM_RESP, resp.GetCommand())
+	assert.Equal(t, uint32(1), resp.GetSequenceID())
+}
+
+func TestBroadcastSmResp(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 34, 0x80, 0, 0x01, 0x11, 0, 0, 0, 0, 0, 0, 0, 1, // header
+		'b', '1', 0, // message_id
+		0x06, 0x06, 0, 3, 0, 'S', 'H', // failed_broadcast_area_identifier
+		0x06, 0x07, 0, 4, 0, 0, 0, 0xD2, // broadcast_error_status
+	}
+	b := &BroadcastSmResp{
+		Header:                         smpp.Header{ID: smpp.BROADCAST_SM_RESP, Sequence: 1},
+		MessageID:                      "b1",
+		FailedBroadcastAreaIdentifiers: []BroadcastArea{{Details: []byte("SH")}},
+	}
+	b.TLVs.SetTLV(smpp.NewTLV(smpp.BROADCAST_ERROR_STATUS, []byte{0, 0, 0, byte(smpp.ESME_RBCASTFAIL)}))
+
+	data, err := b.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP50(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*BroadcastSmResp)
+	if assert.True(t, ok) {
+		b.Header.Length = 34
+		assert.Equal(t, b, decoded)
+		assert.Nil(t, decoded.GenEmptyResponse())
+	}
+
+	// header only
+	pdu, err = DecodeSMPP50([]byte{0, 0, 0, 16, 0x80, 0, 0x01, 0x11, 0, 0, 0, 0xD2, 0, 0, 0, 1})
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.ESME_RBCASTFAIL, pdu.(*BroadcastSmResp).Status)
+	assert.Equal(t, "broadcast_sm operation failed", smpp.ESME_RBCASTFAIL.Error())
+}
@@ -0,0 +1,152 @@
+package smpp50
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+// CancelBroadcastSm cancels one or more outstanding broadcasts.
+type CancelBroadcastSm struct {
+	smpp.Header
+
+	// CString, max 6. If MessageID is empty, all broadcasts of this service type and source address are cancelled.
+	ServiceType string
+
+	// CString, max 65. Message ID of the broadcast to be cancelled, or empty.
+	MessageID string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21. Must match the source address of the original broadcast.
+	SourceAddr string
+
+	// Optional: broadcast_content_type, user_message_reference
+	TLVs smpp.TLVs
+}
+
+func (c *CancelBroadcastSm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	c.Header = smpp.ReadHeader(r)
+	c.ServiceType = r.ReadCString()
+	c.MessageID = r.ReadCString()
+	c.SourceAddrTon = r.ReadUint8()
+	c.SourceAddrNpi = r.ReadUint8()
+	c.SourceAddr = r.ReadCString()
+	tlvs, _, err := readTLVs(r)
+	if err != nil {
+		return err
+	}
+	c.TLVs = tlvs
+
+	return r.Error()
+}
+
+func (c *CancelBroadcastSm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(c.Header, w)
+	w.WriteCString(c.ServiceType)
+	w.WriteCString(c.MessageID)
+	w.WriteUint8(c.SourceAddrTon)
+	w.WriteUint8(c.SourceAddrNpi)
+	w.WriteCString(c.SourceAddr)
+	w.WriteBytes(c.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (c *CancelBroadcastSm) SetSequenceID(id uint32) {
+	c.Header.Sequence = id
+}
+
+func (c *CancelBroadcastSm) GetSequenceID() uint32 {
+	return c.Header.Sequence
+}
+
+func (c *CancelBroadcastSm) GetCommand() sms.ICommander {
+	return smpp.CANCEL_BROADCAST_SM
+}
+
+func (c *CancelBroadcastSm) GenEmptyResponse() sms.PDU {
+	return &CancelBroadcastSmResp{
+		Header: smpp.Header{
+			ID:       smpp.CANCEL_BROADCAST_SM_RESP,
+			Sequence: c.Header.Sequence,
+		},
+	}
+}
+
+func (c *CancelBroadcastSm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", c.Header)
+	str.Write("ServiceType", c.ServiceType)
+	str.Write("MessageID", c.MessageID)
+	str.Write("SourceAddrTon", c.SourceAddrTon)
+	str.Write("SourceAddrNpi", c.SourceAddrNpi)
+	str.Write("SourceAddr", c.SourceAddr)
+	str.OmitWrite("TLVs", c.TLVs.String())
+
+	return str.String()
+}
+
+// CancelBroadcastSmResp has no body.
+type CancelBroadcastSmResp struct {
+	smpp.Header
+}
+
+func (c *CancelBroadcastSmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	c.Header = smpp.ReadHeader(r)
+
+	return r.Error()
+}
+
+func (c *CancelBroadcastSmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(c.Header, w)
+
+	return w.BytesWithLength()
+}
+
+func (c *CancelBroadcastSmResp) SetSequenceID(id uint32) {
+	c.Header.Sequence = id
+}
+
+func (c *CancelBroadcastSmResp) GetSequenceID() uint32 {
+	return c.Header.Sequence
+}
+
+func (c *CancelBroadcastSmResp) GetCommand() sms.ICommander {
+	return smpp.CANCEL_BROADCAST_SM_RESP
+}
+
+func (c *CancelBroadcastSmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (c *CancelBroadcastSmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", c.Header)
+
+	return str.String()
+}
@@ -0,0 +1,49 @@
+package smpp50
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestCancelBroadcastSm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 27, 0, 0, 0x01, 0x13, 0, 0, 0, 0, 0, 0, 0, 3, // header
+		0,           // service_type
+		'b', '1', 0, // message_id
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+	}
+	c := &CancelBroadcastSm{
+		Header:        smpp.Header{ID: smpp.CANCEL_BROADCAST_SM, Sequence: 3},
+		MessageID:     "b1",
+		SourceAddrTon: 1,
+		SourceAddrNpi: 1,
+		SourceAddr:    "1065",
+	}
+
+	data, err := c.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP50(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*CancelBroadcastSm)
+	if assert.True(t, ok) {
+		c.Header.Length = 27
+		assert.Equal(t, c, decoded)
+		assert.Equal(t, smpp.CANCEL_BROADCAST_SM, decoded.GetCommand())
+	}
+
+	resp := c.GenEmptyResponse().(*CancelBroadcastSmResp)
+	data, err = resp.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 16, 0x80, 0, 0x01, 0x13, 0, 0, 0, 0, 0, 0, 0, 3}, data)
+
+	pdu, err = DecodeSMPP50(data)
+	assert.Nil(t, err)
+	assert.Equal(t, smpp.CANCEL_BROADCAST_SM_RESP, pdu.GetCommand())
+	assert.Nil(t, pdu.GenEmptyResponse())
+}
@@ -0,0 +1,191 @@
+package smpp50
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/packet"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+// QueryBroadcastSm queries the state of a broadcast submitted with broadcast_sm.
+type QueryBroadcastSm struct {
+	smpp.Header
+
+	// CString, max 65. Message ID of the broadcast to be queried.
+	MessageID string
+
+	SourceAddrTon uint8
+	SourceAddrNpi uint8
+	// CString, max 21. Must match the source address of the original broadcast.
+	SourceAddr string
+
+	// Optional: user_message_reference
+	TLVs smpp.TLVs
+}
+
+func (q *QueryBroadcastSm) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	q.Header = smpp.ReadHeader(r)
+	q.MessageID = r.ReadCString()
+	q.SourceAddrTon = r.ReadUint8()
+	q.SourceAddrNpi = r.ReadUint8()
+	q.SourceAddr = r.ReadCString()
+	tlvs, _, err := readTLVs(r)
+	if err != nil {
+		return err
+	}
+	q.TLVs = tlvs
+
+	return r.Error()
+}
+
+func (q *QueryBroadcastSm) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(q.Header, w)
+	w.WriteCString(q.MessageID)
+	w.WriteUint8(q.SourceAddrTon)
+	w.WriteUint8(q.SourceAddrNpi)
+	w.WriteCString(q.SourceAddr)
+	w.WriteBytes(q.TLVs.Bytes())
+
+	return w.BytesWithLength()
+}
+
+func (q *QueryBroadcastSm) SetSequenceID(id uint32) {
+	q.Header.Sequence = id
+}
+
+func (q *QueryBroadcastSm) GetSequenceID() uint32 {
+	return q.Header.Sequence
+}
+
+func (q *QueryBroadcastSm) GetCommand() sms.ICommander {
+	return smpp.QUERY_BROADCAST_SM
+}
+
+func (q *QueryBroadcastSm) GenEmptyResponse() sms.PDU {
+	return &QueryBroadcastSmResp{
+		Header: smpp.Header{
+			ID:       smpp.QUERY_BROADCAST_SM_RESP,
+			Sequence: q.Header.Sequence,
+		},
+		MessageID: q.MessageID,
+	}
+}
+
+func (q *QueryBroadcastSm) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", q.Header)
+	str.Write("MessageID", q.MessageID)
+	str.Write("SourceAddrTon", q.SourceAddrTon)
+	str.Write("SourceAddrNpi", q.SourceAddrNpi)
+	str.Write("SourceAddr", q.SourceAddr)
+	str.OmitWrite("TLVs", q.TLVs.String())
+
+	return str.String()
+}
+
+type QueryBroadcastSmResp struct {
+	smpp.Header
+
+	// CString, max 65
+	MessageID string
+
+	// Mandatory, one or more broadcast_area_identifier TLVs
+	BroadcastAreaIdentifiers []BroadcastArea
+
+	// Mandatory, one broadcast_area_success TLV for each area: the success rate 0~100, or 255 if unknown
+	BroadcastAreaSuccess []uint8
+
+	// Mandatory: message_state. Optional: user_message_reference
+	TLVs smpp.TLVs
+}
+
+func (q *QueryBroadcastSmResp) IDecode(data []byte) error {
+	if len(data) < smpp.MinSMPPPacketLen {
+		return smpp.ErrInvalidPudLength
+	}
+
+	r := packet.NewPacketReader(data)
+	defer r.Release()
+
+	q.Header = smpp.ReadHeader(r)
+	// the body may be empty if command_status is not ESME_ROK
+	if len(data) == smpp.MinSMPPPacketLen {
+		return r.Error()
+	}
+	q.MessageID = r.ReadCString()
+
+	tlvs, repeated, err := readTLVs(r, smpp.BROADCAST_AREA_IDENTIFIER, smpp.BROADCAST_AREA_SUCCESS)
+	if err != nil {
+		return err
+	}
+	q.TLVs = tlvs
+	q.BroadcastAreaIdentifiers = nil
+	for _, tlv := range repeated[smpp.BROADCAST_AREA_IDENTIFIER] {
+		q.BroadcastAreaIdentifiers = append(q.BroadcastAreaIdentifiers, broadcastAreaFromTLV(tlv))
+	}
+	q.BroadcastAreaSuccess = nil
+	for _, tlv := range repeated[smpp.BROADCAST_AREA_SUCCESS] {
+		if len(tlv.ValueBytes) > 0 {
+			q.BroadcastAreaSuccess = append(q.BroadcastAreaSuccess, tlv.ValueBytes[0])
+		}
+	}
+
+	return r.Error()
+}
+
+func (q *QueryBroadcastSmResp) IEncode() ([]byte, error) {
+	w := packet.NewPacketWriter(0)
+	defer w.Release()
+
+	smpp.WriteHeaderNoLength(q.Header, w)
+	w.WriteCString(q.MessageID)
+	w.WriteBytes(q.TLVs.Bytes())
+	for _, area := range q.BroadcastAreaIdentifiers {
+		w.WriteBytes(area.TLV().Bytes())
+	}
+	for _, success := range q.BroadcastAreaSuccess {
+		w.WriteBytes(smpp.NewTLV(smpp.BROADCAST_AREA_SUCCESS, []byte{success}).Bytes())
+	}
+
+	return w.BytesWithLength()
+}
+
+func (q *QueryBroadcastSmResp) SetSequenceID(id uint32) {
+	q.Header.Sequence = id
+}
+
+func (q *QueryBroadcastSmResp) GetSequenceID() uint32 {
+	return q.Header.Sequence
+}
+
+func (q *QueryBroadcastSmResp) GetCommand() sms.ICommander {
+	return smpp.QUERY_BROADCAST_SM_RESP
+}
+
+func (q *QueryBroadcastSmResp) GenEmptyResponse() sms.PDU {
+	return nil
+}
+
+func (q *QueryBroadcastSmResp) String() string {
+	str := packet.NewPDUStringer()
+	defer str.Release()
+
+	str.Write("Header", q.Header)
+	str.Write("MessageID", q.MessageID)
+	str.Write("BroadcastAreaIdentifiers", q.BroadcastAreaIdentifiers)
+	str.Write("BroadcastAreaSuccess", q.BroadcastAreaSuccess)
+	str.OmitWrite("TLVs", q.TLVs.String())
+
+	return str.String()
+}
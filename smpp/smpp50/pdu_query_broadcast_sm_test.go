@@ -0,0 +1,72 @@
+package smpp50
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hujm2023/go-sms-protocol/smpp"
+)
+
+func TestQueryBroadcastSm(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 26, 0, 0, 0x01, 0x12, 0, 0, 0, 0, 0, 0, 0, 2, // header
+		'b', '1', 0, // message_id
+		1, 1, // source_addr_ton, source_addr_npi
+		'1', '0', '6', '5', 0, // source_addr
+	}
+	q := &QueryBroadcastSm{
+		Header:        smpp.Header{ID: smpp.QUERY_BROADCAST_SM, Sequence: 2},
+		MessageID:     "b1",
+		SourceAddrTon: 1,
+		SourceAddrNpi: 1,
+		SourceAddr:    "1065",
+	}
+
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP50(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*QueryBroadcastSm)
+	if assert.True(t, ok) {
+		q.Header.Length = 26
+		assert.Equal(t, q, decoded)
+		assert.Equal(t, smpp.QUERY_BROADCAST_SM, decoded.GetCommand())
+	}
+
+	resp := q.GenEmptyResponse().(*QueryBroadcastSmResp)
+	assert.Equal(t, uint32(2), resp.GetSequenceID())
+	assert.Equal(t, "b1", resp.MessageID)
+}
+
+func TestQueryBroadcastSmResp(t *testing.T) {
+	raw := []byte{
+		0, 0, 0, 36, 0x80, 0, 0x01, 0x12, 0, 0, 0, 0, 0, 0, 0, 2, // header
+		'b', '1', 0, // message_id
+		0x04, 0x27, 0, 1, 2, // message_state
+		0x06, 0x06, 0, 3, 0, 'B', 'J', // broadcast_area_identifier
+		0x06, 0x08, 0, 1, 100, // broadcast_area_success
+	}
+	q := &QueryBroadcastSmResp{
+		Header:                   smpp.Header{ID: smpp.QUERY_BROADCAST_SM_RESP, Sequence: 2},
+		MessageID:                "b1",
+		BroadcastAreaIdentifiers: []BroadcastArea{{Details: []byte("BJ")}},
+		BroadcastAreaSuccess:     []uint8{100},
+	}
+	q.TLVs.SetTLV(smpp.NewTLV(smpp.DR_MESSAGE_STATE, []byte{uint8(smpp.MESSAGE_STATE_DELIVERED)}))
+
+	data, err := q.IEncode()
+	assert.Nil(t, err)
+	assert.Equal(t, raw, data)
+
+	pdu, err := DecodeSMPP50(raw)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*QueryBroadcastSmResp)
+	if assert.True(t, ok) {
+		q.Header.Length = 36
+		assert.Equal(t, q, decoded)
+		assert.Nil(t, decoded.GenEmptyResponse())
+	}
+}
@@ -0,0 +1,104 @@
+// Package smpp50 implements SMPP 5.0.
+//
+// SMPP 5.0 keeps the PDUs of SMPP 3.4 and adds the broadcast PDUs, new TLVs and command statuses.
+// The PDUs that are the same as in 3.4 are the types of package smpp34, which DecodeSMPP50 falls back to.
+package smpp50
+
+import (
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+// InterfaceVersion is the interface_version of SMPP 5.0 binds.
+const InterfaceVersion = uint8(consts.SMPPVersion5_0)
+
+// NewBind returns a bind_transmitter, bind_receiver or bind_transceiver with interface_version 0x50.
+// The sequence number is left to the sender.
+func NewBind(bindType smpp.CMDId, systemID, password string) *smpp34.Bind {
+	return &smpp34.Bind{
+		Header:           smpp.Header{ID: bindType},
+		SystemID:         systemID,
+		Password:         password,
+		InterfaceVersion: InterfaceVersion,
+	}
+}
+
+func init() {
+	sms.RegisterDecoder(consts.ProtocolSMPP, consts.SMPPVersion5_0, DecodeSMPP50)
+}
+
+// DecodeSMPP50 decodes the broadcast PDUs of SMPP 5.0, and falls back to smpp34.DecodeSMPP34 for the others.
+// The lengths of the TLVs are not checked, see DecodeSMPP50Strict.
+func DecodeSMPP50(data []byte) (sms.PDU, error) {
+	header, err := smpp.PeekHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var pdu sms.PDU
+	switch header.ID {
+	case smpp.BROADCAST_SM:
+		pdu = new(BroadcastSm)
+	case smpp.BROADCAST_SM_RESP:
+		pdu = new(BroadcastSmResp)
+	case smpp.QUERY_BROADCAST_SM:
+		pdu = new(QueryBroadcastSm)
+	case smpp.QUERY_BROADCAST_SM_RESP:
+		pdu = new(QueryBroadcastSmResp)
+	case smpp.CANCEL_BROADCAST_SM:
+		pdu = new(CancelBroadcastSm)
+	case smpp.CANCEL_BROADCAST_SM_RESP:
+		pdu = new(CancelBroadcastSmResp)
+	default:
+		return smpp34.DecodeSMPP34(data)
+	}
+
+	err = pdu.IDecode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdu, nil
+}
+
+// DecodeSMPP50Strict decodes an SMPP 5.0 PDU like DecodeSMPP50, a known TLV with an invalid length
+// is reported as smpp.ErrInvalidTLVLength.
+func DecodeSMPP50Strict(data []byte) (sms.PDU, error) {
+	pdu, err := DecodeSMPP50(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = ValidateTLVs(pdu); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+// ValidateTLVs checks the lengths of the TLVs of p, including the broadcast_area_identifier TLVs,
+// and falls back to smpp34.ValidateTLVs for the PDUs of SMPP 3.4.
+func ValidateTLVs(p sms.PDU) error {
+	switch pdu := p.(type) {
+	case *BroadcastSm:
+		return validateTLVs(pdu.TLVs, pdu.BroadcastAreaIdentifiers)
+	case *BroadcastSmResp:
+		return validateTLVs(pdu.TLVs, pdu.FailedBroadcastAreaIdentifiers)
+	case *QueryBroadcastSm:
+		return pdu.TLVs.Validate()
+	case *QueryBroadcastSmResp:
+		return validateTLVs(pdu.TLVs, pdu.BroadcastAreaIdentifiers)
+	case *CancelBroadcastSm:
+		return pdu.TLVs.Validate()
+	}
+	return smpp34.ValidateTLVs(p)
+}
+
+func validateTLVs(tlvs smpp.TLVs, areas []BroadcastArea) error {
+	for _, area := range areas {
+		if err := area.TLV().Validate(); err != nil {
+			return err
+		}
+	}
+	return tlvs.Validate()
+}
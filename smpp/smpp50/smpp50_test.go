@@ -0,0 +1,51 @@
+package smpp50
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sms "github.com/hujm2023/go-sms-protocol"
+	"github.com/hujm2023/go-sms-protocol/consts"
+	"github.com/hujm2023/go-sms-protocol/smpp"
+	"github.com/hujm2023/go-sms-protocol/smpp/smpp34"
+)
+
+func TestDecodeSMPP50(t *testing.T) {
+	bind := NewBind(smpp.BIND_TRANSCEIVER, "sp", "pwd")
+	bind.Sequence = 1
+	data, err := bind.IEncode()
+	assert.Nil(t, err)
+
+	// falls back to the 3.4 types
+	pdu, err := DecodeSMPP50(data)
+	assert.Nil(t, err)
+	decoded, ok := pdu.(*smpp34.Bind)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint8(0x50), decoded.InterfaceVersion)
+		assert.Equal(t, "sp", decoded.SystemID)
+	}
+
+	// registered for SMPP 5.0
+	pdu, err = sms.Decode(consts.ProtocolSMPP, consts.SMPPVersion5_0, []byte{0, 0, 0, 16, 0x80, 0, 0x01, 0x13, 0, 0, 0, 0, 0, 0, 0, 3})
+	assert.Nil(t, err)
+	assert.IsType(t, &CancelBroadcastSmResp{}, pdu)
+
+	_, err = DecodeSMPP50([]byte{0, 0, 0, 16, 0, 0, 0x01, 0x14, 0, 0, 0, 0, 0, 0, 0, 3})
+	assert.Equal(t, sms.ErrUnsupportedPacket, err)
+}
+
+func TestDecodeSMPP50Strict(t *testing.T) {
+	b := &BroadcastSm{Header: smpp.Header{ID: smpp.BROADCAST_SM, Sequence: 2}, MessageID: "b1"}
+	// broadcast_area_identifier must not be empty
+	b.BroadcastAreaIdentifiers = []BroadcastArea{{Format: 1, Details: []byte("bj")}}
+	b.TLVs.SetTLV(smpp.TLV{Tag: smpp.BROADCAST_REP_NUM, Length: 1, ValueBytes: []byte{1}})
+	data, err := b.IEncode()
+	assert.Nil(t, err)
+
+	_, err = DecodeSMPP50(data)
+	assert.Nil(t, err)
+	_, err = DecodeSMPP50Strict(data)
+	assert.True(t, errors.Is(err, smpp.ErrInvalidTLVLength))
+}
@@ -0,0 +1,729 @@
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Uint8 returns the value of an integer TLV of 1 byte.
+func (t TLVs) Uint8(tag uint16) (uint8, bool) {
+	tlv, ok := t[tag]
+	if !ok || len(tlv.ValueBytes) != 1 {
+		return 0, false
+	}
+	return tlv.ValueBytes[0], true
+}
+
+// Uint16 returns the value of an integer TLV of 2 bytes.
+func (t TLVs) Uint16(tag uint16) (uint16, bool) {
+	tlv, ok := t[tag]
+	if !ok || len(tlv.ValueBytes) != 2 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint16(tlv.ValueBytes), true
+}
+
+// Uint32 returns the value of an integer TLV of 4 bytes.
+func (t TLVs) Uint32(tag uint16) (uint32, bool) {
+	tlv, ok := t[tag]
+	if !ok || len(tlv.ValueBytes) != 4 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(tlv.ValueBytes), true
+}
+
+// CString returns the value of a C-Octet String TLV without the trailing NULL.
+func (t TLVs) CString(tag uint16) (string, bool) {
+	tlv, ok := t[tag]
+	if !ok {
+		return "", false
+	}
+	if i := bytes.IndexByte(tlv.ValueBytes, 0); i >= 0 {
+		return string(tlv.ValueBytes[:i]), true
+	}
+	return string(tlv.ValueBytes), true
+}
+
+// Octets returns the value of an Octet String TLV.
+func (t TLVs) Octets(tag uint16) ([]byte, bool) {
+	tlv, ok := t[tag]
+	if !ok {
+		return nil, false
+	}
+	return tlv.ValueBytes, true
+}
+
+func (t *TLVs) SetUint8(tag uint16, v uint8) {
+	t.SetTLV(NewTLV(tag, []byte{v}))
+}
+
+func (t *TLVs) SetUint16(tag uint16, v uint16) {
+	b := make([]byte, 2)
+	binary.BigEndian.PutUint16(b, v)
+	t.SetTLV(NewTLV(tag, b))
+}
+
+func (t *TLVs) SetUint32(tag uint16, v uint32) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	t.SetTLV(NewTLV(tag, b))
+}
+
+// SetCString sets a C-Octet String TLV, appending the NULL.
+func (t *TLVs) SetCString(tag uint16, v string) {
+	b := make([]byte, len(v)+1)
+	copy(b, v)
+	t.SetTLV(NewTLV(tag, b))
+}
+
+func (t *TLVs) SetOctets(tag uint16, v []byte) {
+	t.SetTLV(NewTLV(tag, v))
+}
+
+// DestAddrSubunit returns the dest_addr_subunit TLV.
+func (t TLVs) DestAddrSubunit() (uint8, bool) {
+	return t.Uint8(DEST_ADDR_SUBUNIT)
+}
+
+// SetDestAddrSubunit sets the dest_addr_subunit TLV.
+func (t *TLVs) SetDestAddrSubunit(v uint8) {
+	t.SetUint8(DEST_ADDR_SUBUNIT, v)
+}
+
+// DestNetworkType returns the dest_network_type TLV.
+func (t TLVs) DestNetworkType() (uint8, bool) {
+	return t.Uint8(DEST_NETWORK_TYPE)
+}
+
+// SetDestNetworkType sets the dest_network_type TLV.
+func (t *TLVs) SetDestNetworkType(v uint8) {
+	t.SetUint8(DEST_NETWORK_TYPE, v)
+}
+
+// DestBearerType returns the dest_bearer_type TLV.
+func (t TLVs) DestBearerType() (uint8, bool) {
+	return t.Uint8(DEST_BEARER_TYPE)
+}
+
+// SetDestBearerType sets the dest_bearer_type TLV.
+func (t *TLVs) SetDestBearerType(v uint8) {
+	t.SetUint8(DEST_BEARER_TYPE, v)
+}
+
+// DestTelematicsID returns the dest_telematics_id TLV.
+func (t TLVs) DestTelematicsID() (uint16, bool) {
+	return t.Uint16(DEST_TELEMATICS_ID)
+}
+
+// SetDestTelematicsID sets the dest_telematics_id TLV.
+func (t *TLVs) SetDestTelematicsID(v uint16) {
+	t.SetUint16(DEST_TELEMATICS_ID, v)
+}
+
+// SourceAddrSubunit returns the source_addr_subunit TLV.
+func (t TLVs) SourceAddrSubunit() (uint8, bool) {
+	return t.Uint8(SOURCE_ADDR_SUBUNIT)
+}
+
+// SetSourceAddrSubunit sets the source_addr_subunit TLV.
+func (t *TLVs) SetSourceAddrSubunit(v uint8) {
+	t.SetUint8(SOURCE_ADDR_SUBUNIT, v)
+}
+
+// SourceNetworkType returns the source_network_type TLV.
+func (t TLVs) SourceNetworkType() (uint8, bool) {
+	return t.Uint8(SOURCE_NETWORK_TYPE)
+}
+
+// SetSourceNetworkType sets the source_network_type TLV.
+func (t *TLVs) SetSourceNetworkType(v uint8) {
+	t.SetUint8(SOURCE_NETWORK_TYPE, v)
+}
+
+// SourceBearerType returns the source_bearer_type TLV.
+func (t TLVs) SourceBearerType() (uint8, bool) {
+	return t.Uint8(SOURCE_BEARER_TYPE)
+}
+
+// SetSourceBearerType sets the source_bearer_type TLV.
+func (t *TLVs) SetSourceBearerType(v uint8) {
+	t.SetUint8(SOURCE_BEARER_TYPE, v)
+}
+
+// SourceTelematicsID returns the source_telematics_id TLV.
+func (t TLVs) SourceTelematicsID() (uint8, bool) {
+	return t.Uint8(SOURCE_TELEMATICS_ID)
+}
+
+// SetSourceTelematicsID sets the source_telematics_id TLV.
+func (t *TLVs) SetSourceTelematicsID(v uint8) {
+	t.SetUint8(SOURCE_TELEMATICS_ID, v)
+}
+
+// QosTimeToLive returns the qos_time_to_live TLV.
+func (t TLVs) QosTimeToLive() (uint32, bool) {
+	return t.Uint32(QOS_TIME_TO_LIVE)
+}
+
+// SetQosTimeToLive sets the qos_time_to_live TLV.
+func (t *TLVs) SetQosTimeToLive(v uint32) {
+	t.SetUint32(QOS_TIME_TO_LIVE, v)
+}
+
+// PayloadType returns the payload_type TLV.
+func (t TLVs) PayloadType() (uint8, bool) {
+	return t.Uint8(PAYLOAD_TYPE)
+}
+
+// SetPayloadType sets the payload_type TLV.
+func (t *TLVs) SetPayloadType(v uint8) {
+	t.SetUint8(PAYLOAD_TYPE, v)
+}
+
+// AdditionalStatusInfoText returns the additional_status_info_text TLV, or "" if it is absent.
+func (t TLVs) AdditionalStatusInfoText() string {
+	v, _ := t.CString(ADDITIONAL_STATUS_INFO_TEXT)
+	return v
+}
+
+// SetAdditionalStatusInfoText sets the additional_status_info_text TLV.
+func (t *TLVs) SetAdditionalStatusInfoText(v string) {
+	t.SetCString(ADDITIONAL_STATUS_INFO_TEXT, v)
+}
+
+// ReceiptedMessageID returns the receipted_message_id TLV, or "" if it is absent.
+func (t TLVs) ReceiptedMessageID() string {
+	v, _ := t.CString(RECEIPTED_MESSAGE_ID)
+	return v
+}
+
+// SetReceiptedMessageID sets the receipted_message_id TLV.
+func (t *TLVs) SetReceiptedMessageID(v string) {
+	t.SetCString(RECEIPTED_MESSAGE_ID, v)
+}
+
+// MsMsgWaitFacilities returns the ms_msg_wait_facilities TLV.
+func (t TLVs) MsMsgWaitFacilities() (uint8, bool) {
+	return t.Uint8(MS_MSG_WAIT_FACILITIES)
+}
+
+// SetMsMsgWaitFacilities sets the ms_msg_wait_facilities TLV.
+func (t *TLVs) SetMsMsgWaitFacilities(v uint8) {
+	t.SetUint8(MS_MSG_WAIT_FACILITIES, v)
+}
+
+// PrivacyIndicator returns the privacy_indicator TLV.
+func (t TLVs) PrivacyIndicator() (uint8, bool) {
+	return t.Uint8(PRIVACY_INDICATOR)
+}
+
+// SetPrivacyIndicator sets the privacy_indicator TLV.
+func (t *TLVs) SetPrivacyIndicator(v uint8) {
+	t.SetUint8(PRIVACY_INDICATOR, v)
+}
+
+// SourceSubaddress returns the source_subaddress TLV.
+func (t TLVs) SourceSubaddress() ([]byte, bool) {
+	return t.Octets(SOURCE_SUBADDRESS)
+}
+
+// SetSourceSubaddress sets the source_subaddress TLV.
+func (t *TLVs) SetSourceSubaddress(v []byte) {
+	t.SetOctets(SOURCE_SUBADDRESS, v)
+}
+
+// DestSubaddress returns the dest_subaddress TLV.
+func (t TLVs) DestSubaddress() ([]byte, bool) {
+	return t.Octets(DEST_SUBADDRESS)
+}
+
+// SetDestSubaddress sets the dest_subaddress TLV.
+func (t *TLVs) SetDestSubaddress(v []byte) {
+	t.SetOctets(DEST_SUBADDRESS, v)
+}
+
+// UserMessageReference returns the user_message_reference TLV.
+func (t TLVs) UserMessageReference() (uint16, bool) {
+	return t.Uint16(USER_MESSAGE_REFERENCE)
+}
+
+// SetUserMessageReference sets the user_message_reference TLV.
+func (t *TLVs) SetUserMessageReference(v uint16) {
+	t.SetUint16(USER_MESSAGE_REFERENCE, v)
+}
+
+// UserResponseCode returns the user_response_code TLV.
+func (t TLVs) UserResponseCode() (uint8, bool) {
+	return t.Uint8(USER_RESPONSE_CODE)
+}
+
+// SetUserResponseCode sets the user_response_code TLV.
+func (t *TLVs) SetUserResponseCode(v uint8) {
+	t.SetUint8(USER_RESPONSE_CODE, v)
+}
+
+// SourcePort returns the source_port TLV.
+func (t TLVs) SourcePort() (uint16, bool) {
+	return t.Uint16(SOURCE_PORT)
+}
+
+// SetSourcePort sets the source_port TLV.
+func (t *TLVs) SetSourcePort(v uint16) {
+	t.SetUint16(SOURCE_PORT, v)
+}
+
+// DestinationPort returns the destination_port TLV.
+func (t TLVs) DestinationPort() (uint16, bool) {
+	return t.Uint16(DESTINATION_PORT)
+}
+
+// SetDestinationPort sets the destination_port TLV.
+func (t *TLVs) SetDestinationPort(v uint16) {
+	t.SetUint16(DESTINATION_PORT, v)
+}
+
+// SarMsgRefNum returns the sar_msg_ref_num TLV.
+func (t TLVs) SarMsgRefNum() (uint16, bool) {
+	return t.Uint16(SAR_MSG_REF_NUM)
+}
+
+// SetSarMsgRefNum sets the sar_msg_ref_num TLV.
+func (t *TLVs) SetSarMsgRefNum(v uint16) {
+	t.SetUint16(SAR_MSG_REF_NUM, v)
+}
+
+// LanguageIndicator returns the language_indicator TLV.
+func (t TLVs) LanguageIndicator() (uint8, bool) {
+	return t.Uint8(LANGUAGE_INDICATOR)
+}
+
+// SetLanguageIndicator sets the language_indicator TLV.
+func (t *TLVs) SetLanguageIndicator(v uint8) {
+	t.SetUint8(LANGUAGE_INDICATOR, v)
+}
+
+// SarTotalSegments returns the sar_total_segments TLV.
+func (t TLVs) SarTotalSegments() (uint8, bool) {
+	return t.Uint8(SAR_TOTAL_SEGMENTS)
+}
+
+// SetSarTotalSegments sets the sar_total_segments TLV.
+func (t *TLVs) SetSarTotalSegments(v uint8) {
+	t.SetUint8(SAR_TOTAL_SEGMENTS, v)
+}
+
+// SarSegmentSeqnum returns the sar_segment_seqnum TLV.
+func (t TLVs) SarSegmentSeqnum() (uint8, bool) {
+	return t.Uint8(SAR_SEGMENT_SEQNUM)
+}
+
+// SetSarSegmentSeqnum sets the sar_segment_seqnum TLV.
+func (t *TLVs) SetSarSegmentSeqnum(v uint8) {
+	t.SetUint8(SAR_SEGMENT_SEQNUM, v)
+}
+
+// ScInterfaceVersion returns the sc_interface_version TLV.
+func (t TLVs) ScInterfaceVersion() (uint8, bool) {
+	return t.Uint8(SC_INTERFACE_VERSION)
+}
+
+// SetScInterfaceVersion sets the sc_interface_version TLV.
+func (t *TLVs) SetScInterfaceVersion(v uint8) {
+	t.SetUint8(SC_INTERFACE_VERSION, v)
+}
+
+// CallbackNumPresInd returns the callback_num_pres_ind TLV.
+func (t TLVs) CallbackNumPresInd() (uint8, bool) {
+	return t.Uint8(CALLBACK_NUM_PRES_IND)
+}
+
+// SetCallbackNumPresInd sets the callback_num_pres_ind TLV.
+func (t *TLVs) SetCallbackNumPresInd(v uint8) {
+	t.SetUint8(CALLBACK_NUM_PRES_IND, v)
+}
+
+// CallbackNumAtag returns the callback_num_atag TLV.
+func (t TLVs) CallbackNumAtag() ([]byte, bool) {
+	return t.Octets(CALLBACK_NUM_ATAG)
+}
+
+// SetCallbackNumAtag sets the callback_num_atag TLV.
+func (t *TLVs) SetCallbackNumAtag(v []byte) {
+	t.SetOctets(CALLBACK_NUM_ATAG, v)
+}
+
+// NumberOfMessages returns the number_of_messages TLV.
+func (t TLVs) NumberOfMessages() (uint8, bool) {
+	return t.Uint8(NUMBER_OF_MESSAGES)
+}
+
+// SetNumberOfMessages sets the number_of_messages TLV.
+func (t *TLVs) SetNumberOfMessages(v uint8) {
+	t.SetUint8(NUMBER_OF_MESSAGES, v)
+}
+
+// CallbackNum returns the callback_num TLV.
+func (t TLVs) CallbackNum() ([]byte, bool) {
+	return t.Octets(CALLBACK_NUM)
+}
+
+// SetCallbackNum sets the callback_num TLV.
+func (t *TLVs) SetCallbackNum(v []byte) {
+	t.SetOctets(CALLBACK_NUM, v)
+}
+
+// DpfResult returns the dpf_result TLV.
+func (t TLVs) DpfResult() (uint8, bool) {
+	return t.Uint8(DPF_RESULT)
+}
+
+// SetDpfResult sets the dpf_result TLV.
+func (t *TLVs) SetDpfResult(v uint8) {
+	t.SetUint8(DPF_RESULT, v)
+}
+
+// SetDpf returns the set_dpf TLV.
+func (t TLVs) SetDpf() (uint8, bool) {
+	return t.Uint8(SET_DPF)
+}
+
+// SetSetDpf sets the set_dpf TLV.
+func (t *TLVs) SetSetDpf(v uint8) {
+	t.SetUint8(SET_DPF, v)
+}
+
+// MsAvailabilityStatus returns the ms_availability_status TLV.
+func (t TLVs) MsAvailabilityStatus() (uint8, bool) {
+	return t.Uint8(MS_AVAILABILITY_STATUS)
+}
+
+// SetMsAvailabilityStatus sets the ms_availability_status TLV.
+func (t *TLVs) SetMsAvailabilityStatus(v uint8) {
+	t.SetUint8(MS_AVAILABILITY_STATUS, v)
+}
+
+// NetworkErrorCode returns the network_error_code TLV.
+func (t TLVs) NetworkErrorCode() ([]byte, bool) {
+	return t.Octets(NETWORK_ERROR_CODE)
+}
+
+// SetNetworkErrorCode sets the network_error_code TLV.
+func (t *TLVs) SetNetworkErrorCode(v []byte) {
+	t.SetOctets(NETWORK_ERROR_CODE, v)
+}
+
+// MessagePayload returns the message_payload TLV.
+func (t TLVs) MessagePayload() ([]byte, bool) {
+	return t.Octets(MESSAGE_PAYLOAD)
+}
+
+// SetMessagePayload sets the message_payload TLV.
+func (t *TLVs) SetMessagePayload(v []byte) {
+	t.SetOctets(MESSAGE_PAYLOAD, v)
+}
+
+// DeliveryFailureReason returns the delivery_failure_reason TLV.
+func (t TLVs) DeliveryFailureReason() (uint8, bool) {
+	return t.Uint8(DELIVERY_FAILURE_REASON)
+}
+
+// SetDeliveryFailureReason sets the delivery_failure_reason TLV.
+func (t *TLVs) SetDeliveryFailureReason(v uint8) {
+	t.SetUint8(DELIVERY_FAILURE_REASON, v)
+}
+
+// MoreMessagesToSend returns the more_messages_to_send TLV.
+func (t TLVs) MoreMessagesToSend() (uint8, bool) {
+	return t.Uint8(MORE_MESSAGES_TO_SEND)
+}
+
+// SetMoreMessagesToSend sets the more_messages_to_send TLV.
+func (t *TLVs) SetMoreMessagesToSend(v uint8) {
+	t.SetUint8(MORE_MESSAGES_TO_SEND, v)
+}
+
+// MessageState returns the message_state TLV.
+func (t TLVs) MessageState() (MessageState, bool) {
+	v, ok := t.Uint8(DR_MESSAGE_STATE)
+	return MessageState(v), ok
+}
+
+// SetMessageState sets the message_state TLV.
+func (t *TLVs) SetMessageState(v MessageState) {
+	t.SetUint8(DR_MESSAGE_STATE, uint8(v))
+}
+
+// UssdServiceOp returns the ussd_service_op TLV.
+func (t TLVs) UssdServiceOp() (uint8, bool) {
+	return t.Uint8(USSD_SERVICE_OP)
+}
+
+// SetUssdServiceOp sets the ussd_service_op TLV.
+func (t *TLVs) SetUssdServiceOp(v uint8) {
+	t.SetUint8(USSD_SERVICE_OP, v)
+}
+
+// DisplayTime returns the display_time TLV.
+func (t TLVs) DisplayTime() (uint8, bool) {
+	return t.Uint8(DISPLAY_TIME)
+}
+
+// SetDisplayTime sets the display_time TLV.
+func (t *TLVs) SetDisplayTime(v uint8) {
+	t.SetUint8(DISPLAY_TIME, v)
+}
+
+// SmsSignal returns the sms_signal TLV.
+func (t TLVs) SmsSignal() (uint16, bool) {
+	return t.Uint16(SMS_SIGNAL)
+}
+
+// SetSmsSignal sets the sms_signal TLV.
+func (t *TLVs) SetSmsSignal(v uint16) {
+	t.SetUint16(SMS_SIGNAL, v)
+}
+
+// MsValidity returns the ms_validity TLV.
+func (t TLVs) MsValidity() ([]byte, bool) {
+	return t.Octets(MS_VALIDITY)
+}
+
+// SetMsValidity sets the ms_validity TLV.
+func (t *TLVs) SetMsValidity(v []byte) {
+	t.SetOctets(MS_VALIDITY, v)
+}
+
+// AlertOnMessageDelivery reports whether the alert_on_message_delivery TLV is present.
+func (t TLVs) AlertOnMessageDelivery() bool {
+	_, ok := t[ALERT_ON_MESSAGE_DELIVERY]
+	return ok
+}
+
+// SetAlertOnMessageDelivery sets the alert_on_message_delivery TLV, which has no value.
+func (t *TLVs) SetAlertOnMessageDelivery() {
+	t.SetOctets(ALERT_ON_MESSAGE_DELIVERY, nil)
+}
+
+// ItsReplyType returns the its_reply_type TLV.
+func (t TLVs) ItsReplyType() (uint8, bool) {
+	return t.Uint8(ITS_REPLY_TYPE)
+}
+
+// SetItsReplyType sets the its_reply_type TLV.
+func (t *TLVs) SetItsReplyType(v uint8) {
+	t.SetUint8(ITS_REPLY_TYPE, v)
+}
+
+// ItsSessionInfo returns the its_session_info TLV.
+func (t TLVs) ItsSessionInfo() ([]byte, bool) {
+	return t.Octets(ITS_SESSION_INFO)
+}
+
+// SetItsSessionInfo sets the its_session_info TLV.
+func (t *TLVs) SetItsSessionInfo(v []byte) {
+	t.SetOctets(ITS_SESSION_INFO, v)
+}
+
+// CongestionState returns the congestion_state TLV.
+func (t TLVs) CongestionState() (uint8, bool) {
+	return t.Uint8(CONGESTION_STATE)
+}
+
+// SetCongestionState sets the congestion_state TLV.
+func (t *TLVs) SetCongestionState(v uint8) {
+	t.SetUint8(CONGESTION_STATE, v)
+}
+
+// BroadcastChannelIndicator returns the broadcast_channel_indicator TLV.
+func (t TLVs) BroadcastChannelIndicator() (uint8, bool) {
+	return t.Uint8(BROADCAST_CHANNEL_INDICATOR)
+}
+
+// SetBroadcastChannelIndicator sets the broadcast_channel_indicator TLV.
+func (t *TLVs) SetBroadcastChannelIndicator(v uint8) {
+	t.SetUint8(BROADCAST_CHANNEL_INDICATOR, v)
+}
+
+// BroadcastContentType returns the broadcast_content_type TLV.
+func (t TLVs) BroadcastContentType() ([]byte, bool) {
+	return t.Octets(BROADCAST_CONTENT_TYPE)
+}
+
+// SetBroadcastContentType sets the broadcast_content_type TLV.
+func (t *TLVs) SetBroadcastContentType(v []byte) {
+	t.SetOctets(BROADCAST_CONTENT_TYPE, v)
+}
+
+// BroadcastContentTypeInfo returns the broadcast_content_type_info TLV.
+func (t TLVs) BroadcastContentTypeInfo() ([]byte, bool) {
+	return t.Octets(BROADCAST_CONTENT_TYPE_INFO)
+}
+
+// SetBroadcastContentTypeInfo sets the broadcast_content_type_info TLV.
+func (t *TLVs) SetBroadcastContentTypeInfo(v []byte) {
+	t.SetOctets(BROADCAST_CONTENT_TYPE_INFO, v)
+}
+
+// BroadcastMessageClass returns the broadcast_message_class TLV.
+func (t TLVs) BroadcastMessageClass() (uint8, bool) {
+	return t.Uint8(BROADCAST_MESSAGE_CLASS)
+}
+
+// SetBroadcastMessageClass sets the broadcast_message_class TLV.
+func (t *TLVs) SetBroadcastMessageClass(v uint8) {
+	t.SetUint8(BROADCAST_MESSAGE_CLASS, v)
+}
+
+// BroadcastRepNum returns the broadcast_rep_num TLV.
+func (t TLVs) BroadcastRepNum() (uint16, bool) {
+	return t.Uint16(BROADCAST_REP_NUM)
+}
+
+// SetBroadcastRepNum sets the broadcast_rep_num TLV.
+func (t *TLVs) SetBroadcastRepNum(v uint16) {
+	t.SetUint16(BROADCAST_REP_NUM, v)
+}
+
+// BroadcastFrequencyInterval returns the broadcast_frequency_interval TLV.
+func (t TLVs) BroadcastFrequencyInterval() ([]byte, bool) {
+	return t.Octets(BROADCAST_FREQUENCY_INTERVAL)
+}
+
+// SetBroadcastFrequencyInterval sets the broadcast_frequency_interval TLV.
+func (t *TLVs) SetBroadcastFrequencyInterval(v []byte) {
+	t.SetOctets(BROADCAST_FREQUENCY_INTERVAL, v)
+}
+
+// BroadcastAreaIdentifier returns the broadcast_area_identifier TLV.
+func (t TLVs) BroadcastAreaIdentifier() ([]byte, bool) {
+	return t.Octets(BROADCAST_AREA_IDENTIFIER)
+}
+
+// SetBroadcastAreaIdentifier sets the broadcast_area_identifier TLV.
+func (t *TLVs) SetBroadcastAreaIdentifier(v []byte) {
+	t.SetOctets(BROADCAST_AREA_IDENTIFIER, v)
+}
+
+// BroadcastErrorStatus returns the broadcast_error_status TLV.
+func (t TLVs) BroadcastErrorStatus() (CMDStatus, bool) {
+	v, ok := t.Uint32(BROADCAST_ERROR_STATUS)
+	return CMDStatus(v), ok
+}
+
+// SetBroadcastErrorStatus sets the broadcast_error_status TLV.
+func (t *TLVs) SetBroadcastErrorStatus(v CMDStatus) {
+	t.SetUint32(BROADCAST_ERROR_STATUS, uint32(v))
+}
+
+// BroadcastAreaSuccess returns the broadcast_area_success TLV.
+func (t TLVs) BroadcastAreaSuccess() (uint8, bool) {
+	return t.Uint8(BROADCAST_AREA_SUCCESS)
+}
+
+// SetBroadcastAreaSuccess sets the broadcast_area_success TLV.
+func (t *TLVs) SetBroadcastAreaSuccess(v uint8) {
+	t.SetUint8(BROADCAST_AREA_SUCCESS, v)
+}
+
+// BroadcastEndTime returns the broadcast_end_time TLV, or "" if it is absent.
+func (t TLVs) BroadcastEndTime() string {
+	v, _ := t.CString(BROADCAST_END_TIME)
+	return v
+}
+
+// SetBroadcastEndTime sets the broadcast_end_time TLV.
+func (t *TLVs) SetBroadcastEndTime(v string) {
+	t.SetCString(BROADCAST_END_TIME, v)
+}
+
+// BroadcastServiceGroup returns the broadcast_service_group TLV.
+func (t TLVs) BroadcastServiceGroup() ([]byte, bool) {
+	return t.Octets(BROADCAST_SERVICE_GROUP)
+}
+
+// SetBroadcastServiceGroup sets the broadcast_service_group TLV.
+func (t *TLVs) SetBroadcastServiceGroup(v []byte) {
+	t.SetOctets(BROADCAST_SERVICE_GROUP, v)
+}
+
+// BillingIdentification returns the billing_identification TLV.
+func (t TLVs) BillingIdentification() ([]byte, bool) {
+	return t.Octets(BILLING_IDENTIFICATION)
+}
+
+// SetBillingIdentification sets the billing_identification TLV.
+func (t *TLVs) SetBillingIdentification(v []byte) {
+	t.SetOctets(BILLING_IDENTIFICATION, v)
+}
+
+// SourceNetworkID returns the source_network_id TLV, or "" if it is absent.
+func (t TLVs) SourceNetworkID() string {
+	v, _ := t.CString(SOURCE_NETWORK_ID)
+	return v
+}
+
+// SetSourceNetworkID sets the source_network_id TLV.
+func (t *TLVs) SetSourceNetworkID(v string) {
+	t.SetCString(SOURCE_NETWORK_ID, v)
+}
+
+// DestNetworkID returns the dest_network_id TLV, or "" if it is absent.
+func (t TLVs) DestNetworkID() string {
+	v, _ := t.CString(DEST_NETWORK_ID)
+	return v
+}
+
+// SetDestNetworkID sets the dest_network_id TLV.
+func (t *TLVs) SetDestNetworkID(v string) {
+	t.SetCString(DEST_NETWORK_ID, v)
+}
+
+// SourceNodeID returns the source_node_id TLV.
+func (t TLVs) SourceNodeID() ([]byte, bool) {
+	return t.Octets(SOURCE_NODE_ID)
+}
+
+// SetSourceNodeID sets the source_node_id TLV.
+func (t *TLVs) SetSourceNodeID(v []byte) {
+	t.SetOctets(SOURCE_NODE_ID, v)
+}
+
+// DestNodeID returns the dest_node_id TLV.
+func (t TLVs) DestNodeID() ([]byte, bool) {
+	return t.Octets(DEST_NODE_ID)
+}
+
+// SetDestNodeID sets the dest_node_id TLV.
+func (t *TLVs) SetDestNodeID(v []byte) {
+	t.SetOctets(DEST_NODE_ID, v)
+}
+
+// DestAddrNpResolution returns the dest_addr_np_resolution TLV.
+func (t TLVs) DestAddrNpResolution() (uint8, bool) {
+	return t.Uint8(DEST_ADDR_NP_RESOLUTION)
+}
+
+// SetDestAddrNpResolution sets the dest_addr_np_resolution TLV.
+func (t *TLVs) SetDestAddrNpResolution(v uint8) {
+	t.SetUint8(DEST_ADDR_NP_RESOLUTION, v)
+}
+
+// DestAddrNpInformation returns the dest_addr_np_information TLV.
+func (t TLVs) DestAddrNpInformation() ([]byte, bool) {
+	return t.Octets(DEST_ADDR_NP_INFORMATION)
+}
+
+// SetDestAddrNpInformation sets the dest_addr_np_information TLV.
+func (t *TLVs) SetDestAddrNpInformation(v []byte) {
+	t.SetOctets(DEST_ADDR_NP_INFORMATION, v)
+}
+
+// DestAddrNpCountry returns the dest_addr_np_country TLV.
+func (t TLVs) DestAddrNpCountry() ([]byte, bool) {
+	return t.Octets(DEST_ADDR_NP_COUNTRY)
+}
+
+// SetDestAddrNpCountry sets the dest_addr_np_country TLV.
+func (t *TLVs) SetDestAddrNpCountry(v []byte) {
+	t.SetOctets(DEST_ADDR_NP_COUNTRY, v)
+}
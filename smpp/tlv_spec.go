@@ -0,0 +1,134 @@
+package smpp
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrInvalidTLVLength indicates that the length of a known TLV does not match its spec.
+var ErrInvalidTLVLength = errors.New("invalid tlv length")
+
+// TLVType is the type of the value of a TLV.
+type TLVType uint8
+
+const (
+	TLVTypeOctetString TLVType = iota
+	TLVTypeInteger
+	TLVTypeCString
+)
+
+// TLVSpec describes a TLV tag. Length is the length of the value, including the NULL of a C-Octet String.
+type TLVSpec struct {
+	Name      string
+	Type      TLVType
+	MinLength uint16
+	MaxLength uint16
+}
+
+var (
+	tlvSpecsMu sync.RWMutex
+	tlvSpecs   = map[uint16]TLVSpec{
+		DEST_ADDR_SUBUNIT:            {Name: "dest_addr_subunit", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		DEST_NETWORK_TYPE:            {Name: "dest_network_type", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		DEST_BEARER_TYPE:             {Name: "dest_bearer_type", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		DEST_TELEMATICS_ID:           {Name: "dest_telematics_id", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		SOURCE_ADDR_SUBUNIT:          {Name: "source_addr_subunit", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SOURCE_NETWORK_TYPE:          {Name: "source_network_type", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SOURCE_BEARER_TYPE:           {Name: "source_bearer_type", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SOURCE_TELEMATICS_ID:         {Name: "source_telematics_id", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		QOS_TIME_TO_LIVE:             {Name: "qos_time_to_live", Type: TLVTypeInteger, MinLength: 4, MaxLength: 4},
+		PAYLOAD_TYPE:                 {Name: "payload_type", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		ADDITIONAL_STATUS_INFO_TEXT:  {Name: "additional_status_info_text", Type: TLVTypeCString, MinLength: 1, MaxLength: 256},
+		RECEIPTED_MESSAGE_ID:         {Name: "receipted_message_id", Type: TLVTypeCString, MinLength: 1, MaxLength: 65},
+		MS_MSG_WAIT_FACILITIES:       {Name: "ms_msg_wait_facilities", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		PRIVACY_INDICATOR:            {Name: "privacy_indicator", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SOURCE_SUBADDRESS:            {Name: "source_subaddress", Type: TLVTypeOctetString, MinLength: 2, MaxLength: 23},
+		DEST_SUBADDRESS:              {Name: "dest_subaddress", Type: TLVTypeOctetString, MinLength: 2, MaxLength: 23},
+		USER_MESSAGE_REFERENCE:       {Name: "user_message_reference", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		USER_RESPONSE_CODE:           {Name: "user_response_code", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SOURCE_PORT:                  {Name: "source_port", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		DESTINATION_PORT:             {Name: "destination_port", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		SAR_MSG_REF_NUM:              {Name: "sar_msg_ref_num", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		LANGUAGE_INDICATOR:           {Name: "language_indicator", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SAR_TOTAL_SEGMENTS:           {Name: "sar_total_segments", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SAR_SEGMENT_SEQNUM:           {Name: "sar_segment_seqnum", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SC_INTERFACE_VERSION:         {Name: "sc_interface_version", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		CALLBACK_NUM_PRES_IND:        {Name: "callback_num_pres_ind", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		CALLBACK_NUM_ATAG:            {Name: "callback_num_atag", Type: TLVTypeOctetString, MinLength: 0, MaxLength: 65},
+		NUMBER_OF_MESSAGES:           {Name: "number_of_messages", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		CALLBACK_NUM:                 {Name: "callback_num", Type: TLVTypeOctetString, MinLength: 4, MaxLength: 19},
+		DPF_RESULT:                   {Name: "dpf_result", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SET_DPF:                      {Name: "set_dpf", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		MS_AVAILABILITY_STATUS:       {Name: "ms_availability_status", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		NETWORK_ERROR_CODE:           {Name: "network_error_code", Type: TLVTypeOctetString, MinLength: 3, MaxLength: 3},
+		MESSAGE_PAYLOAD:              {Name: "message_payload", Type: TLVTypeOctetString, MinLength: 0, MaxLength: 65535},
+		DELIVERY_FAILURE_REASON:      {Name: "delivery_failure_reason", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		MORE_MESSAGES_TO_SEND:        {Name: "more_messages_to_send", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		DR_MESSAGE_STATE:             {Name: "message_state", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		USSD_SERVICE_OP:              {Name: "ussd_service_op", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		DISPLAY_TIME:                 {Name: "display_time", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		SMS_SIGNAL:                   {Name: "sms_signal", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		MS_VALIDITY:                  {Name: "ms_validity", Type: TLVTypeOctetString, MinLength: 1, MaxLength: 4},
+		ALERT_ON_MESSAGE_DELIVERY:    {Name: "alert_on_message_delivery", Type: TLVTypeOctetString, MinLength: 0, MaxLength: 1},
+		ITS_REPLY_TYPE:               {Name: "its_reply_type", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		ITS_SESSION_INFO:             {Name: "its_session_info", Type: TLVTypeOctetString, MinLength: 2, MaxLength: 2},
+		CONGESTION_STATE:             {Name: "congestion_state", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		BROADCAST_CHANNEL_INDICATOR:  {Name: "broadcast_channel_indicator", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		BROADCAST_CONTENT_TYPE:       {Name: "broadcast_content_type", Type: TLVTypeOctetString, MinLength: 3, MaxLength: 3},
+		BROADCAST_CONTENT_TYPE_INFO:  {Name: "broadcast_content_type_info", Type: TLVTypeOctetString, MinLength: 0, MaxLength: 255},
+		BROADCAST_MESSAGE_CLASS:      {Name: "broadcast_message_class", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		BROADCAST_REP_NUM:            {Name: "broadcast_rep_num", Type: TLVTypeInteger, MinLength: 2, MaxLength: 2},
+		BROADCAST_FREQUENCY_INTERVAL: {Name: "broadcast_frequency_interval", Type: TLVTypeOctetString, MinLength: 3, MaxLength: 3},
+		BROADCAST_AREA_IDENTIFIER:    {Name: "broadcast_area_identifier", Type: TLVTypeOctetString, MinLength: 1, MaxLength: 101},
+		BROADCAST_ERROR_STATUS:       {Name: "broadcast_error_status", Type: TLVTypeInteger, MinLength: 4, MaxLength: 4},
+		BROADCAST_AREA_SUCCESS:       {Name: "broadcast_area_success", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		BROADCAST_END_TIME:           {Name: "broadcast_end_time", Type: TLVTypeCString, MinLength: 1, MaxLength: 17},
+		BROADCAST_SERVICE_GROUP:      {Name: "broadcast_service_group", Type: TLVTypeOctetString, MinLength: 1, MaxLength: 255},
+		BILLING_IDENTIFICATION:       {Name: "billing_identification", Type: TLVTypeOctetString, MinLength: 1, MaxLength: 1024},
+		SOURCE_NETWORK_ID:            {Name: "source_network_id", Type: TLVTypeCString, MinLength: 1, MaxLength: 65},
+		DEST_NETWORK_ID:              {Name: "dest_network_id", Type: TLVTypeCString, MinLength: 1, MaxLength: 65},
+		SOURCE_NODE_ID:               {Name: "source_node_id", Type: TLVTypeOctetString, MinLength: 6, MaxLength: 6},
+		DEST_NODE_ID:                 {Name: "dest_node_id", Type: TLVTypeOctetString, MinLength: 6, MaxLength: 6},
+		DEST_ADDR_NP_RESOLUTION:      {Name: "dest_addr_np_resolution", Type: TLVTypeInteger, MinLength: 1, MaxLength: 1},
+		DEST_ADDR_NP_INFORMATION:     {Name: "dest_addr_np_information", Type: TLVTypeOctetString, MinLength: 10, MaxLength: 10},
+		DEST_ADDR_NP_COUNTRY:         {Name: "dest_addr_np_country", Type: TLVTypeOctetString, MinLength: 1, MaxLength: 5},
+	}
+)
+
+// LookupTLVSpec returns the spec of a tag.
+func LookupTLVSpec(tag uint16) (TLVSpec, bool) {
+	tlvSpecsMu.RLock()
+	defer tlvSpecsMu.RUnlock()
+	spec, ok := tlvSpecs[tag]
+	return spec, ok
+}
+
+// RegisterTLVSpec registers or replaces the spec of a tag, e.g. for a vendor specific TLV.
+func RegisterTLVSpec(tag uint16, spec TLVSpec) {
+	tlvSpecsMu.Lock()
+	defer tlvSpecsMu.Unlock()
+	tlvSpecs[tag] = spec
+}
+
+// Validate checks the length of a known TLV. Unknown tags are always valid.
+func (t TLV) Validate() error {
+	spec, ok := LookupTLVSpec(t.Tag)
+	if !ok {
+		return nil
+	}
+	if t.Length < spec.MinLength || t.Length > spec.MaxLength {
+		return fmt.Errorf("%w: %s(%#04x) length %d, want %d..%d", ErrInvalidTLVLength, spec.Name, t.Tag, t.Length, spec.MinLength, spec.MaxLength)
+	}
+	return nil
+}
+
+// Validate checks the lengths of the known TLVs in order, it returns the first ErrInvalidTLVLength.
+func (t TLVs) Validate() error {
+	for _, tlv := range t.Sorted() {
+		if err := tlv.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}